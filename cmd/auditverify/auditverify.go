@@ -0,0 +1,103 @@
+// enc-server-go project main.go
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"log/slog"
+	"os"
+
+	client1 "enc-server-go/pkg/v1-sockets/be/client"
+	client2 "enc-server-go/pkg/v2-apis/be/client"
+
+	"enc-server-go/pkg/utils"
+)
+
+const configPath = "config/config.yaml"
+
+func main() {
+
+	// Comand line
+	var v2 bool
+	var auditFile, head string
+	flag.BoolVar(&v2, "v2", true, "Read back-end audit records in v2 mode")
+	flag.StringVar(&auditFile, "file", "", "Verify this audit log file rather than the configured log")
+	flag.StringVar(&head, "head", "", "Require the log to end at this entry hash, detecting truncation")
+	flag.Parse()
+
+	// Logging
+	logFile, err := utils.InitLogger("auditverify")
+	if err != nil {
+		utils.Fatal("Failed to start log", "error", err)
+	}
+	defer logFile.Close()
+	slog.Info("Started auditverify")
+
+	// Load configuration file.
+	configs, err := utils.LoadConfigs(configPath)
+	if err != nil {
+		utils.Fatal("Failed to load configs", "error", err)
+	}
+
+	// Verify required configurations.
+	if ok, missing := utils.VerifyTopConfigs(configs, []string{"feServerConfigs", "beClientConfigs"}); !ok {
+		utils.Fatal("Configuration missing", "config", missing)
+	}
+
+	// Apply the configured log level and redaction.
+	if err = utils.ConfigureLogger(configs["feServerConfigs"]); err != nil {
+		utils.Fatal("Failed to configure log", "error", err)
+	}
+	if auditFile == "" {
+		auditFile = configs["feServerConfigs"]["auditFile"]
+	}
+
+	// Read the audit log from its file, or from its back-end records.
+	var entries []utils.AuditEntry
+	if auditFile != "" {
+		slog.Info("Reading audit log", "file", auditFile)
+		file, err := os.Open(auditFile)
+		if err != nil {
+			utils.Fatal("Failed to open audit log", "error", err)
+		}
+		defer file.Close()
+		if entries, err = utils.ReadAuditLog(file); err != nil {
+			utils.Fatal("Failed to read audit log", "error", err)
+		}
+
+	} else {
+		id, err := hex.DecodeString(configs["feServerConfigs"]["auditRecordID"])
+		if err != nil || len(id) == 0 {
+			utils.Fatal("Configuration missing", "config", "auditFile or auditRecordID")
+		}
+
+		var c utils.ClientBE
+		if v2 {
+			slog.Info("Running in v2 mode")
+			c, err = client2.MakeClient(configs["beClientConfigs"])
+		} else {
+			slog.Info("Running in v1 mode")
+			c, err = client1.MakeClient(configs["beClientConfigs"])
+		}
+		if err != nil {
+			utils.Fatal("Failed to create client", "error", err)
+		}
+		if entries, err = utils.LoadAuditRecords(c, id); err != nil {
+			utils.Fatal("Failed to read audit records", "error", err)
+		}
+	}
+
+	// Verify the hash chain under the audit key, and its head when given.
+	key, err := utils.AuditKey([]byte(configs["feServerConfigs"]["idKeyStr"]))
+	if err != nil {
+		utils.Fatal("Failed to derive audit key", "error", err)
+	}
+	last, err := utils.VerifyAuditLog(entries, key)
+	if err != nil {
+		utils.Fatal("Audit log failed verification", "error", err)
+	}
+	if head != "" && last != head {
+		utils.Fatal("Audit log failed verification", "head", last, "expected", head)
+	}
+	slog.Info("Verified audit log", "entries", len(entries), "head", last)
+}
@@ -2,8 +2,9 @@
 package main
 
 import (
+	"bytes"
 	"flag"
-	"log"
+	"log/slog"
 
 	client1 "enc-server-go/pkg/v1-sockets/be/client"
 	client2 "enc-server-go/pkg/v2-apis/be/client"
@@ -23,20 +24,25 @@ func main() {
 	// Logging
 	logFile, err := utils.InitLogger("beclient")
 	if err != nil {
-		log.Fatalf("Failed to start log: %v", err)
+		utils.Fatal("Failed to start log", "error", err)
 	}
 	defer logFile.Close()
-	log.Println("Started beclient")
+	slog.Info("Started beclient")
 
 	// Load configuration file.
 	configs, err := utils.LoadConfigs(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load configs: %v", err)
+		utils.Fatal("Failed to load configs", "error", err)
 	}
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyTopConfigs(configs, []string{"testParams", "beClientConfigs"}); !ok {
-		log.Fatalf("Configuration missing: %s", missing)
+		utils.Fatal("Configuration missing", "config", missing)
+	}
+
+	// Apply the configured log level and redaction.
+	if err = utils.ConfigureLogger(configs["beClientConfigs"]); err != nil {
+		utils.Fatal("Failed to configure log", "error", err)
 	}
 
 	// Load test params
@@ -46,33 +52,35 @@ func main() {
 	// Make client.
 	var c utils.ClientBE
 	if v2 {
-		log.Println("Running in v2 mode")
+		slog.Info("Running in v2 mode")
 		c, err = client2.MakeClient(configs["beClientConfigs"])
 	} else {
-		log.Println("Running in v1 mode")
+		slog.Info("Running in v1 mode")
 		c, err = client1.MakeClient(configs["beClientConfigs"])
 	}
 	if err != nil {
-		log.Fatalf("Failed to create client: %v", err)
+		utils.Fatal("Failed to create client", "error", err)
 	}
 
 	// Store record.
-	log.Println("record", string(record))
-	if err = c.StoreRecord(id, record); err != nil {
-		log.Fatalf("Failed to store record: %v", err)
+	slog.Info("Storing record", "size", len(record))
+	info, err := c.StoreRecord(id, nil, record, utils.StoreOptions{})
+	if err != nil {
+		utils.Fatal("Failed to store record", "error", err)
 	}
+	slog.Info("Stored record", "version", info.Version)
 
 	// Retrieve record.
-	retrieved, err := c.RetrieveRecord(id)
+	retrieved, _, err := c.RetrieveRecord(id, nil, utils.RetrieveOptions{})
 	if err != nil {
-		log.Fatalf("Failed to retrieve record: %v", err)
+		utils.Fatal("Failed to retrieve record", "error", err)
 	}
-	log.Println("retrieved", retrieved)
+	slog.Info("Retrieved record", "size", len(retrieved), "matched", bytes.Equal(retrieved, record))
 
 	// Delete record.
-	err = c.DeleteRecord(id)
+	err = c.DeleteRecord(id, nil, utils.DeleteOptions{})
 	if err != nil {
-		log.Fatalf("Failed to delete record: %v", err)
+		utils.Fatal("Failed to delete record", "error", err)
 	}
-	log.Println("deleted record")
+	slog.Info("Deleted record")
 }
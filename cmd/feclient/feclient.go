@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"flag"
-	"log"
+	"log/slog"
 
+	"enc-server-go/pkg/shamir"
 	client1 "enc-server-go/pkg/v1-sockets/fe/client"
 	client2 "enc-server-go/pkg/v2-apis/fe/client"
 
@@ -15,27 +17,36 @@ const configPath = "config/config.yaml"
 func main() {
 
 	// Comand line
-	var v2 bool
+	var v2, e2e bool
+	var shares, threshold int
 	flag.BoolVar(&v2, "v2", true, "Run in v2 mode")
+	flag.BoolVar(&e2e, "e2e", false, "Seal records on the client end to end (v2 mode)")
+	flag.IntVar(&shares, "shares", 0, "Split the record key into this many shares (v2 mode)")
+	flag.IntVar(&threshold, "threshold", 2, "Number of key shares needed to retrieve the record")
 	flag.Parse()
 
 	// Logging
 	logFile, err := utils.InitLogger("feclient")
 	if err != nil {
-		log.Fatalf("Failed to start log: %v", err)
+		utils.Fatal("Failed to start log", "error", err)
 	}
 	defer logFile.Close()
-	log.Println("Started feclient")
+	slog.Info("Started feclient")
 
 	// Load configuration file.
 	configs, err := utils.LoadConfigs(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load configs: %v", err)
+		utils.Fatal("Failed to load configs", "error", err)
 	}
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyTopConfigs(configs, []string{"testParams", "feClientConfigs"}); !ok {
-		log.Fatalf("Configuration missing: %s", missing)
+		utils.Fatal("Configuration missing", "config", missing)
+	}
+
+	// Apply the configured log level and redaction.
+	if err = utils.ConfigureLogger(configs["feClientConfigs"]); err != nil {
+		utils.Fatal("Failed to configure log", "error", err)
 	}
 
 	// Set test params
@@ -45,34 +56,57 @@ func main() {
 	// Make client.
 	var c utils.ClientFE
 	if v2 {
-		log.Println("Running in v2 mode")
+		slog.Info("Running in v2 mode")
+		if e2e {
+			configs["feClientConfigs"]["e2e"] = "true"
+			if configs["feClientConfigs"]["keySize"] == "" {
+				configs["feClientConfigs"]["keySize"] = "32"
+			}
+		}
 		c, err = client2.MakeClient(configs["feClientConfigs"])
 	} else {
-		log.Println("Running in v1 mode")
+		slog.Info("Running in v1 mode")
 		c, err = client1.MakeClient(configs["feClientConfigs"])
 	}
 	if err != nil {
-		log.Fatalf("Failed to create client: %v", err)
+		utils.Fatal("Failed to create client", "error", err)
 	}
 
-	// Store record.
-	log.Println("record", string(record))
-	key, err := c.StoreRecord(id, record)
+	// Store record, splitting its key into shares if requested.
+	slog.Info("Storing record", "size", len(record))
+	var key []byte
+	var keyShares [][]byte
+	if shares > 0 {
+		keyShares, _, err = c.StoreRecordShares(id, nil, record, shares, threshold, utils.StoreOptions{})
+	} else {
+		key, _, err = c.StoreRecord(id, nil, record, utils.StoreOptions{})
+	}
 	if err != nil {
-		log.Fatalf("Failed to store record: %v", err)
+		utils.Fatal("Failed to store record", "error", err)
 	}
 
-	// Retrieve record.
-	retrieved, err := c.RetrieveRecord(id, key)
+	// Retrieve record, with a threshold of key shares if split.
+	var retrieved []byte
+	if shares > 0 {
+		retrieved, _, err = c.RetrieveRecordShares(id, nil, keyShares[:threshold])
+	} else {
+		retrieved, _, err = c.RetrieveRecord(id, nil, key)
+	}
 	if err != nil {
-		log.Fatalf("Failed to retrieve record: %v", err)
+		utils.Fatal("Failed to retrieve record", "error", err)
 	}
-	log.Println("retrieved", string(retrieved))
+	slog.Info("Retrieved record", "size", len(retrieved), "matched", bytes.Equal(retrieved, record))
 
-	// Delete record.
-	err = c.DeleteRecord(id)
+	// Delete record, proving ownership with its key. Split keys are
+	// recombined from the same threshold of shares.
+	if shares > 0 {
+		if key, err = shamir.Combine(keyShares[:threshold]); err != nil {
+			utils.Fatal("Failed to combine key shares", "error", err)
+		}
+	}
+	err = c.DeleteRecord(id, nil, key, utils.DeleteOptions{})
 	if err != nil {
-		log.Fatalf("Failed to delete record: %v", err)
+		utils.Fatal("Failed to delete record", "error", err)
 	}
-	log.Println("deleted record")
+	slog.Info("Deleted record")
 }
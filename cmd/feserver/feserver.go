@@ -3,7 +3,7 @@ package main
 
 import (
 	"flag"
-	"log"
+	"log/slog"
 
 	server1 "enc-server-go/pkg/v1-sockets/fe/server"
 	server2 "enc-server-go/pkg/v2-apis/fe/server"
@@ -23,37 +23,49 @@ func main() {
 	// Logging
 	logFile, err := utils.InitLogger("feserver")
 	if err != nil {
-		log.Fatalf("Failed to start log: %v", err)
+		utils.Fatal("Failed to start log", "error", err)
 	}
 	defer logFile.Close()
-	log.Println("Started feserver")
+	slog.Info("Started feserver")
 
 	// Load configuration file.
 	configs, err := utils.LoadConfigs(configPath)
 	if err != nil {
-		log.Fatalf("Failed to load configs: %v", err)
+		utils.Fatal("Failed to load configs", "error", err)
 	}
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyTopConfigs(configs, []string{"feServerConfigs", "beClientConfigs"}); !ok {
-		log.Fatalf("Configuration missing: %s", missing)
+		utils.Fatal("Configuration missing", "config", missing)
 	}
 
+	// Apply the configured log level and redaction.
+	if err = utils.ConfigureLogger(configs["feServerConfigs"]); err != nil {
+		utils.Fatal("Failed to configure log", "error", err)
+	}
+
+	// Tracing
+	shutdownTracer, err := utils.InitTracer("feserver", configs["feServerConfigs"])
+	if err != nil {
+		utils.Fatal("Failed to start tracing", "error", err)
+	}
+	defer shutdownTracer()
+
 	// Make server.
 	var s utils.Server
 	if v2 {
-		log.Println("Running in v2 mode")
+		slog.Info("Running in v2 mode")
 		s, err = server2.MakeServer(configs["feServerConfigs"], configs["beClientConfigs"])
 
 	} else {
-		log.Println("Running in v1 mode")
+		slog.Info("Running in v1 mode")
 		s, err = server1.MakeServer(configs["feServerConfigs"], configs["beClientConfigs"])
 	}
 	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
+		utils.Fatal("Failed to create server", "error", err)
 	}
 
 	if err = s.Start(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		utils.Fatal("Failed to start server", "error", err)
 	}
 }
@@ -0,0 +1,125 @@
+// shamir
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// Bounds on the number of shares a secret is split into. Shares are indexed
+// by a non-zero byte, and a single share would hold the secret in the clear.
+const (
+	MinShares = 2
+	MaxShares = 255
+)
+
+// Returned when shares or threshold fall outside the supported bounds.
+var ErrInvalidThreshold = errors.New("Threshold must be at least 2 and at most the number of shares, at most 255")
+
+// Returned when a secret to split is empty.
+var ErrEmptySecret = errors.New("Cannot split an empty secret")
+
+// Returned when shares to combine are too few, malformed or duplicated.
+var ErrInvalidShares = errors.New("Shares must number at least 2, be of equal length and have distinct indexes")
+
+// Split splits a secret into n shares, any m of which reconstruct it. Each
+// share is the share index followed by one polynomial evaluation per secret
+// byte over GF(2^8). Fewer than m shares reveal nothing of the secret.
+func Split(secret []byte, n, m int) (shares [][]byte, err error) {
+
+	// Verify share counts and secret.
+	if m < MinShares || m > n || n > MaxShares {
+		return nil, ErrInvalidThreshold
+	}
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+
+	// Shares are indexed from 1; the secret is the polynomial at 0.
+	shares = make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][0] = byte(i + 1)
+	}
+
+	// Draw a random polynomial of degree m-1 per secret byte, with the
+	// secret byte as its constant term, and evaluate it for each share.
+	coeffs := make([]byte, m)
+	for j, b := range secret {
+		if _, err = rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+		coeffs[0] = b
+		for _, share := range shares {
+			share[j+1] = evaluate(coeffs, share[0])
+		}
+	}
+	clear(coeffs)
+
+	return shares, nil
+}
+
+// Combine reconstructs a secret from shares produced by Split. Given fewer
+// shares than the split threshold, it returns an unrelated value rather than
+// an error, so callers should verify the result, such as by opening a record.
+func Combine(shares [][]byte) (secret []byte, err error) {
+
+	// Verify shares are alike and distinctly indexed.
+	if len(shares) < MinShares || len(shares) > MaxShares {
+		return nil, ErrInvalidShares
+	}
+	size := len(shares[0])
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if len(share) != size || size < 2 || share[0] == 0 || seen[share[0]] {
+			return nil, ErrInvalidShares
+		}
+		seen[share[0]] = true
+	}
+
+	// Interpolate each secret byte at 0 using the Lagrange basis
+	// polynomials of the share indexes.
+	secret = make([]byte, size-1)
+	for i, share := range shares {
+		basis := byte(1)
+		for k, other := range shares {
+			if k != i {
+				basis = mul(basis, div(other[0], other[0]^share[0]))
+			}
+		}
+		for j := range secret {
+			secret[j] ^= mul(basis, share[j+1])
+		}
+	}
+
+	return secret, nil
+}
+
+// evaluate evaluates a polynomial at x by Horner's method.
+func evaluate(coeffs []byte, x byte) (y byte) {
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coeffs[i]
+	}
+	return y
+}
+
+// mul multiplies in GF(2^8) modulo the AES polynomial x^8+x^4+x^3+x+1,
+// without branching on secret values.
+func mul(a, b byte) (p byte) {
+	for range 8 {
+		p ^= a & -(b & 1)
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// div divides in GF(2^8), multiplying by the inverse b^254. Divisors are
+// distinct share indexes, never zero.
+func div(a, b byte) byte {
+	inv := b
+	for range 6 {
+		inv = mul(mul(inv, inv), b)
+	}
+	return mul(a, mul(inv, inv))
+}
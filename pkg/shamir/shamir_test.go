@@ -0,0 +1,167 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secretStr = "vkAZAarLbZ6w0kmL2HJP3eU1ODCgVj4k"
+
+var secret = []byte(secretStr)
+
+// Split() - Test Method
+func TestShamir_Split(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  []byte
+		n       int
+		m       int
+		wantErr error
+	}{
+		{
+			name:   "should split 2 of 3 successfully",
+			secret: secret,
+			n:      3,
+			m:      2,
+		},
+		{
+			name:   "should split 5 of 5 successfully",
+			secret: secret,
+			n:      5,
+			m:      5,
+		},
+		{
+			name:   "should split 3 of 255 successfully",
+			secret: secret,
+			n:      MaxShares,
+			m:      3,
+		},
+		{
+			name:    "should fail with threshold of 1",
+			secret:  secret,
+			n:       3,
+			m:       1,
+			wantErr: ErrInvalidThreshold,
+		},
+		{
+			name:    "should fail with threshold above shares",
+			secret:  secret,
+			n:       3,
+			m:       4,
+			wantErr: ErrInvalidThreshold,
+		},
+		{
+			name:    "should fail with too many shares",
+			secret:  secret,
+			n:       MaxShares + 1,
+			m:       3,
+			wantErr: ErrInvalidThreshold,
+		},
+		{
+			name:    "should fail with empty secret",
+			n:       3,
+			m:       2,
+			wantErr: ErrEmptySecret,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shares, err := Split(test.secret, test.n, test.m)
+
+			if test.wantErr != nil {
+				assert.Equal(t, test.wantErr, err)
+				assert.Nil(t, shares)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, shares, test.n)
+			for i, share := range shares {
+				assert.Len(t, share, len(test.secret)+1)
+				assert.Equal(t, byte(i+1), share[0])
+				assert.False(t, bytes.Contains(share, test.secret))
+			}
+
+			// Any threshold of shares reconstructs the secret.
+			got, err := Combine(shares[:test.m])
+			assert.NoError(t, err)
+			assert.Equal(t, test.secret, got)
+			got, err = Combine(shares[test.n-test.m:])
+			assert.NoError(t, err)
+			assert.Equal(t, test.secret, got)
+
+			// Fewer shares do not.
+			got, err = Combine(shares[:test.m-1])
+			if test.m-1 >= MinShares {
+				assert.NoError(t, err)
+				assert.NotEqual(t, test.secret, got)
+			} else {
+				assert.Equal(t, ErrInvalidShares, err)
+			}
+		})
+	}
+}
+
+// Combine() - Test Method
+func TestShamir_Combine(t *testing.T) {
+	shares, _ := Split(secret, 5, 3)
+
+	tests := []struct {
+		name    string
+		shares  [][]byte
+		want    []byte
+		wantErr error
+	}{
+		{
+			name:   "should combine threshold shares successfully",
+			shares: [][]byte{shares[4], shares[0], shares[2]},
+			want:   secret,
+		},
+		{
+			name:   "should combine all shares successfully",
+			shares: shares,
+			want:   secret,
+		},
+		{
+			name:    "should fail with a single share",
+			shares:  shares[:1],
+			wantErr: ErrInvalidShares,
+		},
+		{
+			name:    "should fail with duplicate shares",
+			shares:  [][]byte{shares[0], shares[1], shares[0]},
+			wantErr: ErrInvalidShares,
+		},
+		{
+			name:    "should fail with shares of unequal length",
+			shares:  [][]byte{shares[0], shares[1], shares[2][:8]},
+			wantErr: ErrInvalidShares,
+		},
+		{
+			name:    "should fail with zero share index",
+			shares:  [][]byte{shares[0], shares[1], append([]byte{0}, shares[2][1:]...)},
+			wantErr: ErrInvalidShares,
+		},
+		{
+			name:    "should fail with empty shares",
+			shares:  [][]byte{{1}, {2}},
+			wantErr: ErrInvalidShares,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Combine(test.shares)
+
+			if test.wantErr != nil {
+				assert.Equal(t, test.wantErr, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Record operations written to the audit log. Rotations add or remove the
+// key slots of a record.
+const (
+	AuditStore    = "store"
+	AuditRetrieve = "retrieve"
+	AuditDelete   = "delete"
+	AuditRotate   = "rotate"
+)
+
+// Outcome of audited operations that succeed. Failures record their error.
+const AuditOK = "ok"
+
+// Width of the zero-padded sequence numbers naming audit records, so they
+// list in sequence order.
+const auditNameWidth = 20
+
+// Info labelling the derivation of audit keys from the ID key.
+const auditKeyInfo = "enc-server-go audit-log"
+
+// Returned when an audit log was appended to by another process since its
+// head was read.
+var errAuditConflict = errors.New("Audit log head has moved")
+
+// Record operation to audit: who performed it, on which record and with what
+// outcome.
+type AuditEvent struct {
+	Operation string `json:"op"`
+	Principal string `json:"principal,omitempty"`
+	RecordID  string `json:"record,omitempty"`
+	Outcome   string `json:"outcome"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// Audit log entry. Each entry holds the hash of the entry before it, and its
+// own HMAC over its fields, so altered, removed or reordered entries break
+// the chain, and it cannot be rewritten without the audit key.
+type AuditEntry struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	AuditEvent
+	Prev string `json:"prev"`
+	Hash string `json:"hash,omitempty"`
+}
+
+// AuditRecordID derives the ID of a record written to the audit log from its
+// sealed ID and name, so the log does not reveal user IDs or record names.
+func AuditRecordID(idEncrypt, nameEncrypt []byte) (recordID string) {
+	h := sha256.New()
+	h.Write(idEncrypt)
+	h.Write([]byte{0})
+	h.Write(nameEncrypt)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AuditKey derives the key of audit log hashes from the ID key, so the log
+// is verified without the ID cipher key being reused.
+func AuditKey(idKey []byte) (auditKey []byte, err error) {
+	return hkdf.Key(sha256.New, idKey, nil, auditKeyInfo, sha256.Size)
+}
+
+// HMAC of an entry over its fields other than the hash itself.
+func (e AuditEntry) digest(key []byte) (hash string) {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type Auditor interface {
+
+	// Appends an event to the audit log, chained to the entry before it.
+	Record(event AuditEvent) (err error)
+
+	// Reports whether events are audited.
+	Enabled() bool
+}
+
+// Append-only store of audit entries, failing appends that do not follow the
+// last entry when shared with other processes.
+type auditSink interface {
+	append(entry AuditEntry) (err error)
+
+	// Returns the last entry appended, or nil for an empty log.
+	last() (entry *AuditEntry, err error)
+}
+
+type auditorImpl struct {
+	sink auditSink
+	key  []byte
+
+	mu     sync.Mutex
+	head   *AuditEntry
+	loaded bool
+}
+
+func (a *auditorImpl) Record(event AuditEvent) (err error) {
+	if a.sink == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Other processes may share the log, so a failed append reloads the head
+	// and is tried once more.
+	entry := AuditEntry{Time: time.Now().UTC(), AuditEvent: event}
+	if err = a.append(&entry); err != nil {
+		err = a.append(&entry)
+	}
+	return err
+}
+
+// Chains an entry to the head, resuming from the last entry in the log when
+// the head is not loaded, and appends it.
+func (a *auditorImpl) append(entry *AuditEntry) (err error) {
+	if !a.loaded {
+		if a.head, err = a.sink.last(); err != nil {
+			return err
+		}
+		a.loaded = true
+	}
+
+	entry.Seq, entry.Prev = 1, ""
+	if a.head != nil {
+		entry.Seq, entry.Prev = a.head.Seq+1, a.head.Hash
+	}
+	entry.Hash = entry.digest(a.key)
+	if err = a.sink.append(*entry); err != nil {
+		a.loaded = false
+		return err
+	}
+	a.head = entry
+	return nil
+}
+
+func (a *auditorImpl) Enabled() bool {
+	return a.sink != nil
+}
+
+// Audit log kept in a local file, one JSON entry per line. Appends and reads
+// lock the file, and appends fail should it have grown since the head was
+// read.
+type auditFile struct {
+	path string
+	size int64
+}
+
+func (f *auditFile) append(entry AuditEntry) (err error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() != f.size {
+		return errAuditConflict
+	}
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	f.size = info.Size() + int64(len(line)) + 1
+	return file.Sync()
+}
+
+func (f *auditFile) last() (entry *AuditEntry, err error) {
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		f.size = 0
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if err = syscall.Flock(int(file.Fd()), syscall.LOCK_SH); err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ReadAuditLog(file)
+	if err != nil {
+		return nil, err
+	}
+	f.size = info.Size()
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// Audit log kept as back-end records under one user ID, named by sequence
+// number. Entries are stored create-only, so none is overwritten.
+type auditRecords struct {
+	beClient ClientBE
+	id       []byte
+}
+
+func auditRecordName(seq uint64) (name []byte) {
+	s := strconv.FormatUint(seq, 10)
+	return append(bytes.Repeat([]byte("0"), auditNameWidth-len(s)), s...)
+}
+
+func (r *auditRecords) append(entry AuditEntry) (err error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	opts := StoreOptions{Match: Precondition{IfNotExists: true}, ContentType: "application/json"}
+	_, err = r.beClient.StoreRecord(r.id, auditRecordName(entry.Seq), data, opts)
+	return err
+}
+
+func (r *auditRecords) last() (entry *AuditEntry, err error) {
+
+	// Page through the log for its last record name.
+	var name []byte
+	opts := ListOptions{}
+	for {
+		records, next, err := r.beClient.ListRecords(r.id, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			name = records[len(records)-1].Name
+		}
+		if next == "" {
+			break
+		}
+		opts.Cursor = next
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	return r.entry(name)
+}
+
+func (r *auditRecords) entry(name []byte) (entry *AuditEntry, err error) {
+	data, _, err := r.beClient.RetrieveRecord(r.id, name, RetrieveOptions{})
+	if err != nil {
+		return nil, err
+	}
+	entry = &AuditEntry{}
+	if err = json.Unmarshal(data, entry); err != nil {
+		return nil, errors.New("Error decoding audit record " + string(name) + ": " + err.Error())
+	}
+	return entry, nil
+}
+
+// ReadAuditLog decodes the entries of an audit log file.
+func ReadAuditLog(r io.Reader) (entries []AuditEntry, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.New("Error decoding audit log line " + strconv.Itoa(line) + ": " + err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// LoadAuditRecords reads the entries of an audit log kept as back-end
+// records under a user ID.
+func LoadAuditRecords(beClient ClientBE, id []byte) (entries []AuditEntry, err error) {
+	r := &auditRecords{beClient: beClient, id: id}
+
+	opts := ListOptions{}
+	for {
+		records, next, err := beClient.ListRecords(id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			entry, err := r.entry(record.Name)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, *entry)
+		}
+		if next == "" {
+			return entries, nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// VerifyAuditLog checks the entries of an audit log number from one without
+// gaps, each holds its own HMAC under the audit key and each chains to the
+// entry before it. The hash of the last entry is returned, to compare against
+// a copy kept apart from the log so truncation is detected too.
+func VerifyAuditLog(entries []AuditEntry, key []byte) (head string, err error) {
+	for i, entry := range entries {
+		seq := strconv.FormatUint(entry.Seq, 10)
+		if entry.Seq != uint64(i)+1 {
+			return "", errors.New("Audit log has a gap before entry " + seq)
+		}
+		if !hmac.Equal([]byte(entry.Hash), []byte(entry.digest(key))) {
+			return "", errors.New("Audit log entry " + seq + " has been altered")
+		}
+		if entry.Prev != head {
+			return "", errors.New("Audit log chain is broken at entry " + seq)
+		}
+		head = entry.Hash
+	}
+	return head, nil
+}
+
+// MakeAuditor appends audit entries to the local file at auditFile, or to
+// back-end records under the hex-encoded user ID auditRecordID through
+// beClient, hashed under a key derived from idKeyStr. Without either setting
+// events are not audited.
+func MakeAuditor(configs map[string]string, beClient ClientBE) (a Auditor, err error) {
+	impl := &auditorImpl{}
+
+	if configs["auditFile"] != "" && configs["auditRecordID"] != "" {
+		err = errors.New("MakeAuditor cannot be configured with both auditFile and auditRecordID")
+		return nil, err
+	}
+
+	if path := configs["auditFile"]; path != "" {
+		impl.sink = &auditFile{path: path}
+	}
+
+	if idStr := configs["auditRecordID"]; idStr != "" {
+		id, err := hex.DecodeString(idStr)
+		if err != nil || len(id) == 0 {
+			return nil, errors.New("MakeAuditor cannot be configured with invalid auditRecordID")
+		}
+		impl.sink = &auditRecords{beClient: beClient, id: id}
+	}
+
+	if impl.sink != nil {
+		if configs["idKeyStr"] == "" {
+			return nil, errors.New("MakeAuditor missing configuration idKeyStr")
+		}
+		if impl.key, err = AuditKey([]byte(configs["idKeyStr"])); err != nil {
+			return nil, err
+		}
+	}
+
+	return impl, nil
+}
@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Constants
+const auditIDKeyStr = "vkAZAarLbZ6w0kmL2HJP3eU1ODCgVj4k"
+
+// Audit sink kept in memory, refusing entries that do not follow the last as
+// back-end records stored create-only do, and failing its first appends when
+// set to.
+type memoryAuditSink struct {
+	entries  *[]AuditEntry
+	failures int
+}
+
+func (m *memoryAuditSink) append(entry AuditEntry) (err error) {
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("Audit sink unavailable")
+	}
+	if entry.Seq != uint64(len(*m.entries))+1 {
+		return ErrPreconditionFailed
+	}
+	*m.entries = append(*m.entries, entry)
+	return nil
+}
+
+func (m *memoryAuditSink) last() (entry *AuditEntry, err error) {
+	if len(*m.entries) == 0 {
+		return nil, nil
+	}
+	return &(*m.entries)[len(*m.entries)-1], nil
+}
+
+// Record() - Test Method
+func TestAudit_Record(t *testing.T) {
+	key, _ := AuditKey([]byte(auditIDKeyStr))
+
+	tests := []struct {
+		name     string
+		file     bool
+		failures int
+		writers  []int
+		wantErrs []bool
+	}{
+		{
+			name:     "should chain entries of one auditor",
+			writers:  []int{0, 0, 0},
+			wantErrs: []bool{false, false, false},
+		},
+		{
+			name:     "should chain entries of auditors sharing records",
+			writers:  []int{0, 1, 0, 0, 1},
+			wantErrs: []bool{false, false, false, false, false},
+		},
+		{
+			name:     "should chain entries of auditors sharing a file",
+			file:     true,
+			writers:  []int{0, 1, 0, 0, 1},
+			wantErrs: []bool{false, false, false, false, false},
+		},
+		{
+			name:     "should retry a failed append",
+			failures: 1,
+			writers:  []int{0, 0},
+			wantErrs: []bool{false, false},
+		},
+		{
+			name:     "should recover once appends succeed again",
+			failures: 2,
+			writers:  []int{0, 0, 0},
+			wantErrs: []bool{true, false, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var entries []AuditEntry
+			path := filepath.Join(t.TempDir(), "audit.log")
+			sink := func(failures int) auditSink {
+				if test.file {
+					return &auditFile{path: path}
+				}
+				return &memoryAuditSink{entries: &entries, failures: failures}
+			}
+			auditors := []*auditorImpl{{sink: sink(test.failures), key: key}, {sink: sink(0), key: key}}
+
+			for i, writer := range test.writers {
+				err := auditors[writer].Record(AuditEvent{Operation: AuditStore, Outcome: AuditOK})
+				assert.Equal(t, test.wantErrs[i], err != nil)
+			}
+
+			if test.file {
+				file, err := os.Open(path)
+				assert.NoError(t, err)
+				defer file.Close()
+				entries, err = ReadAuditLog(file)
+				assert.NoError(t, err)
+			}
+			wantLen := 0
+			for _, wantErr := range test.wantErrs {
+				if !wantErr {
+					wantLen++
+				}
+			}
+			assert.Len(t, entries, wantLen)
+			_, err := VerifyAuditLog(entries, key)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// VerifyAuditLog() - Test Method
+func TestAudit_VerifyAuditLog(t *testing.T) {
+	key, _ := AuditKey([]byte(auditIDKeyStr))
+	otherKey, _ := AuditKey([]byte("other-id-key"))
+
+	// Builds a log of three entries chained under a key.
+	chain := func(key []byte) []AuditEntry {
+		var entries []AuditEntry
+		a := &auditorImpl{sink: &memoryAuditSink{entries: &entries}, key: key}
+		for _, op := range []string{AuditStore, AuditRetrieve, AuditDelete} {
+			_ = a.Record(AuditEvent{Operation: op, Outcome: AuditOK})
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name    string
+		entries func() []AuditEntry
+		wantErr error
+	}{
+		{
+			name:    "should verify an intact log",
+			entries: func() []AuditEntry { return chain(key) },
+		},
+		{
+			name: "should fail an altered entry",
+			entries: func() []AuditEntry {
+				entries := chain(key)
+				entries[1].Outcome = "altered"
+				return entries
+			},
+			wantErr: errors.New("Audit log entry 2 has been altered"),
+		},
+		{
+			name:    "should fail a log rewritten without the audit key",
+			entries: func() []AuditEntry { return chain(otherKey) },
+			wantErr: errors.New("Audit log entry 1 has been altered"),
+		},
+		{
+			name: "should fail a removed entry",
+			entries: func() []AuditEntry {
+				entries := chain(key)
+				return append(entries[:1], entries[2:]...)
+			},
+			wantErr: errors.New("Audit log has a gap before entry 3"),
+		},
+		{
+			name: "should fail a broken chain",
+			entries: func() []AuditEntry {
+				entries := chain(key)
+				entries[1].Prev = ""
+				entries[1].Hash = entries[1].digest(key)
+				return entries
+			},
+			wantErr: errors.New("Audit log chain is broken at entry 2"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := VerifyAuditLog(test.entries(), key)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// MakeAuditor() - Test Method
+func TestAudit_MakeAuditor(t *testing.T) {
+	auditFilePath := filepath.Join(t.TempDir(), "audit.log")
+
+	tests := []struct {
+		name        string
+		configs     map[string]string
+		wantEnabled bool
+		wantErr     error
+	}{
+		{
+			name:    "should not audit without a log",
+			configs: map[string]string{},
+		},
+		{
+			name:        "should audit to a file",
+			configs:     map[string]string{"auditFile": auditFilePath, "idKeyStr": auditIDKeyStr},
+			wantEnabled: true,
+		},
+		{
+			name:    "should fail without an ID key",
+			configs: map[string]string{"auditFile": auditFilePath},
+			wantErr: errors.New("MakeAuditor missing configuration idKeyStr"),
+		},
+		{
+			name:    "should fail with both logs",
+			configs: map[string]string{"auditFile": auditFilePath, "auditRecordID": "4a5448", "idKeyStr": auditIDKeyStr},
+			wantErr: errors.New("MakeAuditor cannot be configured with both auditFile and auditRecordID"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := MakeAuditor(test.configs, nil)
+			assert.Equal(t, test.wantErr, err)
+			if err == nil {
+				assert.Equal(t, test.wantEnabled, a.Enabled())
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Scopes granted to API keys. Admin keys hold every scope.
+const (
+	ScopeStore    = "store"
+	ScopeRetrieve = "retrieve"
+	ScopeDelete   = "delete"
+	ScopeAdmin    = "admin"
+)
+
+var scopes = []string{ScopeStore, ScopeRetrieve, ScopeDelete, ScopeAdmin}
+
+// How long loaded API keys are trusted before being reloaded, so revoked keys
+// stop working without a restart.
+const defaultAPIKeysTTL = time.Minute
+
+// Returned when a request carries no API key, or one that is not known.
+var ErrUnauthenticated = errors.New("Missing or unknown API key")
+
+// Returned when an API key does not hold the scope a request requires.
+var ErrForbidden = errors.New("API key lacks the required scope")
+
+// API key of a named client. Only the SHA-256 of the key is kept, so a leaked
+// key list does not leak keys.
+type APIKey struct {
+	Name   string   `json:"name"`
+	Hash   string   `json:"hash"`
+	Scopes []string `json:"scopes"`
+}
+
+type Authenticator interface {
+
+	// Authenticates a presented API key, returning the name of the client
+	// holding it. Any key is accepted when authentication is off.
+	Authenticate(apiKey string) (client string, err error)
+
+	// Authorizes a presented API key for a scope, returning the name of the
+	// client holding it. Any key is authorized when authentication is off.
+	Authorize(apiKey, scope string) (client string, err error)
+
+	// Reports whether requests must present an API key.
+	Enabled() bool
+}
+
+type authenticatorImpl struct {
+	load func() ([]byte, error)
+	ttl  time.Duration
+
+	mu     sync.Mutex
+	keys   map[string]APIKey
+	loaded time.Time
+}
+
+// HashAPIKey returns the hex SHA-256 of an API key, as held in API key lists.
+func HashAPIKey(apiKey string) (hash string) {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKeys decodes a JSON list of API keys, indexed by key hash.
+func ParseAPIKeys(data []byte) (keys map[string]APIKey, err error) {
+	var list []APIKey
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, errors.New("Error decoding API keys: " + err.Error())
+	}
+
+	keys = make(map[string]APIKey, len(list))
+	for _, key := range list {
+		if hash, err := hex.DecodeString(key.Hash); err != nil || len(hash) != sha256.Size {
+			return nil, errors.New("API key " + key.Name + " must have a hex SHA-256 hash")
+		}
+		for _, scope := range key.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return nil, errors.New("API key " + key.Name + " has unknown scope " + scope)
+			}
+		}
+		keys[key.Hash] = key
+	}
+	return keys, nil
+}
+
+func (a *authenticatorImpl) loadKeys() (keys map[string]APIKey, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Keys are loaded on first use and once their TTL expires. Expired keys
+	// are not trusted while reloading fails, so a revoked key is never revived.
+	if a.keys == nil || time.Since(a.loaded) >= a.ttl {
+		data, err := a.load()
+		if err != nil {
+			return nil, errors.New("Error loading API keys: " + err.Error())
+		}
+		keys, err := ParseAPIKeys(data)
+		if err != nil {
+			return nil, err
+		}
+		a.keys, a.loaded = keys, time.Now()
+	}
+	return a.keys, nil
+}
+
+func (a *authenticatorImpl) lookup(apiKey string) (key APIKey, err error) {
+	keys, err := a.loadKeys()
+	if err != nil {
+		return key, err
+	}
+
+	key, ok := keys[HashAPIKey(apiKey)]
+	if apiKey == "" || !ok {
+		return key, ErrUnauthenticated
+	}
+	return key, nil
+}
+
+func (a *authenticatorImpl) Authenticate(apiKey string) (client string, err error) {
+	if a.load == nil {
+		return "", nil
+	}
+	key, err := a.lookup(apiKey)
+	if err != nil {
+		return "", err
+	}
+	return key.Name, nil
+}
+
+func (a *authenticatorImpl) Authorize(apiKey, scope string) (client string, err error) {
+	if a.load == nil {
+		return "", nil
+	}
+	key, err := a.lookup(apiKey)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(key.Scopes, scope) && !slices.Contains(key.Scopes, ScopeAdmin) {
+		return key.Name, ErrForbidden
+	}
+	return key.Name, nil
+}
+
+func (a *authenticatorImpl) Enabled() bool {
+	return a.load != nil
+}
+
+// MakeAuthenticator loads API keys from the JSON file at apiKeysFile, or from
+// the record of the hex user ID apiKeysRecord under the hex record key
+// apiKeysRecordKey, read with loadRecord. Keys are reloaded every apiKeysTTL.
+// Without either, authentication is off.
+func MakeAuthenticator(configs map[string]string,
+	loadRecord func(id, key []byte) (record []byte, err error)) (a Authenticator, err error) {
+	impl := &authenticatorImpl{ttl: defaultAPIKeysTTL}
+	if val, ok := configs["apiKeysTTL"]; ok {
+		if impl.ttl, err = time.ParseDuration(val); err != nil || impl.ttl <= 0 {
+			return nil, errors.New("MakeAuthenticator cannot be configured with invalid apiKeysTTL " + val)
+		}
+	}
+
+	fileStr, recordStr := configs["apiKeysFile"], configs["apiKeysRecord"]
+	switch {
+	case fileStr != "" && recordStr != "":
+		err = errors.New("MakeAuthenticator cannot be configured with both apiKeysFile and apiKeysRecord")
+		return nil, err
+
+	case fileStr != "":
+
+		// Key files are loaded upfront, so a bad file fails startup.
+		impl.load = func() ([]byte, error) {
+			return os.ReadFile(fileStr)
+		}
+		if _, err = impl.loadKeys(); err != nil {
+			return nil, err
+		}
+
+	case recordStr != "":
+
+		// Key records are loaded once the back-end service is reachable.
+		id, err := hex.DecodeString(recordStr)
+		if err != nil {
+			err = errors.New("MakeAuthenticator cannot be configured with invalid apiKeysRecord")
+			return nil, err
+		}
+		key, err := hex.DecodeString(configs["apiKeysRecordKey"])
+		if err != nil || len(key) == 0 {
+			err = errors.New("MakeAuthenticator cannot be configured with invalid apiKeysRecordKey")
+			return nil, err
+		}
+		impl.load = func() ([]byte, error) {
+			return loadRecord(id, key)
+		}
+	}
+
+	return impl, nil
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Constants
+const (
+	authAPIKeyStr      = "ci-api-key"
+	authOtherAPIKeyStr = "ops-api-key"
+)
+
+// Key list holding the given API keys with the store scope.
+func authKeyList(apiKeys ...string) []byte {
+	list := "["
+	for i, apiKey := range apiKeys {
+		if i > 0 {
+			list += ","
+		}
+		list += `{"name": "` + apiKey + `", "hash": "` + HashAPIKey(apiKey) + `", "scopes": ["store"]}`
+	}
+	return []byte(list + "]")
+}
+
+// Authorize() - Test Method
+func TestAuth_Authorize(t *testing.T) {
+	loadErr := errors.New("Back-end service unavailable")
+
+	type step struct {
+		list    []byte
+		loadErr error
+		expire  bool
+		apiKey  string
+		wantErr error
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "should authorize a listed key",
+			steps: []step{
+				{list: authKeyList(authAPIKeyStr), apiKey: authAPIKeyStr},
+			},
+		},
+		{
+			name: "should keep a revoked key until the keys expire",
+			steps: []step{
+				{list: authKeyList(authAPIKeyStr, authOtherAPIKeyStr), apiKey: authAPIKeyStr},
+				{list: authKeyList(authOtherAPIKeyStr), apiKey: authAPIKeyStr},
+			},
+		},
+		{
+			name: "should refuse a revoked key once the keys expire",
+			steps: []step{
+				{list: authKeyList(authAPIKeyStr, authOtherAPIKeyStr), apiKey: authAPIKeyStr},
+				{list: authKeyList(authOtherAPIKeyStr), expire: true, apiKey: authAPIKeyStr, wantErr: ErrUnauthenticated},
+				{list: authKeyList(authOtherAPIKeyStr), apiKey: authOtherAPIKeyStr},
+			},
+		},
+		{
+			name: "should authorize an added key once the keys expire",
+			steps: []step{
+				{list: authKeyList(authOtherAPIKeyStr), apiKey: authAPIKeyStr, wantErr: ErrUnauthenticated},
+				{list: authKeyList(authAPIKeyStr), expire: true, apiKey: authAPIKeyStr},
+			},
+		},
+		{
+			name: "should refuse expired keys while they fail to reload",
+			steps: []step{
+				{list: authKeyList(authAPIKeyStr), apiKey: authAPIKeyStr},
+				{loadErr: loadErr, expire: true, apiKey: authAPIKeyStr,
+					wantErr: errors.New("Error loading API keys: " + loadErr.Error())},
+				{list: authKeyList(authAPIKeyStr), apiKey: authAPIKeyStr},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var current step
+			a := &authenticatorImpl{ttl: defaultAPIKeysTTL, load: func() ([]byte, error) {
+				return current.list, current.loadErr
+			}}
+
+			for _, current = range test.steps {
+				if current.expire {
+					a.loaded = a.loaded.Add(-a.ttl)
+				}
+				_, err := a.Authorize(current.apiKey, ScopeStore)
+				assert.Equal(t, current.wantErr, err)
+			}
+		})
+	}
+}
+
+// MakeAuthenticator() - Test Method
+func TestAuth_MakeAuthenticator(t *testing.T) {
+	loadRecord := func(id, key []byte) ([]byte, error) { return authKeyList(authAPIKeyStr), nil }
+
+	tests := []struct {
+		name        string
+		configs     map[string]string
+		wantEnabled bool
+		wantTTL     time.Duration
+		wantErr     error
+	}{
+		{
+			name:    "should not authenticate without keys",
+			configs: map[string]string{},
+		},
+		{
+			name:        "should reload record keys every minute by default",
+			configs:     map[string]string{"apiKeysRecord": "4a5448", "apiKeysRecordKey": "4a5448"},
+			wantEnabled: true,
+			wantTTL:     time.Minute,
+		},
+		{
+			name: "should reload record keys every configured TTL",
+			configs: map[string]string{"apiKeysRecord": "4a5448", "apiKeysRecordKey": "4a5448",
+				"apiKeysTTL": "10s"},
+			wantEnabled: true,
+			wantTTL:     10 * time.Second,
+		},
+		{
+			name: "should fail with a zero TTL",
+			configs: map[string]string{"apiKeysRecord": "4a5448", "apiKeysRecordKey": "4a5448",
+				"apiKeysTTL": "0s"},
+			wantErr: errors.New("MakeAuthenticator cannot be configured with invalid apiKeysTTL 0s"),
+		},
+		{
+			name: "should fail with an invalid TTL",
+			configs: map[string]string{"apiKeysRecord": "4a5448", "apiKeysRecordKey": "4a5448",
+				"apiKeysTTL": "soon"},
+			wantErr: errors.New("MakeAuthenticator cannot be configured with invalid apiKeysTTL soon"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := MakeAuthenticator(test.configs, loadRecord)
+			assert.Equal(t, test.wantErr, err)
+			if err == nil {
+				assert.Equal(t, test.wantEnabled, a.Enabled())
+				if test.wantEnabled {
+					assert.Equal(t, test.wantTTL, a.(*authenticatorImpl).ttl)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Record compression codecs, applied before records are sealed.
+const (
+	CompressionGzip   = "gzip"
+	CompressionZstd   = "zstd"
+	CompressionSnappy = "snappy"
+)
+
+// Default limit on the decompressed size of records sealed without their
+// plaintext size.
+const defaultMaxDecompressedSize = 1 << 30
+
+// Returned when a record decompresses beyond its size limit.
+var ErrDecompressedTooLarge = errors.New("Decompressed record exceeds size limit")
+
+type Compressor interface {
+
+	// Codec applied to stored records. Empty when compression is disabled.
+	Codec() string
+
+	// Compress record contents with the configured codec as they are written.
+	NewWriter(w io.Writer) (cw io.WriteCloser, err error)
+
+	// Decompress record contents compressed with the given codec as they are
+	// read, failing once they exceed the plaintext size sealed in the record
+	// attributes, or the configured size limit when none is given.
+	NewReader(codec string, size int64, r io.Reader) (cr io.ReadCloser, err error)
+}
+
+type compressorImpl struct {
+	codec   string
+	maxSize int64
+}
+
+func (c *compressorImpl) Codec() string {
+	return c.codec
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func (c *compressorImpl) NewWriter(w io.Writer) (cw io.WriteCloser, err error) {
+	switch c.codec {
+	case "":
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	case CompressionSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	}
+	return nil, errors.New("Unsupported compression " + c.codec)
+}
+
+// Decompressed reader failing beyond a size limit.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	close     func() error
+}
+
+func (l *limitedReader) Read(p []byte) (n int, err error) {
+	if l.remaining < 0 {
+		return 0, ErrDecompressedTooLarge
+	}
+
+	// Read one byte beyond the limit to detect records exceeding it.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err = l.r.Read(p)
+	if l.remaining -= int64(n); l.remaining < 0 {
+		return n + int(l.remaining), ErrDecompressedTooLarge
+	}
+	return n, err
+}
+
+func (l *limitedReader) Close() error {
+	return l.close()
+}
+
+func (c *compressorImpl) NewReader(codec string, size int64, r io.Reader) (cr io.ReadCloser, err error) {
+	var dr io.Reader
+	closer := func() error { return nil }
+	switch codec {
+	case "":
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		dr, closer = gr, gr.Close
+	case CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		dr, closer = zr, func() error { zr.Close(); return nil }
+	case CompressionSnappy:
+		dr = snappy.NewReader(r)
+	default:
+		return nil, errors.New("Unsupported compression " + codec)
+	}
+
+	// Sealed sizes are authenticated, so records stored as streams of any
+	// size decompress in full while forged contents cannot grow past them.
+	limit := c.maxSize
+	if size > 0 {
+		limit = size
+	}
+	return &limitedReader{r: dr, remaining: limit, close: closer}, nil
+}
+
+// Compress compresses record contents held in memory.
+func Compress(c Compressor, data []byte) (compressed []byte, err error) {
+	var buf bytes.Buffer
+	cw, err := c.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = cw.Write(data); err != nil {
+		return nil, err
+	}
+	if err = cw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses record contents held in memory, up to their sealed
+// plaintext size.
+func Decompress(c Compressor, codec string, size int64, data []byte) (plain []byte, err error) {
+	cr, err := c.NewReader(codec, size, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer cr.Close()
+	return io.ReadAll(cr)
+}
+
+func MakeCompressor(configs map[string]string) (c Compressor, err error) {
+
+	// Compression is disabled unless a codec is configured.
+	codec := configs["compression"]
+	switch codec {
+	case "", CompressionGzip, CompressionZstd, CompressionSnappy:
+	default:
+		err = errors.New("MakeCompressor cannot be configured with unsupported compression " + codec)
+		return nil, err
+	}
+
+	// Initialize optional fields that require error handling.
+	var maxSize int64 = defaultMaxDecompressedSize
+	if val, ok := configs["maxDecompressedSize"]; ok {
+		if maxSize, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return nil, err
+		}
+		if maxSize <= 0 {
+			err = errors.New("MakeCompressor cannot be configured with non-positive maxDecompressedSize")
+			return nil, err
+		}
+	}
+
+	c = &compressorImpl{
+		codec:   codec,
+		maxSize: maxSize,
+	}
+	return c, nil
+}
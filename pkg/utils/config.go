@@ -26,8 +26,9 @@ type FeServerConfigs struct {
 }
 
 type BeServerConfigs struct {
-	Port     string `yaml:"port"`
-	MongoURI string `yaml:"mongoURI"`
+	Port        string `yaml:"port"`
+	MongoURI    string `yaml:"mongoURI"`
+	MaxVersions string `yaml:"maxVersions"`
 }
 
 type BeClientConfigs struct {
@@ -14,6 +14,7 @@ type Conn interface {
 
 type connImpl struct {
 	serverAddr string
+	apiKey     string
 }
 
 func (c *connImpl) GetResponse(message string) (response string, err error) {
@@ -24,12 +25,25 @@ func (c *connImpl) GetResponse(message string) (response string, err error) {
 		return "", nil
 	}
 	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	// Authenticate the connection when configured with an API key.
+	if c.apiKey != "" {
+		fmt.Fprint(conn, "AUTH "+c.apiKey+"\n")
+		reply, err := reader.ReadString('\n')
+		if err != nil {
+			return "", nil
+		}
+		if errMsg, failed := strings.CutPrefix(strings.TrimRight(reply, "\n"), "ERROR "); failed {
+			return "", errors.New(errMsg)
+		}
+	}
 
 	// Write request to server.
 	fmt.Fprint(conn, message)
 
 	// Receive server response.
-	response, err = bufio.NewReader(conn).ReadString('\n')
+	response, err = reader.ReadString('\n')
 	if err != nil {
 		return "", nil
 	}
@@ -48,6 +62,7 @@ func MakeConn(configs map[string]string) (c Conn, err error) {
 
 	c = &connImpl{
 		serverAddr: configs["serverAddr"],
+		apiKey:     configs["apiKey"],
 	}
 	return c, nil
 }
@@ -2,8 +2,14 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"log"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -11,110 +17,600 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Number of prior versions retained per record when not configured.
+const defaultMaxVersions = 5
+
+// Number of records returned per list request when not requested, and at most.
+const defaultListLimit = 100
+const maxListLimit = 1000
+
 type DB interface {
-	StoreRecord(id, record string) (err error)
-	RetrieveRecord(id string) (record string, err error)
-	DeleteRecord(id string) (err error)
+	StoreRecord(entry Entry, match Precondition) (stored Entry, err error)
+	RetrieveRecord(id, name, keyHash string, version int64) (entry Entry, err error)
+	StatRecord(id, name string, version int64) (entry Entry, err error)
+	ListVersions(id, name string) (versions []int64, err error)
+	ListRecords(id, cursor string, limit int64) (entries []Entry, next string, err error)
+	DeleteRecord(id, name string, match Precondition) (err error)
+	UpdateMetadata(id, name, prior, metadata, signatures string) (entry Entry, err error)
+	StoreChunks(stream string, next func() (data string, err error)) (chunks int64, err error)
+	RetrieveChunks(stream string, chunks int64, fn func(data string) error) (err error)
+	DeleteChunks(streams []string) (err error)
+
+	// Returns a data store tracing its calls under the span of ctx.
+	WithContext(ctx context.Context) DB
 }
 
 type dbImpl struct {
-	mongoURI string
+	mongoURI       string
+	maxVersions    int
+	indexOnce      *sync.Once
+	chunkIndexOnce *sync.Once
+
+	// Context of the request making data store calls, parenting their spans.
+	ctx context.Context
 }
 
 type Entry struct {
-	Id     string
-	Record string
+	Id         string
+	Name       string `bson:",omitempty"`
+	Record     string
+	OneTime    bool
+	KeyHash    string
+	Version    int64
+	Size       int64     `bson:",omitempty"`
+	Metadata   string    `bson:",omitempty"`
+	Signatures string    `bson:",omitempty"`
+	Stream     string    `bson:",omitempty"`
+	Chunks     int64     `bson:",omitempty"`
+	Created    time.Time `bson:",omitempty"`
+	Updated    time.Time `bson:",omitempty"`
+	History    []Entry   `bson:",omitempty"`
 }
 
-func (db *dbImpl) getRecordCollection() (coll *mongo.Collection, err error) {
+// Chunk of a streamed record, stored apart from its record entry so records
+// are not bound by the document size limit.
+type Chunk struct {
+	Stream string
+	Index  int64
+	Data   string
+}
 
-	log.Println("Connecting to data store...")
+// Returned when a presented key does not match a record's key commitment.
+var ErrKeyMismatch = errors.New("record key does not match")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// Returned when a requested record version is not retained.
+var ErrVersionNotFound = errors.New("record version not found")
+
+// Returned by back-end clients when a requested record does not exist.
+var ErrRecordNotFound = errors.New("record not found")
+
+// Returned when a store or delete precondition does not hold.
+var ErrPreconditionFailed = errors.New("record precondition failed")
+
+// Returned when a streamed record is missing chunks.
+var ErrChunkMissing = errors.New("record chunk missing")
+
+// NewStreamID returns a random identifier for the chunks of a streamed record.
+func NewStreamID() (stream string, err error) {
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// LoadRecord returns the hex record of an entry, reading streamed records back
+// from their chunks. Chunks of consumed one-time records are then released.
+func LoadRecord(db DB, entry Entry) (record string, err error) {
+	if entry.Stream == "" {
+		return entry.Record, nil
+	}
+
+	var builder strings.Builder
+	err = db.RetrieveChunks(entry.Stream, entry.Chunks, func(data string) error {
+		builder.WriteString(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if entry.OneTime {
+		if err = db.DeleteChunks([]string{entry.Stream}); err != nil {
+			slog.Error("Data store chunk cleanup error", "error", err)
+		}
+	}
+	return builder.String(), nil
+}
+
+func (db *dbImpl) getDatabase(ctx context.Context) (database *mongo.Database, err error) {
+
+	slog.DebugContext(db.ctx, "Connecting to data store")
 
 	// Create Mongo client.
 	client, err := mongo.Connect(ctx, options.Client().ApplyURI(db.mongoURI))
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Data store connected")
+	slog.DebugContext(db.ctx, "Data store connected")
 
 	// Verify connectivity.
 	if err = client.Ping(ctx, readpref.Primary()); err != nil {
 		return nil, err
 	}
-	log.Println("Data store ping verified")
+	slog.DebugContext(db.ctx, "Data store ping verified")
+
+	return client.Database("enc-server-go"), nil
+}
+
+func (db *dbImpl) getChunkCollection() (coll *mongo.Collection, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Retrieve chunk collection.
+	database, err := db.getDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	coll = database.Collection("chunks")
+
+	// Chunks are read back in order per stream.
+	db.chunkIndexOnce.Do(func() {
+		index := mongo.IndexModel{
+			Keys: bson.D{
+				primitive.E{Key: "stream", Value: 1},
+				primitive.E{Key: "index", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := coll.Indexes().CreateOne(ctx, index); err != nil {
+			slog.ErrorContext(db.ctx, "Data store index error", "error", err)
+		}
+	})
+
+	return coll, nil
+}
+
+func (db *dbImpl) getRecordCollection() (coll *mongo.Collection, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
 	// Retrieve record collection.
-	coll = client.Database("enc-server-go").Collection("records")
+	database, err := db.getDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	coll = database.Collection("records")
+
+	// Enforce one entry per ID and name so conditional upserts cannot
+	// duplicate records.
+	db.indexOnce.Do(func() {
+		index := mongo.IndexModel{
+			Keys: bson.D{
+				primitive.E{Key: "id", Value: 1},
+				primitive.E{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := coll.Indexes().CreateOne(ctx, index); err != nil {
+			slog.ErrorContext(db.ctx, "Data store index error", "error", err)
+		}
+	})
+
 	return coll, nil
 }
 
-func (db *dbImpl) StoreRecord(id, record string) (err error) {
+func recordFilter(id, name string) (filter bson.D) {
+
+	// The default record for an ID carries no name.
+	if name == "" {
+		return bson.D{
+			primitive.E{Key: "id", Value: id},
+			primitive.E{Key: "name", Value: bson.D{primitive.E{Key: "$exists", Value: false}}},
+		}
+	}
+
+	return bson.D{
+		primitive.E{Key: "id", Value: id},
+		primitive.E{Key: "name", Value: name},
+	}
+}
+
+func preconditionFilter(filter bson.D, match Precondition) (result bson.D) {
+
+	// Constrain the current revision, or require the record exist.
+	if match.IfMatch > 0 {
+		return append(filter, primitive.E{Key: "version", Value: match.IfMatch})
+	} else if match.IfExists {
+		return append(filter, primitive.E{Key: "record",
+			Value: bson.D{primitive.E{Key: "$exists", Value: true}}})
+	} else if match.IfNotExists {
+		return append(filter, primitive.E{Key: "record",
+			Value: bson.D{primitive.E{Key: "$exists", Value: false}}})
+	}
+
+	return filter
+}
+
+func (db *dbImpl) historyUpdate() (history bson.D) {
+
+	// Retain nothing when history is disabled.
+	if db.maxVersions == 0 {
+		return bson.D{primitive.E{Key: "$literal", Value: bson.A{}}}
+	}
+
+	// Prior history, or an empty array for new records.
+	prior := bson.D{primitive.E{Key: "$ifNull", Value: bson.A{"$history", bson.A{}}}}
+
+	// Snapshot of the current record and its attributes, newest version first.
+	current := bson.D{
+		primitive.E{Key: "record", Value: "$record"},
+		primitive.E{Key: "version", Value: bson.D{
+			primitive.E{Key: "$ifNull", Value: bson.A{"$version", 0}}}},
+		primitive.E{Key: "size", Value: "$size"},
+		primitive.E{Key: "metadata", Value: "$metadata"},
+		primitive.E{Key: "signatures", Value: "$signatures"},
+		primitive.E{Key: "stream", Value: "$stream"},
+		primitive.E{Key: "chunks", Value: "$chunks"},
+		primitive.E{Key: "updated", Value: "$updated"},
+	}
+	shifted := bson.D{primitive.E{Key: "$slice", Value: bson.A{
+		bson.D{primitive.E{Key: "$concatArrays", Value: bson.A{bson.A{current}, prior}}},
+		db.maxVersions,
+	}}}
+
+	// Only existing records are retained. One-time records never are.
+	retain := bson.D{primitive.E{Key: "$and", Value: bson.A{
+		bson.D{primitive.E{Key: "$ne", Value: bson.A{
+			bson.D{primitive.E{Key: "$type", Value: "$record"}}, "missing"}}},
+		bson.D{primitive.E{Key: "$ne", Value: bson.A{"$onetime", true}}},
+	}}}
+
+	history = bson.D{primitive.E{Key: "$cond", Value: bson.A{retain, shifted, prior}}}
+	return history
+}
+
+func (db *dbImpl) replacedStreams(prior Entry, found bool) (streams []string) {
+
+	// History retained after a store, mirroring historyUpdate.
+	kept := prior.History
+	if found && !prior.OneTime {
+		current := prior
+		current.History = nil
+		kept = append([]Entry{current}, prior.History...)
+		kept = kept[:min(len(kept), db.maxVersions)]
+	}
+	if db.maxVersions == 0 {
+		kept = nil
+	}
+
+	// Streams of the prior record and history that are no longer retained.
+	retained := make(map[string]bool, len(kept))
+	for _, version := range kept {
+		retained[version.Stream] = true
+	}
+	for _, version := range append([]Entry{prior}, prior.History...) {
+		if version.Stream != "" && !retained[version.Stream] {
+			streams = append(streams, version.Stream)
+		}
+	}
+	return streams
+}
+
+func (db *dbImpl) WithContext(ctx context.Context) DB {
+	traced := *db
+	traced.ctx = ctx
+	return &traced
+}
 
-	log.Println("Storing record on data store")
+// Starts the span of a data store operation, under the request making it.
+func (db *dbImpl) startSpan(operation string) (span trace.Span) {
+	_, span = StartSpan(db.ctx, "mongo."+operation, semconv.DBSystemNameMongoDB, semconv.DBOperationName(operation))
+	return span
+}
+
+func (db *dbImpl) StoreRecord(entry Entry, match Precondition) (stored Entry, err error) {
+	defer observeMongo("StoreRecord", time.Now(), &err)
+	defer EndSpan(db.startSpan("StoreRecord"), &err)
+
+	slog.DebugContext(db.ctx, "Storing record on data store")
 
 	// Get reference to record collection.
 	coll, err := db.getRecordCollection()
 	if err != nil {
-		return err
+		return Entry{}, err
 	}
 
-	// Set query parameters.
-	filter := bson.D{primitive.E{Key: "id", Value: id}}
-	update := bson.D{primitive.E{Key: "$set",
-		Value: bson.D{primitive.E{Key: "record", Value: record}}}}
-	opts := options.Update().SetUpsert(true)
+	// Streamed records are sized by their chunks.
+	size := int64(len(entry.Record) / 2)
+	if entry.Stream != "" {
+		size = entry.Size
+	}
+
+	// Set query parameters. The pipeline shifts the current record into its
+	// history and increments the version atomically. Records are only created
+	// when no existing record is required. The prior entry is returned so
+	// streams it no longer retains can be released.
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	upsert := match.IfMatch == 0 && !match.IfExists
+	filter := preconditionFilter(recordFilter(entry.Id, entry.Name), match)
+	update := mongo.Pipeline{bson.D{primitive.E{Key: "$set",
+		Value: bson.D{
+			primitive.E{Key: "history", Value: db.historyUpdate()},
+			primitive.E{Key: "version", Value: bson.D{primitive.E{Key: "$add", Value: bson.A{
+				bson.D{primitive.E{Key: "$ifNull", Value: bson.A{"$version", 0}}}, 1}}}},
+			primitive.E{Key: "record", Value: bson.D{
+				primitive.E{Key: "$literal", Value: entry.Record}}},
+			primitive.E{Key: "onetime", Value: entry.OneTime},
+			primitive.E{Key: "keyhash", Value: bson.D{
+				primitive.E{Key: "$literal", Value: entry.KeyHash}}},
+			primitive.E{Key: "size", Value: size},
+			primitive.E{Key: "metadata", Value: bson.D{
+				primitive.E{Key: "$literal", Value: entry.Metadata}}},
+			primitive.E{Key: "signatures", Value: bson.D{
+				primitive.E{Key: "$literal", Value: entry.Signatures}}},
+			primitive.E{Key: "stream", Value: bson.D{
+				primitive.E{Key: "$literal", Value: entry.Stream}}},
+			primitive.E{Key: "chunks", Value: entry.Chunks},
+			primitive.E{Key: "created", Value: bson.D{
+				primitive.E{Key: "$ifNull", Value: bson.A{"$created", now}}}},
+			primitive.E{Key: "updated", Value: now},
+		}}}}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(upsert).
+		SetReturnDocument(options.Before).
+		SetProjection(bson.D{
+			primitive.E{Key: "version", Value: 1},
+			primitive.E{Key: "onetime", Value: 1},
+			primitive.E{Key: "stream", Value: 1},
+			primitive.E{Key: "created", Value: 1},
+			primitive.E{Key: "history.stream", Value: 1},
+		})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Update record record.
-	result, err := coll.UpdateOne(ctx, filter, update, opts)
+	// Update record entry. Unmatched preconditions either find no entry or
+	// collide with the existing entry on upsert. Upserts creating a record
+	// find no prior entry.
+	var prior Entry
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&prior)
+	found := err == nil
+	if errors.Is(err, mongo.ErrNoDocuments) && upsert {
+		err = nil
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) || mongo.IsDuplicateKeyError(err) {
+		return Entry{}, ErrPreconditionFailed
+	} else if err != nil {
+		return Entry{}, err
+	}
+
+	stored = Entry{Version: prior.Version + 1, Created: prior.Created, Updated: now}
+	if stored.Created.IsZero() {
+		stored.Created = now
+	}
+	slog.DebugContext(db.ctx, "Stored record entry on data store", "version", stored.Version)
+
+	// Release streams replaced or pruned from history.
+	if streams := db.replacedStreams(prior, found); len(streams) > 0 {
+		if err = db.DeleteChunks(streams); err != nil {
+			slog.ErrorContext(db.ctx, "Data store chunk cleanup error", "error", err)
+		}
+	}
+
+	return stored, nil
+}
+
+func (db *dbImpl) RetrieveRecord(id, name, keyHash string, version int64) (entry Entry, err error) {
+	defer observeMongo("RetrieveRecord", time.Now(), &err)
+	defer EndSpan(db.startSpan("RetrieveRecord"), &err)
+
+	slog.DebugContext(db.ctx, "Retrieving record on data store")
+
+	// Get reference to record collection.
+	coll, err := db.getRecordCollection()
 	if err != nil {
-		return err
+		return Entry{}, err
 	}
 
-	log.Printf("Number of record entries updated: %v\n", result.ModifiedCount)
-	log.Printf("Number of record entries upserted: %v\n", result.UpsertedCount)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	return nil
+	// Atomically consume a one-time record if the key commitment matches.
+	consumeFilter := append(recordFilter(id, name),
+		primitive.E{Key: "onetime", Value: true},
+		primitive.E{Key: "keyhash", Value: keyHash},
+	)
+	if version > 0 {
+		consumeFilter = append(consumeFilter, primitive.E{Key: "version", Value: version})
+	}
+	err = coll.FindOneAndDelete(ctx, consumeFilter).Decode(&entry)
+	if err == nil {
+
+		// Chunks of consumed streamed records are released once read.
+		slog.DebugContext(db.ctx, "Consumed one-time record on data store")
+		entry.History = nil
+		return entry, nil
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return Entry{}, err
+	}
+
+	// Query record entry.
+	filter := recordFilter(id, name)
+	if err = coll.FindOne(ctx, filter).Decode(&entry); err != nil {
+		return Entry{}, err
+	}
+
+	// Search history for prior versions.
+	if version > 0 && version != entry.Version {
+		for _, prior := range entry.History {
+			if prior.Version == version {
+				prior.Id, prior.Name, prior.Created = entry.Id, entry.Name, entry.Created
+				return prior, nil
+			}
+		}
+		return Entry{}, ErrVersionNotFound
+	}
+
+	// One-time records are only released against a matching key.
+	if entry.OneTime {
+		return Entry{}, ErrKeyMismatch
+	}
+
+	entry.History = nil
+	return entry, nil
 }
 
-func (db *dbImpl) RetrieveRecord(id string) (record string, err error) {
+func (db *dbImpl) StatRecord(id, name string, version int64) (entry Entry, err error) {
+	defer observeMongo("StatRecord", time.Now(), &err)
+	defer EndSpan(db.startSpan("StatRecord"), &err)
 
-	log.Println("Retrieving record on data store")
+	slog.DebugContext(db.ctx, "Retrieving record attributes on data store")
 
 	// Get reference to record collection.
 	coll, err := db.getRecordCollection()
 	if err != nil {
-		return "", err
+		return Entry{}, err
+	}
+
+	// Set query parameters. Record contents are never read, so one-time
+	// records are left in place.
+	filter := recordFilter(id, name)
+	opts := options.FindOne().SetProjection(bson.D{
+		primitive.E{Key: "record", Value: 0},
+		primitive.E{Key: "keyhash", Value: 0},
+		primitive.E{Key: "history.record", Value: 0},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Query record entry.
+	if err = coll.FindOne(ctx, filter, opts).Decode(&entry); err != nil {
+		return Entry{}, err
+	}
+
+	// Search history for prior versions.
+	if version > 0 && version != entry.Version {
+		for _, prior := range entry.History {
+			if prior.Version == version {
+				prior.Id, prior.Name, prior.Created = entry.Id, entry.Name, entry.Created
+				return prior, nil
+			}
+		}
+		return Entry{}, ErrVersionNotFound
+	}
+
+	entry.History = nil
+	return entry, nil
+}
+
+func (db *dbImpl) ListVersions(id, name string) (versions []int64, err error) {
+	defer observeMongo("ListVersions", time.Now(), &err)
+	defer EndSpan(db.startSpan("ListVersions"), &err)
+
+	slog.DebugContext(db.ctx, "Listing record versions on data store")
+
+	// Get reference to record collection.
+	coll, err := db.getRecordCollection()
+	if err != nil {
+		return nil, err
 	}
 
 	// Set query parameters.
-	filter := bson.D{primitive.E{Key: "id", Value: id}}
+	filter := recordFilter(id, name)
+	opts := options.FindOne().SetProjection(bson.D{
+		primitive.E{Key: "version", Value: 1},
+		primitive.E{Key: "history.version", Value: 1},
+	})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Query record entry.
 	var entry Entry
-	if err = coll.FindOne(ctx, filter).Decode(&entry); err != nil {
-		return "", err
+	if err = coll.FindOne(ctx, filter, opts).Decode(&entry); err != nil {
+		return nil, err
 	}
 
-	// Extract record.
-	record = entry.Record
+	// Collect versions, newest first.
+	versions = append(versions, entry.Version)
+	for _, prior := range entry.History {
+		versions = append(versions, prior.Version)
+	}
 
-	return record, nil
+	return versions, nil
 }
 
-func (db *dbImpl) DeleteRecord(id string) (err error) {
+func (db *dbImpl) ListRecords(id, cursor string, limit int64) (entries []Entry, next string, err error) {
+	defer observeMongo("ListRecords", time.Now(), &err)
+	defer EndSpan(db.startSpan("ListRecords"), &err)
 
-	log.Println("Deleting record on data store")
+	slog.DebugContext(db.ctx, "Listing records on data store")
+
+	// Get reference to record collection.
+	coll, err := db.getRecordCollection()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Bound the page size.
+	if limit <= 0 {
+		limit = defaultListLimit
+	} else if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	// Set query parameters. Named records are paged in name order on the
+	// record index, resuming after the cursor. One extra entry is requested
+	// to detect a following page.
+	filter := bson.D{
+		primitive.E{Key: "id", Value: id},
+		primitive.E{Key: "name", Value: bson.D{primitive.E{Key: "$gt", Value: cursor}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{primitive.E{Key: "name", Value: 1}}).
+		SetLimit(limit + 1).
+		SetProjection(bson.D{
+			primitive.E{Key: "name", Value: 1},
+			primitive.E{Key: "size", Value: 1},
+			primitive.E{Key: "metadata", Value: 1},
+			primitive.E{Key: "created", Value: 1},
+			primitive.E{Key: "updated", Value: 1},
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Query record entries.
+	results, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = results.All(ctx, &entries); err != nil {
+		return nil, "", err
+	}
+
+	// Resume the following page after the last returned name.
+	if int64(len(entries)) > limit {
+		entries = entries[:limit]
+		next = entries[limit-1].Name
+	}
+
+	return entries, next, nil
+}
+
+func (db *dbImpl) DeleteRecord(id, name string, match Precondition) (err error) {
+	defer observeMongo("DeleteRecord", time.Now(), &err)
+	defer EndSpan(db.startSpan("DeleteRecord"), &err)
+
+	slog.DebugContext(db.ctx, "Deleting record on data store")
 
 	// Get reference to record collection.
 	coll, err := db.getRecordCollection()
@@ -122,18 +618,210 @@ func (db *dbImpl) DeleteRecord(id string) (err error) {
 		return err
 	}
 
-	// Set query parameters.
-	filter := bson.D{primitive.E{Key: "id", Value: id}}
-	opts := options.Delete().SetHint(bson.D{{Key: "_id", Value: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Deleting a record required not to exist only verifies its absence.
+	if match.IfNotExists {
+		count, err := coll.CountDocuments(ctx, recordFilter(id, name))
+		if err != nil {
+			return err
+		} else if count > 0 {
+			return ErrPreconditionFailed
+		}
+		return nil
+	}
+
+	// Set query parameters. Streams of the deleted record are released.
+	filter := preconditionFilter(recordFilter(id, name), match)
+	opts := options.FindOneAndDelete().SetProjection(bson.D{
+		primitive.E{Key: "stream", Value: 1},
+		primitive.E{Key: "history.stream", Value: 1},
+	})
+
+	var deleted Entry
+	err = coll.FindOneAndDelete(ctx, filter, opts).Decode(&deleted)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+
+		// Conditional deletes require a matching record.
+		if match.IfMatch > 0 || match.IfExists {
+			return ErrPreconditionFailed
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+	slog.DebugContext(db.ctx, "Deleted record entry on data store")
+
+	var streams []string
+	for _, version := range append([]Entry{deleted}, deleted.History...) {
+		if version.Stream != "" {
+			streams = append(streams, version.Stream)
+		}
+	}
+	if len(streams) > 0 {
+		if err = db.DeleteChunks(streams); err != nil {
+			slog.ErrorContext(db.ctx, "Data store chunk cleanup error", "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *dbImpl) UpdateMetadata(id, name, prior, metadata, signatures string) (entry Entry, err error) {
+	defer observeMongo("UpdateMetadata", time.Now(), &err)
+	defer EndSpan(db.startSpan("UpdateMetadata"), &err)
+
+	slog.DebugContext(db.ctx, "Updating record metadata on data store")
+
+	// Get reference to record collection.
+	coll, err := db.getRecordCollection()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	// Set query parameters. Metadata and its signatures are replaced in place
+	// only while it still holds the prior value, so concurrent updates cannot
+	// be lost. The record and its version are left unchanged.
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	filter := append(recordFilter(id, name), primitive.E{Key: "metadata", Value: prior})
+	update := bson.D{primitive.E{Key: "$set", Value: bson.D{
+		primitive.E{Key: "metadata", Value: metadata},
+		primitive.E{Key: "signatures", Value: signatures},
+		primitive.E{Key: "updated", Value: now},
+	}}}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.D{
+			primitive.E{Key: "version", Value: 1},
+			primitive.E{Key: "metadata", Value: 1},
+			primitive.E{Key: "signatures", Value: 1},
+			primitive.E{Key: "created", Value: 1},
+			primitive.E{Key: "updated", Value: 1},
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Update record entry.
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Entry{}, ErrPreconditionFailed
+	} else if err != nil {
+		return Entry{}, err
+	}
+	slog.DebugContext(db.ctx, "Updated record metadata on data store", "version", entry.Version)
+
+	return entry, nil
+}
+
+func (db *dbImpl) StoreChunks(stream string, next func() (data string, err error)) (chunks int64, err error) {
+	defer observeMongo("StoreChunks", time.Now(), &err)
+	defer EndSpan(db.startSpan("StoreChunks"), &err)
+
+	slog.DebugContext(db.ctx, "Storing record chunks on data store")
+
+	// Get reference to chunk collection.
+	coll, err := db.getChunkCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	// Insert chunks in order until the stream is exhausted.
+	for {
+		data, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return chunks, err
+		} else if data == "" {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err = coll.InsertOne(ctx, Chunk{Stream: stream, Index: chunks, Data: data})
+		cancel()
+		if err != nil {
+			return chunks, err
+		}
+		chunks++
+	}
+
+	slog.DebugContext(db.ctx, "Stored record chunks on data store", "chunks", chunks)
+
+	return chunks, nil
+}
+
+func (db *dbImpl) RetrieveChunks(stream string, chunks int64, fn func(data string) error) (err error) {
+	defer observeMongo("RetrieveChunks", time.Now(), &err)
+	defer EndSpan(db.startSpan("RetrieveChunks"), &err)
+
+	slog.DebugContext(db.ctx, "Retrieving record chunks on data store")
+
+	// Get reference to chunk collection.
+	coll, err := db.getChunkCollection()
+	if err != nil {
+		return err
+	}
+
+	// Set query parameters. Streams may take far longer to read back than
+	// any single request timeout.
+	filter := bson.D{primitive.E{Key: "stream", Value: stream}}
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "index", Value: 1}})
+
+	ctx := context.Background()
+	results, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer results.Close(ctx)
+
+	// Chunks must be read back complete and in order.
+	var index int64
+	for ; results.Next(ctx); index++ {
+		var chunk Chunk
+		if err = results.Decode(&chunk); err != nil {
+			return err
+		} else if chunk.Index != index {
+			return ErrChunkMissing
+		}
+		if err = fn(chunk.Data); err != nil {
+			return err
+		}
+	}
+	if err = results.Err(); err != nil {
+		return err
+	} else if index != chunks {
+		return ErrChunkMissing
+	}
+
+	return nil
+}
+
+func (db *dbImpl) DeleteChunks(streams []string) (err error) {
+	defer observeMongo("DeleteChunks", time.Now(), &err)
+	defer EndSpan(db.startSpan("DeleteChunks"), &err)
+
+	slog.DebugContext(db.ctx, "Deleting record chunks on data store")
+
+	// Get reference to chunk collection.
+	coll, err := db.getChunkCollection()
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	result, err := coll.DeleteMany(ctx, filter, opts)
+	// Set query parameters.
+	filter := bson.D{primitive.E{Key: "stream", Value: bson.D{
+		primitive.E{Key: "$in", Value: streams}}}}
+
+	result, err := coll.DeleteMany(ctx, filter)
 	if err != nil {
 		return err
 	}
-	log.Printf("Number of record entries deleted: %d\n", result.DeletedCount)
+	slog.DebugContext(db.ctx, "Deleted record chunks on data store", "chunks", result.DeletedCount)
 
 	return nil
 }
@@ -146,8 +834,23 @@ func MakeDB(configs map[string]string) (db DB, err error) {
 		return nil, err
 	}
 
+	// Initialize optional fields that require error handling.
+	maxVersions := defaultMaxVersions
+	if val, ok := configs["maxVersions"]; ok {
+		if maxVersions, err = strconv.Atoi(val); err != nil {
+			return nil, err
+		}
+		if maxVersions < 0 {
+			err = errors.New("MakeDB cannot be configured with negative maxVersions")
+			return nil, err
+		}
+	}
+
 	db = &dbImpl{
-		mongoURI: configs["mongoURI"],
+		mongoURI:       configs["mongoURI"],
+		maxVersions:    maxVersions,
+		indexOnce:      &sync.Once{},
+		chunkIndexOnce: &sync.Once{},
 	}
 	return db, nil
 }
@@ -1,31 +1,242 @@
 package utils
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 type Server interface {
 
 	// Start server.
 	Start() (err error)
 }
 
+// Preconditions on the current record revision, checked atomically by the
+// back-end before a store or delete is applied.
+type Precondition struct {
+
+	// Require the current revision to equal this value. Zero disables the check.
+	IfMatch int64
+
+	// Require an existing record (update-only).
+	IfExists bool
+
+	// Require no existing record (create-only).
+	IfNotExists bool
+}
+
+// Options accompanying a store request.
+type StoreOptions struct {
+
+	// Delete the record upon its first successful retrieval.
+	OneTime bool
+
+	// Commitment to the record key, required to consume one-time records.
+	// Derived by the front-end service.
+	KeyHash []byte
+
+	// Preconditions on the current record revision.
+	Match Precondition
+
+	// Media type of the record contents.
+	ContentType string
+
+	// User labels describing the record, encrypted at rest.
+	Labels map[string]string
+
+	// Store the record uncompressed, such as when already compressed.
+	NoCompress bool
+
+	// Recipient X25519 public keys. The record key is wrapped for each
+	// recipient rather than returned to the storer.
+	Recipients [][]byte
+
+	// Record metadata sealed by the front-end service. Opaque to the back-end.
+	Metadata []byte
+
+	// Seals record metadata once a streamed record has been consumed, so
+	// attributes such as its size may depend on the streamed contents.
+	// Overrides Metadata when set.
+	SealMetadata func() (metadata []byte, err error)
+
+	// Record signatures encoded by the front-end service, stored alongside
+	// the record. Opaque to the back-end.
+	Signatures []byte
+
+	// Signs the metadata sealed by SealMetadata. Overrides Signatures when set.
+	SignMetadata func(metadata []byte) (signatures []byte, err error)
+
+	// Signature over the record contents by the storing client, verified and
+	// stored by the front-end service.
+	ContentSignature *Signature
+}
+
+// Options accompanying a back-end retrieve request.
+type RetrieveOptions struct {
+
+	// Commitment to the presented record key.
+	KeyHash []byte
+
+	// Prior record version to retrieve. Zero retrieves the current version.
+	Version int64
+
+	// Retrieve record attributes without the record contents. One-time
+	// records are not consumed.
+	MetadataOnly bool
+}
+
+// Options accompanying a delete request.
+type DeleteOptions struct {
+
+	// Preconditions on the current record revision.
+	Match Precondition
+}
+
+// Options accompanying a list request.
+type ListOptions struct {
+
+	// Opaque cursor returned by a prior list request. Empty lists from the start.
+	Cursor string
+
+	// Maximum number of records to return. Zero selects the back-end default.
+	Limit int64
+}
+
+// Summary of a named record returned by list requests. Contents are not
+// revealed.
+type RecordSummary struct {
+
+	// Record name. Sealed between the front-end and back-end services.
+	Name []byte
+
+	// Record size in bytes.
+	Size int64
+
+	// Media type of the record contents.
+	ContentType string
+
+	// Creation and last update times.
+	Created time.Time
+	Updated time.Time
+
+	// Record metadata sealed by the front-end service. Opaque to the back-end.
+	Metadata []byte
+}
+
+// Record attributes returned by the back-end.
+type RecordInfo struct {
+
+	// Record version, incremented on every store. Serves as the record
+	// revision checked by preconditions.
+	Version int64
+
+	// Creation and last update times.
+	Created time.Time
+	Updated time.Time
+
+	// Record metadata sealed by the front-end service. Opaque to the back-end.
+	Metadata []byte
+
+	// Record signatures encoded by the front-end service. Opaque to the back-end.
+	Signatures []byte
+
+	// Record attributes opened by the front-end service: plaintext size,
+	// media type and user labels.
+	Size        int64
+	ContentType string
+	Labels      map[string]string
+
+	// Record sealed as a chunked stream rather than in a single call.
+	Chunked bool
+
+	// Codec compressing the record before it was sealed, if any.
+	Compression string
+
+	// Scheme padding the record before it was sealed, if any, and the length
+	// of the sealed contents before padding.
+	Padding  string
+	Unpadded int64
+
+	// Record key wrapped for each recipient, if stored for recipients.
+	KeySlots []KeySlot
+
+	// Digest of the stored ciphertext, if the record envelope is signed.
+	Digest []byte
+
+	// Key ID of the client whose content signature verified, if any.
+	Signer []byte
+}
+
 type ClientBE interface {
 
-	// This endpoint accepts requests to store a record associated with a user ID.
-	StoreRecord(id, record []byte) (err error)
+	// This endpoint accepts requests to store a named record associated with a user ID.
+	StoreRecord(id, name, record []byte, opts StoreOptions) (info RecordInfo, err error)
+
+	// This endpoint accepts requests for named record retrieval via a user ID.
+	RetrieveRecord(id, name []byte, opts RetrieveOptions) (record []byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests to store a named record streamed in chunks.
+	StoreStream(id, name []byte, record io.Reader, opts StoreOptions) (info RecordInfo, err error)
+
+	// This endpoint accepts requests for named record retrieval streamed in chunks.
+	RetrieveStream(id, name []byte, opts RetrieveOptions) (record io.ReadCloser, info RecordInfo, err error)
+
+	// This endpoint accepts requests for the retained versions of a named record, newest first.
+	ListVersions(id, name []byte) (versions []int64, err error)
+
+	// This endpoint accepts requests for a page of the named records of a user ID.
+	ListRecords(id []byte, opts ListOptions) (records []RecordSummary, next string, err error)
 
-	// This endpoint accepts requests for record retrieval via a user ID.
-	RetrieveRecord(id []byte) (record []byte, err error)
+	// This endpoint accepts requests for named record deletion via a user ID.
+	DeleteRecord(id, name []byte, opts DeleteOptions) (err error)
 
-	// This endpoint accepts requests for record deletion via a user ID.
-	DeleteRecord(id []byte) (err error)
+	// This endpoint accepts requests to replace the sealed metadata of a named
+	// record, provided it still holds the prior metadata.
+	UpdateMetadata(id, name, prior, metadata, signatures []byte) (info RecordInfo, err error)
+
+	// Returns a client tracing its calls under the span of ctx.
+	WithContext(ctx context.Context) ClientBE
 }
 
+// Front-end client endpoints address a named record of a user ID. An empty
+// name addresses the user's default record.
 type ClientFE interface {
 
-	// This endpoint accepts requests to store a record associated with a user ID.
-	StoreRecord(id, record []byte) (key []byte, err error)
+	// This endpoint accepts requests to store a named record associated with a user ID.
+	StoreRecord(id, name, record []byte, opts StoreOptions) (key []byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests for named record retrieval via a user ID.
+	RetrieveRecord(id, name, key []byte) (record []byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests to store a named record, splitting its key
+	// into shares, any threshold of which recover it.
+	StoreRecordShares(id, name, record []byte, shares, threshold int,
+		opts StoreOptions) (keyShares [][]byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests for named record retrieval with a threshold
+	// of key shares.
+	RetrieveRecordShares(id, name []byte, keyShares [][]byte) (record []byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests for named record retrieval by a recipient,
+	// unwrapping the record key with their X25519 private key.
+	RetrieveRecordFor(id, name, private []byte) (record []byte, info RecordInfo, err error)
+
+	// This endpoint accepts requests for the attributes of a named record via a user ID.
+	StatRecord(id, name []byte) (info RecordInfo, err error)
+
+	// This endpoint accepts requests to wrap the key of a named record for X25519
+	// recipients and members, authorized by the record key or a member key.
+	AddRecipients(id, name, key []byte, recipients, members [][]byte) (slots [][]byte, err error)
+
+	// This endpoint accepts requests to remove a key slot from a named record.
+	RevokeRecipient(id, name, key, slot []byte) (err error)
 
-	// This endpoint accepts requests for record retrieval via a user ID.
-	RetrieveRecord(id []byte, key []byte) (record []byte, err error)
+	// This endpoint accepts requests for a page of the named records of a user ID.
+	ListRecords(id []byte, opts ListOptions) (records []RecordSummary, next string, err error)
 
-	// This endpoint accepts requests for record deletion via a user ID.
-	DeleteRecord(id []byte) (err error)
+	// This endpoint accepts requests for named record deletion via a user ID,
+	// authorized by the record key or a member key. A nil key deletes under
+	// an admin API key.
+	DeleteRecord(id, name, key []byte, opts DeleteOptions) (err error)
 }
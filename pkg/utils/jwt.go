@@ -0,0 +1,344 @@
+package utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Signing algorithms accepted on bearer tokens.
+const (
+	TokenHS256 = "HS256"
+	TokenRS256 = "RS256"
+	TokenEdDSA = "EdDSA"
+)
+
+// Clock skew tolerated when checking token lifetimes.
+const tokenLeeway = 30 * time.Second
+
+// Returned when a request carries no bearer token, or one that fails to verify.
+var ErrTokenInvalid = errors.New("Missing or invalid bearer token")
+
+// Returned when a bearer token is outside its lifetime.
+var ErrTokenExpired = errors.New("Bearer token expired")
+
+// Returned when a bearer token does not permit the requested user ID.
+var ErrUserForbidden = errors.New("Bearer token does not permit this user ID")
+
+// Principal carried by a verified bearer token: its subject, granted scopes
+// and the user IDs it may address.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	UserIDs [][]byte
+}
+
+// Authorize checks a principal holds a scope for a user ID. Admins may
+// address any user ID.
+func (p Principal) Authorize(scope string, id []byte) (err error) {
+	if slices.Contains(p.Scopes, ScopeAdmin) {
+		return nil
+	}
+	if err = p.AuthorizeScope(scope); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(p.UserIDs, func(userID []byte) bool { return bytes.Equal(userID, id) }) {
+		return ErrUserForbidden
+	}
+	return nil
+}
+
+// AuthorizeScope checks a principal holds a scope, for requests whose user ID
+// is only known once their body is read.
+func (p Principal) AuthorizeScope(scope string) (err error) {
+	if !slices.Contains(p.Scopes, ScopeAdmin) && !slices.Contains(p.Scopes, scope) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+type TokenVerifier interface {
+
+	// Verifies a bearer token, returning the principal it carries.
+	Verify(token string) (principal Principal, err error)
+
+	// Reports whether bearer tokens are accepted.
+	Enabled() bool
+}
+
+// Key verifying tokens signed with one algorithm, optionally named by key ID.
+type tokenKey struct {
+	kid string
+	alg string
+	key any
+}
+
+type tokenVerifierImpl struct {
+	keys     []tokenKey
+	issuer   string
+	audience string
+
+	// Permitted user IDs by subject. Nil permits each subject its own
+	// subject as user ID.
+	subjects map[string][][]byte
+}
+
+// Header and claims read from bearer tokens.
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Subject   string        `json:"sub"`
+	Issuer    string        `json:"iss"`
+	Audience  tokenAudience `json:"aud"`
+	ExpiresAt *float64      `json:"exp"`
+	NotBefore *float64      `json:"nbf"`
+	Scope     string        `json:"scope"`
+}
+
+// Audiences are a single string or an array of strings.
+type tokenAudience []string
+
+func (a *tokenAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = tokenAudience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// JSON web key, as listed in JWKS files.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+}
+
+// Decodes the HMAC, RSA and Ed25519 keys of a JWKS document.
+func parseJWKS(data []byte) (keys []tokenKey, err error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &set); err != nil {
+		return nil, errors.New("Error decoding JWKS: " + err.Error())
+	}
+
+	for _, jwk := range set.Keys {
+		key := tokenKey{kid: jwk.Kid}
+		switch {
+		case jwk.Kty == "oct":
+			key.alg = TokenHS256
+			key.key, err = base64.RawURLEncoding.DecodeString(jwk.K)
+		case jwk.Kty == "RSA":
+			key.alg = TokenRS256
+			key.key, err = parseRSAKey(jwk.N, jwk.E)
+		case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+			key.alg = TokenEdDSA
+			var x []byte
+			if x, err = base64.RawURLEncoding.DecodeString(jwk.X); err == nil && len(x) != ed25519.PublicKeySize {
+				err = errors.New("wrong key size")
+			}
+			key.key = ed25519.PublicKey(x)
+		default:
+			return nil, errors.New("JWKS key " + jwk.Kid + " has unsupported type " + jwk.Kty)
+		}
+		if err != nil {
+			return nil, errors.New("Error decoding JWKS key " + jwk.Kid + ": " + err.Error())
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func parseRSAKey(nStr, eStr string) (key *rsa.PublicKey, err error) {
+	n, err := base64.RawURLEncoding.DecodeString(nStr)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(eStr)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func verifyTokenSignature(key tokenKey, signed, signature []byte) bool {
+
+	// Each key verifies its own algorithm only, so an RSA public key is never
+	// taken for an HMAC secret.
+	switch k := key.key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, signed, signature)
+	}
+	return false
+}
+
+func (v *tokenVerifierImpl) Verify(token string) (principal Principal, err error) {
+
+	// Tokens are three base64url segments: header, claims and signature.
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return principal, ErrTokenInvalid
+	}
+	var header tokenHeader
+	var claims tokenClaims
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(headerJSON, &header) != nil {
+		return principal, ErrTokenInvalid
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(claimsJSON, &claims) != nil {
+		return principal, ErrTokenInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return principal, ErrTokenInvalid
+	}
+
+	// Verify the signature under a key of the token algorithm, and of its
+	// key ID when both name one.
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range v.keys {
+		if key.alg == header.Alg && (header.Kid == "" || key.kid == "" || key.kid == header.Kid) &&
+			verifyTokenSignature(key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return principal, ErrTokenInvalid
+	}
+
+	// Tokens must expire, and name their subject and any configured issuer
+	// and audience.
+	now := time.Now()
+	if claims.ExpiresAt == nil || now.After(time.UnixMilli(int64(*claims.ExpiresAt*1000)).Add(tokenLeeway)) {
+		return principal, ErrTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(tokenLeeway).Before(time.UnixMilli(int64(*claims.NotBefore*1000))) {
+		return principal, ErrTokenExpired
+	}
+	if claims.Subject == "" || (v.issuer != "" && claims.Issuer != v.issuer) ||
+		(v.audience != "" && !slices.Contains(claims.Audience, v.audience)) {
+		return principal, ErrTokenInvalid
+	}
+
+	// Tokens without a scope claim may store, retrieve and delete the
+	// records of their own user IDs. Unknown scopes are ignored.
+	principal.Subject = claims.Subject
+	if claims.Scope == "" {
+		principal.Scopes = []string{ScopeStore, ScopeRetrieve, ScopeDelete}
+	}
+	for _, scope := range strings.Fields(claims.Scope) {
+		if slices.Contains(scopes, scope) {
+			principal.Scopes = append(principal.Scopes, scope)
+		}
+	}
+	if v.subjects != nil {
+		principal.UserIDs = v.subjects[claims.Subject]
+	} else {
+		principal.UserIDs = [][]byte{[]byte(claims.Subject)}
+	}
+	return principal, nil
+}
+
+func (v *tokenVerifierImpl) Enabled() bool {
+	return len(v.keys) > 0
+}
+
+// ParseTokenSubjects decodes a JSON object listing the hex user IDs each
+// token subject may address.
+func ParseTokenSubjects(data []byte) (subjects map[string][][]byte, err error) {
+	var hexSubjects map[string][]string
+	if err = json.Unmarshal(data, &hexSubjects); err != nil {
+		return nil, errors.New("Error decoding token subjects: " + err.Error())
+	}
+
+	subjects = make(map[string][][]byte, len(hexSubjects))
+	for subject, idStrs := range hexSubjects {
+		for _, idStr := range idStrs {
+			id, err := hex.DecodeString(idStr)
+			if err != nil {
+				return nil, errors.New("Token subject " + subject + " has invalid user ID " + idStr)
+			}
+			subjects[subject] = append(subjects[subject], id)
+		}
+	}
+	return subjects, nil
+}
+
+// MakeTokenVerifier accepts HS256 bearer tokens under the secret jwtSecret,
+// and tokens under the keys of the JWKS file at jwksFile. Tokens must carry
+// any configured jwtIssuer and jwtAudience. Subjects address their own
+// subject as user ID, or the user IDs listed for them in jwtSubjectsFile.
+// Without a secret or JWKS, bearer tokens are not accepted.
+func MakeTokenVerifier(configs map[string]string) (v TokenVerifier, err error) {
+	impl := &tokenVerifierImpl{
+		issuer:   configs["jwtIssuer"],
+		audience: configs["jwtAudience"],
+	}
+
+	// HMAC secrets shorter than the SHA-256 output weaken the signature.
+	if secret := configs["jwtSecret"]; secret != "" {
+		if len(secret) < sha256.Size {
+			err = errors.New("MakeTokenVerifier cannot be configured with jwtSecret shorter than 32 bytes")
+			return nil, err
+		}
+		impl.keys = append(impl.keys, tokenKey{alg: TokenHS256, key: []byte(secret)})
+	}
+
+	if path := configs["jwksFile"]; path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("Error loading JWKS: " + err.Error())
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		impl.keys = append(impl.keys, keys...)
+	}
+
+	if path := configs["jwtSubjectsFile"]; path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("Error loading token subjects: " + err.Error())
+		}
+		if impl.subjects, err = ParseTokenSubjects(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return impl, nil
+}
@@ -3,7 +3,12 @@ package utils
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"strconv"
 )
@@ -54,6 +59,115 @@ func (k *keyGenImpl) RandomNonce(nonceSize int) (nonce []byte, err error) {
 	return nonce, nil
 }
 
+// KeyHash returns a commitment to a record key. The commitment may be stored
+// alongside the record to verify a presented key without revealing it.
+func KeyHash(key []byte) (hash []byte) {
+	sum := sha256.Sum256(key)
+	return sum[:]
+}
+
+// Info labelling the derivation of name keys from the ID key.
+const nameKeyInfo = "enc-server-go record-name"
+
+// NameKey derives the key of the nonces of sealed record names from the ID
+// key, so the ID cipher key is never reused as an HMAC key.
+func NameKey(idKey []byte) (nameKey []byte, err error) {
+	return hkdf.Key(sha256.New, idKey, nil, nameKeyInfo, sha256.Size)
+}
+
+// Info labelling the derivation of metadata keys from the ID key.
+const metadataKeyInfo = "enc-server-go record-metadata"
+
+// MetadataKey derives the key sealing record metadata from the ID key, so the
+// ID cipher, which reuses its nonce, never authenticates record attributes.
+func MetadataKey(idKey []byte) (metaKey []byte, err error) {
+	return hkdf.Key(sha256.New, idKey, nil, metadataKeyInfo, len(idKey))
+}
+
+// SealName deterministically encrypts a record name for a user ID. The nonce is
+// derived from the ID and name, so a name always seals to the same lookup value
+// without a nonce being reused across distinct names. Empty names are the
+// default record and are not sealed.
+func SealName(aead cipher.AEAD, nameKey, id, name []byte) (sealed []byte) {
+	if len(name) == 0 {
+		return nil
+	}
+
+	// Derive the nonce from the length-prefixed ID and the name.
+	mac := hmac.New(sha256.New, nameKey)
+	_ = binary.Write(mac, binary.BigEndian, uint64(len(id)))
+	mac.Write(id)
+	mac.Write(name)
+	nonce := mac.Sum(nil)[:aead.NonceSize()]
+
+	return aead.Seal(nonce, nonce, name, id)
+}
+
+// OpenName decrypts a record name sealed for a user ID by SealName.
+func OpenName(aead cipher.AEAD, id, sealed []byte) (name []byte, err error) {
+	if len(sealed) == 0 {
+		return nil, nil
+	} else if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("Sealed record name too short")
+	}
+
+	nonce, remainder := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, remainder, id)
+}
+
+// Record attributes sealed by the front-end service alongside each record.
+type RecordMetadata struct {
+	ContentType  string            `json:"contentType,omitempty"`
+	Size         int64             `json:"size"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Chunked      bool              `json:"chunked,omitempty"`
+	Compression  string            `json:"compression,omitempty"`
+	Padding      string            `json:"padding,omitempty"`
+	Unpadded     int64             `json:"unpadded,omitempty"`
+	KeySlots     []KeySlot         `json:"keySlots,omitempty"`
+	KeyHash      []byte            `json:"keyHash,omitempty"`
+	Digest       []byte            `json:"digest,omitempty"`
+	ClientSealed bool              `json:"clientSealed,omitempty"`
+}
+
+func metadataData(id, name []byte) (data []byte) {
+
+	// Bind metadata to the length-prefixed ID and the name of its record.
+	data = binary.BigEndian.AppendUint64(nil, uint64(len(id)))
+	data = append(data, id...)
+	return append(data, name...)
+}
+
+// SealMetadata encrypts the attributes of a named record for a user ID under a
+// random nonce.
+func SealMetadata(aead cipher.AEAD, nonce, id, name []byte, meta RecordMetadata) (sealed []byte, err error) {
+	plain, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plain, metadataData(id, name)), nil
+}
+
+// OpenMetadata decrypts record attributes sealed by SealMetadata. Records
+// stored without metadata report none.
+func OpenMetadata(aead cipher.AEAD, id, name, sealed []byte) (meta RecordMetadata, err error) {
+	if len(sealed) == 0 {
+		return RecordMetadata{}, nil
+	} else if len(sealed) < aead.NonceSize() {
+		return RecordMetadata{}, errors.New("Sealed record metadata too short")
+	}
+
+	nonce, remainder := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, remainder, metadataData(id, name))
+	if err != nil {
+		return RecordMetadata{}, err
+	}
+	if err = json.Unmarshal(plain, &meta); err != nil {
+		return RecordMetadata{}, err
+	}
+	return meta, nil
+}
+
 func MakeKeyGen(configs map[string]string) (k KeyGen, err error) {
 
 	// Verify required configurations.
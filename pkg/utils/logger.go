@@ -1,20 +1,40 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"io"
-	"io/fs"
 	"log/slog"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const defaultDir = "/tmp/enc-server-go-logs"
 
-// InitLogger configures structured JSON logging via log/slog.
-// It returns the log file handle (to be closed by caller) or an error.
-func InitLogger(serviceName string) (*os.File, error) {
+// Log files rotate at 100 MB or daily, keeping ten rotated files for at most
+// 30 days, unless the environment sets otherwise.
+var defaultRotation = rotation{
+	maxSize:  100 << 20,
+	interval: 24 * time.Hour,
+	maxFiles: 10,
+	maxAge:   30 * 24 * time.Hour,
+}
+
+// Level of the records logged, Info unless configured otherwise.
+var logLevel slog.LevelVar
+
+// InitLogger configures structured JSON logging via log/slog, at the level
+// and redaction policy set in the environment until ConfigureLogger applies
+// those of the service configs.
+// It returns the service log file, rotated as the environment sets and
+// reopened on SIGHUP, to be closed by the caller.
+func InitLogger(serviceName string) (io.Closer, error) {
+	if err := ConfigureLogger(nil); err != nil {
+		return nil, err
+	}
+
 	// 1. Determine log directory override.
 	logDir := defaultDir
 	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_DIR"); ok {
@@ -22,18 +42,16 @@ func InitLogger(serviceName string) (*os.File, error) {
 	}
 
 	// 2. Ensure log directory exists.
-	if err := os.MkdirAll(logDir, os.ModePerm); err != nil {
+	if err := os.MkdirAll(logDir, logDirPerm); err != nil {
 		return nil, err
 	}
 
-	// 3. Create log file with RFC3339 timestamp.
-	timestamp := time.Now().UTC().Format("20060102-150405")
-	fileName := serviceName + "." + timestamp + ".log"
-	logPath := filepath.Join(logDir, fileName)
-
-	logFileFlags := os.O_CREATE | os.O_APPEND | os.O_RDWR
-	logFilePerm := fs.FileMode(0666)
-	logFile, err := os.OpenFile(logPath, logFileFlags, logFilePerm)
+	// 3. Open the service log file, rotated as configured.
+	r, err := logRotation()
+	if err != nil {
+		return nil, err
+	}
+	logFile, err := openRotatingFile(logDir, serviceName, r)
 	if err != nil {
 		return nil, err
 	}
@@ -54,23 +72,111 @@ func InitLogger(serviceName string) (*os.File, error) {
 
 	// 6. Build structured JSON handler with service metadata.
 	handlerOpts := &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: &logLevel,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			// Format time to guarantee exact 6-digit microsecond width (padded with zeros)
 			if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
 				formattedTime := a.Value.Time().Format("2006-01-02T15:04:05.000000Z")
 				return slog.String(slog.TimeKey, formattedTime)
 			}
-			return a
+			return redactAttr(a)
 		},
 	}
 	jsonHandler := slog.NewJSONHandler(writer, handlerOpts)
 
-	// Create logger with pre-attached service attribute
-	logger := slog.New(jsonHandler).With(slog.String("service", serviceName))
+	// Create logger with pre-attached service attribute, adding the request ID
+	// carried in the context of each record.
+	logger := slog.New(requestIDHandler{jsonHandler}).With(slog.String("service", serviceName))
 
 	// Set as global default logger
 	slog.SetDefault(logger)
 
 	return logFile, nil
 }
+
+// Reads the rotation of log files from the environment:
+// ENC_SERVER_GO_LOG_MAX_SIZE_MB and ENC_SERVER_GO_LOG_ROTATE_INTERVAL rotate
+// files by size and age, ENC_SERVER_GO_LOG_MAX_FILES and
+// ENC_SERVER_GO_LOG_MAX_AGE limit the rotated files kept, and
+// ENC_SERVER_GO_LOG_COMPRESS gzips them. Zero turns a limit off.
+func logRotation() (r rotation, err error) {
+	r = defaultRotation
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_MAX_SIZE_MB"); ok {
+		size, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || size < 0 {
+			return r, errors.New("InitLogger cannot be configured with invalid ENC_SERVER_GO_LOG_MAX_SIZE_MB " + val)
+		}
+		r.maxSize = size << 20
+	}
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_ROTATE_INTERVAL"); ok {
+		if r.interval, err = time.ParseDuration(val); err != nil || r.interval < 0 {
+			return r, errors.New("InitLogger cannot be configured with invalid ENC_SERVER_GO_LOG_ROTATE_INTERVAL " + val)
+		}
+	}
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_MAX_FILES"); ok {
+		if r.maxFiles, err = strconv.Atoi(val); err != nil || r.maxFiles < 0 {
+			return r, errors.New("InitLogger cannot be configured with invalid ENC_SERVER_GO_LOG_MAX_FILES " + val)
+		}
+	}
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_MAX_AGE"); ok {
+		if r.maxAge, err = time.ParseDuration(val); err != nil || r.maxAge < 0 {
+			return r, errors.New("InitLogger cannot be configured with invalid ENC_SERVER_GO_LOG_MAX_AGE " + val)
+		}
+	}
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_COMPRESS"); ok {
+		if r.compress, err = strconv.ParseBool(val); err != nil {
+			return r, errors.New("InitLogger cannot be configured with invalid ENC_SERVER_GO_LOG_COMPRESS " + val)
+		}
+	}
+	return r, nil
+}
+
+// ConfigureLogger applies the logLevel (debug, info, warn or error),
+// logRedaction and logHashKey settings of a service. The ENC_SERVER_GO_LOG_LEVEL
+// and ENC_SERVER_GO_LOG_REDACTION environment variables take precedence.
+func ConfigureLogger(configs map[string]string) (err error) {
+	level := configs["logLevel"]
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_LEVEL"); ok {
+		level = val
+	}
+	if level != "" {
+		var parsed slog.Level
+		if err = parsed.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+			return errors.New("ConfigureLogger cannot be configured with invalid logLevel " + level)
+		}
+		logLevel.Set(parsed)
+	}
+
+	policy := configs["logRedaction"]
+	if val, ok := os.LookupEnv("ENC_SERVER_GO_LOG_REDACTION"); ok {
+		policy = val
+	}
+	return setRedaction(policy, configs["logHashKey"])
+}
+
+// Fatal logs an error that stops a command, with its attributes, then exits.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// Handler adding the request ID carried in the context of a record, so the
+// records of one request can be matched across services.
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		r.AddAttrs(slog.String("requestId", requestID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
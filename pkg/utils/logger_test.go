@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ConfigureLogger() - Test Method
+func TestLogger_ConfigureLogger(t *testing.T) {
+	keepRedaction(t)
+	prior := logLevel.Level()
+	t.Cleanup(func() { logLevel.Set(prior) })
+
+	tests := []struct {
+		name       string
+		configs    map[string]string
+		env        map[string]string
+		wantLevel  slog.Level
+		wantPolicy string
+		wantKey    string
+		wantErr    string
+	}{
+		{
+			name:       "should keep info level and hash by default",
+			configs:    map[string]string{},
+			wantLevel:  slog.LevelInfo,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should apply defaults without configs",
+			wantLevel:  slog.LevelInfo,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should parse debug level",
+			configs:    map[string]string{"logLevel": "debug"},
+			wantLevel:  slog.LevelDebug,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should parse level regardless of case and spaces",
+			configs:    map[string]string{"logLevel": " WARN "},
+			wantLevel:  slog.LevelWarn,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should parse level with offset",
+			configs:    map[string]string{"logLevel": "error+2"},
+			wantLevel:  slog.LevelError + 2,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:    "should fail with invalid level",
+			configs: map[string]string{"logLevel": "loud"},
+			wantErr: "ConfigureLogger cannot be configured with invalid logLevel loud",
+		},
+		{
+			name:       "should apply redaction policy and hash key",
+			configs:    map[string]string{"logRedaction": RedactHash, "logHashKey": logHashKeyStr},
+			wantLevel:  slog.LevelInfo,
+			wantPolicy: RedactHash,
+			wantKey:    logHashKeyStr,
+		},
+		{
+			name:       "should apply drop policy",
+			configs:    map[string]string{"logRedaction": RedactDrop},
+			wantLevel:  slog.LevelInfo,
+			wantPolicy: RedactDrop,
+		},
+		{
+			name:    "should fail with invalid redaction policy",
+			configs: map[string]string{"logRedaction": "mask"},
+			wantErr: "ConfigureLogger cannot be configured with invalid logRedaction mask",
+		},
+		{
+			name:       "should prefer environment level over configs",
+			configs:    map[string]string{"logLevel": "debug"},
+			env:        map[string]string{"ENC_SERVER_GO_LOG_LEVEL": "error"},
+			wantLevel:  slog.LevelError,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should prefer environment redaction over configs",
+			configs:    map[string]string{"logRedaction": RedactHash, "logHashKey": logHashKeyStr},
+			env:        map[string]string{"ENC_SERVER_GO_LOG_REDACTION": RedactDrop},
+			wantLevel:  slog.LevelInfo,
+			wantPolicy: RedactDrop,
+			wantKey:    logHashKeyStr,
+		},
+		{
+			name:       "should apply environment without configs",
+			env:        map[string]string{"ENC_SERVER_GO_LOG_LEVEL": "warn", "ENC_SERVER_GO_LOG_REDACTION": RedactDrop},
+			wantLevel:  slog.LevelWarn,
+			wantPolicy: RedactDrop,
+		},
+		{
+			name:    "should fail with invalid environment level over valid configs",
+			configs: map[string]string{"logLevel": "debug"},
+			env:     map[string]string{"ENC_SERVER_GO_LOG_LEVEL": "loud"},
+			wantErr: "ConfigureLogger cannot be configured with invalid logLevel loud",
+		},
+		{
+			name:    "should fail with invalid environment redaction over valid configs",
+			configs: map[string]string{"logRedaction": RedactDrop},
+			env:     map[string]string{"ENC_SERVER_GO_LOG_REDACTION": "mask"},
+			wantErr: "ConfigureLogger cannot be configured with invalid logRedaction mask",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Only the environment of the test case applies.
+			for _, key := range []string{"ENC_SERVER_GO_LOG_LEVEL", "ENC_SERVER_GO_LOG_REDACTION"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			logLevel.Set(slog.LevelInfo)
+			logRedaction.Store(nil)
+
+			err := ConfigureLogger(test.configs)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantLevel, logLevel.Level())
+			assert.Equal(t, &redaction{policy: test.wantPolicy, hashKey: []byte(test.wantKey)}, logRedaction.Load())
+		})
+	}
+}
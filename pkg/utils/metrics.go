@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Path metrics are served on.
+const MetricsPath = "/metrics"
+
+// Registry of the metrics each service exposes, along with Go runtime and
+// process metrics.
+var metricsRegistry = func() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	r.MustRegister(requestsTotal, requestDuration, requestErrors, mongoDuration, grpcClientDuration)
+	return r
+}()
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "enc_server_requests_total",
+		Help: "Requests served, by operation.",
+	}, []string{"operation"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "enc_server_request_duration_seconds",
+		Help:    "Time taken to serve requests, by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "enc_server_request_errors_total",
+		Help: "Requests refused or failed, by operation and error type.",
+	}, []string{"operation", "type"})
+
+	mongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "enc_server_mongo_operation_duration_seconds",
+		Help:    "Time taken by data store operations, by operation and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "outcome"})
+
+	grpcClientDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "enc_server_grpc_client_duration_seconds",
+		Help:    "Time taken by back-end GRPC calls, by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// Error types reported for the errors of this package. Other errors are
+// reported as internal.
+var errorTypes = []struct {
+	err      error
+	typeName string
+}{
+	{ErrPreconditionFailed, "precondition_failed"},
+	{ErrKeyMismatch, "key_mismatch"},
+	{ErrVersionNotFound, "not_found"},
+	{ErrRecordNotFound, "not_found"},
+	{ErrNoKeySlot, "not_found"},
+	{ErrUnauthenticated, "unauthenticated"},
+	{ErrTokenInvalid, "unauthenticated"},
+	{ErrTokenExpired, "unauthenticated"},
+	{ErrForbidden, "forbidden"},
+	{ErrUserForbidden, "forbidden"},
+	{ErrRateLimited, "rate_limited"},
+	{ErrSignerUnknown, "signature"},
+	{ErrSignatureInvalid, "signature"},
+	{ErrSignatureMissing, "signature"},
+	{ErrDecompressedTooLarge, "too_large"},
+	{ErrStreamTooLong, "too_large"},
+	{mongo.ErrNoDocuments, "not_found"},
+}
+
+// ErrorType names the type of an error for metrics, or returns empty for nil.
+// GRPC status errors are named by the codes the back-end service returns.
+func ErrorType(err error) (typeName string) {
+	if err == nil {
+		return ""
+	}
+	for _, t := range errorTypes {
+		if errors.Is(err, t.err) {
+			return t.typeName
+		}
+	}
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		return "precondition_failed"
+	case codes.PermissionDenied:
+		return "key_mismatch"
+	case codes.NotFound:
+		return "not_found"
+	}
+	return "internal"
+}
+
+// ErrorMessageType names the type of an error reported by its message, as on
+// the v1 socket protocol.
+func ErrorMessageType(message string) (typeName string) {
+	for _, t := range errorTypes {
+		if message == t.err.Error() {
+			return t.typeName
+		}
+	}
+	if message == "Malformed request" {
+		return "bad_request"
+	}
+	return "internal"
+}
+
+// StatusErrorType names the type of an error HTTP status, or returns empty
+// for other statuses.
+func StatusErrorType(code int) (typeName string) {
+	switch {
+	case code < http.StatusBadRequest:
+		return ""
+	case code == http.StatusBadRequest:
+		return "bad_request"
+	case code == http.StatusUnauthorized:
+		return "unauthenticated"
+	case code == http.StatusForbidden:
+		return "forbidden"
+	case code == http.StatusNotFound:
+		return "not_found"
+	case code == http.StatusPreconditionFailed:
+		return "precondition_failed"
+	case code == http.StatusRequestEntityTooLarge:
+		return "too_large"
+	case code == http.StatusTooManyRequests:
+		return "rate_limited"
+	case code < http.StatusInternalServerError:
+		return "client_error"
+	}
+	return "internal"
+}
+
+// ObserveRequest records a request served since start, failed with an error
+// of the given type unless empty.
+func ObserveRequest(operation string, start time.Time, errType string) {
+	requestsTotal.WithLabelValues(operation).Inc()
+	requestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if errType != "" {
+		requestErrors.WithLabelValues(operation, errType).Inc()
+	}
+}
+
+// Records a data store operation begun at start, for deferring with the
+// named error result of the operation.
+func observeMongo(operation string, start time.Time, err *error) {
+	outcome := "ok"
+	if *err != nil {
+		outcome = "error"
+	}
+	mongoDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+// MetricsUnaryServerInterceptor records the GRPC requests a server serves.
+func MetricsUnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	resp, err = handler(ctx, req)
+	ObserveRequest(info.FullMethod, start, ErrorType(err))
+	return resp, err
+}
+
+// MetricsStreamServerInterceptor records the GRPC streams a server serves.
+func MetricsStreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	err = handler(srv, ss)
+	ObserveRequest(info.FullMethod, start, ErrorType(err))
+	return err
+}
+
+// MetricsUnaryClientInterceptor records the GRPC calls a client makes.
+func MetricsUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+	start := time.Now()
+	err = invoker(ctx, method, req, reply, cc, opts...)
+	grpcClientDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// MetricsStreamClientInterceptor records the GRPC streams a client opens,
+// timed until opened.
+func MetricsStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (cs grpc.ClientStream, err error) {
+	start := time.Now()
+	cs, err = streamer(ctx, desc, cc, method, opts...)
+	grpcClientDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return cs, err
+}
+
+// MetricsHandler serves the metrics of this service in the Prometheus text
+// format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+// StartMetricsListener serves metrics at MetricsPath on its own port, for
+// services without an HTTP listener of their own. Without a port it does
+// nothing.
+func StartMetricsListener(port string) (err error) {
+	if port == "" {
+		return nil
+	}
+	if _, err = strconv.Atoi(port); err != nil {
+		return errors.New("Metrics listener cannot be configured with invalid port " + port)
+	}
+
+	l, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, MetricsHandler())
+
+	slog.Info("Serving metrics", "port", port)
+	go func() {
+		if err := http.Serve(l, mux); err != nil {
+			slog.Error("Metrics listener error", "error", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+	"strconv"
+)
+
+// Record padding schemes, applied before records are sealed so ciphertext
+// lengths reveal only a size bucket. Padding is stripped using the unpadded
+// length sealed in the record attributes.
+const (
+	PaddingPowerOfTwo = "pow2"
+	PaddingBlock      = "block"
+)
+
+// Default padding block size, also the smallest power-of-two bucket.
+const defaultPaddingBlockSize = 256
+
+// Returned when a padded record is shorter than its recorded contents.
+var ErrPaddingInvalid = errors.New("Padded record shorter than its contents")
+
+type Padder interface {
+
+	// Scheme applied to stored records. Empty when padding is disabled.
+	Scheme() string
+
+	// Padded length of record contents of the given length.
+	PaddedSize(size int64) (padded int64)
+}
+
+type padderImpl struct {
+	scheme    string
+	blockSize int64
+}
+
+func (p *padderImpl) Scheme() string {
+	return p.scheme
+}
+
+func (p *padderImpl) PaddedSize(size int64) (padded int64) {
+	switch p.scheme {
+	case PaddingPowerOfTwo:
+		if size <= p.blockSize {
+			return p.blockSize
+		}
+		return 1 << bits.Len64(uint64(size-1))
+	case PaddingBlock:
+		if size == 0 {
+			return p.blockSize
+		}
+		return (size + p.blockSize - 1) / p.blockSize * p.blockSize
+	}
+	return size
+}
+
+// Pad pads record contents held in memory with zeros.
+func Pad(p Padder, data []byte) (padded []byte) {
+	padded = make([]byte, p.PaddedSize(int64(len(data))))
+	copy(padded, data)
+	return padded
+}
+
+// Unpad strips padding from record contents of the given unpadded length.
+func Unpad(padded []byte, unpadded int64) (data []byte, err error) {
+	if unpadded < 0 || unpadded > int64(len(padded)) {
+		return nil, ErrPaddingInvalid
+	}
+	return padded[:unpadded], nil
+}
+
+// Writer padding record contents with zeros once closed.
+type PadWriter struct {
+	p        Padder
+	w        io.Writer
+	unpadded int64
+}
+
+func NewPadWriter(p Padder, w io.Writer) (pw *PadWriter) {
+	return &PadWriter{p: p, w: w}
+}
+
+func (pw *PadWriter) Write(b []byte) (n int, err error) {
+	n, err = pw.w.Write(b)
+	pw.unpadded += int64(n)
+	return n, err
+}
+
+// Unpadded reports the length of the contents written before padding.
+func (pw *PadWriter) Unpadded() int64 {
+	return pw.unpadded
+}
+
+func (pw *PadWriter) Close() (err error) {
+	zeros := make([]byte, StreamSegmentSize)
+	for remaining := pw.p.PaddedSize(pw.unpadded) - pw.unpadded; remaining > 0; {
+		n := min(remaining, int64(len(zeros)))
+		if _, err = pw.w.Write(zeros[:n]); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return nil
+}
+
+// Reader stripping padding from record contents of a known unpadded length.
+type unpadReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// NewUnpadReader returns a reader stripping padding from record contents of
+// the given unpadded length. The padding is read through, so readers
+// authenticating their input still detect truncated records.
+func NewUnpadReader(r io.Reader, unpadded int64) io.Reader {
+	return &unpadReader{r: r, remaining: unpadded}
+}
+
+func (u *unpadReader) Read(p []byte) (n int, err error) {
+	if u.remaining <= 0 {
+		if _, err = io.Copy(io.Discard, u.r); err != nil {
+			return 0, err
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > u.remaining {
+		p = p[:u.remaining]
+	}
+	n, err = u.r.Read(p)
+	u.remaining -= int64(n)
+	if errors.Is(err, io.EOF) && u.remaining > 0 {
+		return n, ErrPaddingInvalid
+	}
+	return n, err
+}
+
+func MakePadder(configs map[string]string) (p Padder, err error) {
+
+	// Padding is disabled unless a scheme is configured.
+	scheme := configs["padding"]
+	switch scheme {
+	case "", PaddingPowerOfTwo, PaddingBlock:
+	default:
+		err = errors.New("MakePadder cannot be configured with unsupported padding " + scheme)
+		return nil, err
+	}
+
+	// Initialize optional fields that require error handling.
+	var blockSize int64 = defaultPaddingBlockSize
+	if val, ok := configs["paddingBlockSize"]; ok {
+		if blockSize, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return nil, err
+		}
+		if blockSize <= 0 {
+			err = errors.New("MakePadder cannot be configured with non-positive paddingBlockSize")
+			return nil, err
+		}
+	}
+
+	p = &padderImpl{
+		scheme:    scheme,
+		blockSize: blockSize,
+	}
+	return p, nil
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Wildcard matching any existing record revision.
+const AnyRevision = "*"
+
+func FormatETag(revision int64) (tag string) {
+
+	// Revisions are transmitted as strong entity tags.
+	return `"` + strconv.FormatInt(revision, 10) + `"`
+}
+
+func ParseETag(tag string) (revision int64, err error) {
+
+	// Strip quotes from strong entity tag.
+	unquoted, ok := strings.CutPrefix(tag, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	if !ok {
+		err = errors.New("Malformed entity tag " + tag)
+		return 0, err
+	}
+
+	// Revisions start at one.
+	if revision, err = strconv.ParseInt(unquoted, 10, 64); err != nil || revision < 1 {
+		err = errors.New("Malformed entity tag " + tag)
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+func ParsePrecondition(ifMatch, ifNoneMatch string) (match Precondition, err error) {
+
+	// If-Match requires an existing record, optionally at a given revision.
+	if ifMatch == AnyRevision {
+		match.IfExists = true
+	} else if ifMatch != "" {
+		if match.IfMatch, err = ParseETag(ifMatch); err != nil {
+			return Precondition{}, err
+		}
+	}
+
+	// If-None-Match only supports the wildcard (create-only).
+	if ifNoneMatch == AnyRevision {
+		match.IfNotExists = true
+	} else if ifNoneMatch != "" {
+		err = errors.New("If-None-Match only supports " + AnyRevision)
+		return Precondition{}, err
+	}
+
+	if match.IfNotExists && (match.IfExists || match.IfMatch > 0) {
+		err = errors.New("If-Match and If-None-Match cannot be combined")
+		return Precondition{}, err
+	}
+
+	return match, nil
+}
+
+func (match Precondition) Headers() (ifMatch, ifNoneMatch string) {
+
+	// Compose If-Match and If-None-Match values, empty when unset.
+	if match.IfMatch > 0 {
+		ifMatch = FormatETag(match.IfMatch)
+	} else if match.IfExists {
+		ifMatch = AnyRevision
+	}
+	if match.IfNotExists {
+		ifNoneMatch = AnyRevision
+	}
+
+	return ifMatch, ifNoneMatch
+}
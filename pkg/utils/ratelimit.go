@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Returned when a client exceeds the request rate of an endpoint.
+var ErrRateLimited = errors.New("Rate limit exceeded")
+
+// Code of v1 protocol errors refusing rate limited requests, followed by the
+// seconds to wait before retrying.
+const RateLimitedCode = "RATELIMITED"
+
+// Buckets kept, beyond which the least recently used is dropped.
+const maxRateBuckets = 10000
+
+// Request rate allowed on an endpoint: a sustained rate per second, and a
+// burst of requests allowed at once.
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// ParseRateLimit decodes a rate limit written RATE/BURST, such as "5/10" for
+// five requests a second in bursts of up to ten. The burst defaults to the
+// rate, and to one for rates below one.
+func ParseRateLimit(s string) (limit RateLimit, err error) {
+	rateStr, burstStr, hasBurst := strings.Cut(s, "/")
+	if limit.Rate, err = strconv.ParseFloat(rateStr, 64); err != nil || limit.Rate <= 0 ||
+		math.IsInf(limit.Rate, 0) {
+		return limit, errors.New("Rate limit " + s + " must have a positive rate")
+	}
+	limit.Burst = max(math.Floor(limit.Rate), 1)
+	if hasBurst {
+		if limit.Burst, err = strconv.ParseFloat(burstStr, 64); err != nil || limit.Burst < 1 ||
+			math.IsInf(limit.Burst, 0) {
+			return limit, errors.New("Rate limit " + s + " must have a burst of at least one")
+		}
+	}
+	return limit, nil
+}
+
+type RateLimiter interface {
+
+	// Takes a request from the bucket of each key for an endpoint. When any
+	// bucket is empty none is taken from, and the wait before a retry may
+	// succeed is returned with ErrRateLimited.
+	Allow(endpoint string, keys ...string) (retryAfter time.Duration, err error)
+
+	// Reports whether any endpoint is rate limited.
+	Enabled() bool
+}
+
+// Token bucket, holding the requests allowed at its last update.
+type rateBucket struct {
+	name    string
+	tokens  float64
+	updated time.Time
+}
+
+type rateLimiterImpl struct {
+	fallback   *RateLimit
+	endpoints  map[string]RateLimit
+	maxBuckets int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	recent  *list.List // Buckets by last use, most recent first.
+}
+
+// CredentialHash returns the rate limit key of an API key or bearer token,
+// so buckets never hold credentials themselves.
+func CredentialHash(credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (r *rateLimiterImpl) limit(endpoint string) (limit RateLimit, ok bool) {
+	if limit, ok = r.endpoints[endpoint]; ok {
+		return limit, true
+	}
+	if r.fallback != nil {
+		return *r.fallback, true
+	}
+	return limit, false
+}
+
+// Returns the bucket of name, adding a full one when missing. Beyond the
+// buckets kept the least recently used is dropped, to refill as new should its
+// key return.
+func (r *rateLimiterImpl) bucket(name string, limit RateLimit, now time.Time) *rateBucket {
+	if elem, ok := r.buckets[name]; ok {
+		r.recent.MoveToFront(elem)
+		return elem.Value.(*rateBucket)
+	}
+	bucket := &rateBucket{name: name, tokens: limit.Burst, updated: now}
+	r.buckets[name] = r.recent.PushFront(bucket)
+	if r.recent.Len() > r.maxBuckets {
+		oldest := r.recent.Remove(r.recent.Back()).(*rateBucket)
+		delete(r.buckets, oldest.name)
+	}
+	return bucket
+}
+
+func (r *rateLimiterImpl) Allow(endpoint string, keys ...string) (retryAfter time.Duration, err error) {
+	limit, ok := r.limit(endpoint)
+	if !ok {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+
+	// Refill each bucket for the time since its last update, and find the
+	// longest wait for one request.
+	buckets := make([]*rateBucket, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		bucket := r.bucket(endpoint+"\x00"+key, limit, now)
+		bucket.tokens = min(bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.Rate, limit.Burst)
+		bucket.updated = now
+		if bucket.tokens < 1 {
+			wait := time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+			retryAfter = max(retryAfter, wait)
+		}
+		buckets = append(buckets, bucket)
+	}
+	if retryAfter > 0 {
+		return retryAfter, ErrRateLimited
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return 0, nil
+}
+
+func (r *rateLimiterImpl) Enabled() bool {
+	return r.fallback != nil || len(r.endpoints) > 0
+}
+
+// ParseRateLimits decodes a JSON object of rate limits by endpoint, written
+// as for ParseRateLimit.
+func ParseRateLimits(data []byte) (endpoints map[string]RateLimit, err error) {
+	var limitStrs map[string]string
+	if err = json.Unmarshal(data, &limitStrs); err != nil {
+		return nil, errors.New("Error decoding rate limits: " + err.Error())
+	}
+
+	endpoints = make(map[string]RateLimit, len(limitStrs))
+	for endpoint, limitStr := range limitStrs {
+		if endpoints[endpoint], err = ParseRateLimit(limitStr); err != nil {
+			return nil, err
+		}
+	}
+	return endpoints, nil
+}
+
+// MakeRateLimiter limits every endpoint to rateLimit, and the endpoints of the
+// JSON object at rateLimitsFile to their own limits. Without either setting
+// requests are not rate limited.
+func MakeRateLimiter(configs map[string]string) (r RateLimiter, err error) {
+	impl := &rateLimiterImpl{maxBuckets: maxRateBuckets, buckets: make(map[string]*list.Element), recent: list.New()}
+
+	if limitStr := configs["rateLimit"]; limitStr != "" {
+		limit, err := ParseRateLimit(limitStr)
+		if err != nil {
+			return nil, errors.New("MakeRateLimiter cannot be configured with rateLimit " + limitStr)
+		}
+		impl.fallback = &limit
+	}
+
+	if path := configs["rateLimitsFile"]; path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("Error loading rate limits: " + err.Error())
+		}
+		if impl.endpoints, err = ParseRateLimits(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return impl, nil
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"container/list"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Constants
+const rateEndpointStr = "GET /records/:id"
+
+// Allow() - Test Method
+func TestRateLimit_Allow(t *testing.T) {
+	type request struct {
+		endpoint string
+		keys     []string
+	}
+	tests := []struct {
+		name       string
+		limit      RateLimit
+		maxBuckets int
+		requests   []request
+		wantErrs   []bool
+		wantKept   int
+	}{
+		{
+			name:  "should allow requests within the burst",
+			limit: RateLimit{Rate: 1, Burst: 2},
+			requests: []request{{rateEndpointStr, []string{"ip:192.0.2.1"}},
+				{rateEndpointStr, []string{"ip:192.0.2.1"}}},
+			wantErrs: []bool{false, false},
+			wantKept: 1,
+		},
+		{
+			name:  "should limit requests beyond the burst",
+			limit: RateLimit{Rate: 1, Burst: 1},
+			requests: []request{{rateEndpointStr, []string{"ip:192.0.2.1"}},
+				{rateEndpointStr, []string{"ip:192.0.2.1"}}},
+			wantErrs: []bool{false, true},
+			wantKept: 1,
+		},
+		{
+			name:  "should limit requests when any bucket is empty",
+			limit: RateLimit{Rate: 1, Burst: 1},
+			requests: []request{{rateEndpointStr, []string{"ip:192.0.2.1", "id:4a5448"}},
+				{rateEndpointStr, []string{"ip:192.0.2.2", "id:4a5448"}},
+				{rateEndpointStr, []string{"ip:192.0.2.2", "id:00"}}},
+			wantErrs: []bool{false, true, false},
+			wantKept: 4,
+		},
+		{
+			name:  "should keep separate buckets by endpoint",
+			limit: RateLimit{Rate: 1, Burst: 1},
+			requests: []request{{rateEndpointStr, []string{"ip:192.0.2.1"}},
+				{"DELETE /records/:id", []string{"ip:192.0.2.1"}}},
+			wantErrs: []bool{false, false},
+			wantKept: 2,
+		},
+		{
+			name:  "should skip empty keys",
+			limit: RateLimit{Rate: 1, Burst: 1},
+			requests: []request{{rateEndpointStr, []string{"", "ip:192.0.2.1"}},
+				{rateEndpointStr, []string{""}}},
+			wantErrs: []bool{false, false},
+			wantKept: 1,
+		},
+		{
+			name:       "should drop the least recently used bucket beyond the maximum",
+			limit:      RateLimit{Rate: 1, Burst: 1},
+			maxBuckets: 2,
+			requests: []request{{rateEndpointStr, []string{"ip:192.0.2.1"}},
+				{rateEndpointStr, []string{"ip:192.0.2.2"}},
+				{rateEndpointStr, []string{"ip:192.0.2.1"}},
+				{rateEndpointStr, []string{"ip:192.0.2.3"}},
+				{rateEndpointStr, []string{"ip:192.0.2.2"}},
+				{rateEndpointStr, []string{"ip:192.0.2.1"}}},
+			wantErrs: []bool{false, false, true, false, false, false},
+			wantKept: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &rateLimiterImpl{fallback: &test.limit, maxBuckets: maxRateBuckets,
+				buckets: make(map[string]*list.Element), recent: list.New()}
+			if test.maxBuckets > 0 {
+				r.maxBuckets = test.maxBuckets
+			}
+
+			for i, req := range test.requests {
+				retryAfter, err := r.Allow(req.endpoint, req.keys...)
+				if test.wantErrs[i] {
+					assert.ErrorIs(t, err, ErrRateLimited)
+					assert.Positive(t, retryAfter)
+				} else {
+					assert.NoError(t, err)
+					assert.Zero(t, retryAfter)
+				}
+			}
+			assert.Len(t, r.buckets, test.wantKept)
+			assert.Equal(t, test.wantKept, r.recent.Len())
+		})
+	}
+}
+
+// Allow() of endpoints without limits - Test Method
+func TestRateLimit_AllowUnlimited(t *testing.T) {
+	r := &rateLimiterImpl{endpoints: map[string]RateLimit{"DELETE /records/:id": {Rate: 1, Burst: 1}},
+		maxBuckets: maxRateBuckets, buckets: make(map[string]*list.Element), recent: list.New()}
+
+	for range 3 {
+		retryAfter, err := r.Allow(rateEndpointStr, "ip:192.0.2.1")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), retryAfter)
+	}
+	assert.Empty(t, r.buckets)
+}
+
+// CredentialHash() - Test Method
+func TestRateLimit_CredentialHash(t *testing.T) {
+	tests := []struct {
+		name       string
+		credential string
+		other      string
+	}{
+		{
+			name:       "should hash API keys",
+			credential: "store-api-key",
+			other:      "admin-api-key",
+		},
+		{
+			name:       "should hash bearer tokens",
+			credential: "Bearer eyJhbGciOiJIUzI1NiJ9.e30.signature",
+			other:      "Bearer eyJhbGciOiJIUzI1NiJ9.e30.other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hash := CredentialHash(test.credential)
+			assert.Len(t, hash, 32)
+			assert.NotContains(t, hash, test.credential)
+			assert.Equal(t, hash, CredentialHash(test.credential))
+			assert.NotEqual(t, hash, CredentialHash(test.other))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+)
+
+// Context binding derived key-wrapping keys to their use.
+const keySlotInfo = "enc-server-go record key slot"
+
+// Context separating member key identifiers from other key hashes.
+const memberKeyInfo = "enc-server-go record member key"
+
+// Returned when a record holds no key slot for a recipient.
+var ErrNoKeySlot = errors.New("No key slot for recipient")
+
+// Record key wrapped for a recipient X25519 public key by hybrid encryption:
+// an ephemeral X25519 key agreement, HKDF-SHA256 and AES-GCM. Member slots
+// instead wrap the record key under a member's own AES key with AES-GCM and
+// are identified by the member key ID.
+type KeySlot struct {
+	Recipient []byte `json:"recipient,omitempty"`
+	Ephemeral []byte `json:"ephemeral,omitempty"`
+	KeyID     []byte `json:"keyId,omitempty"`
+	Wrapped   []byte `json:"wrapped"`
+}
+
+// GenerateRecipientKey generates an X25519 key pair for receiving records.
+func GenerateRecipientKey() (private, public []byte, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key.Bytes(), key.PublicKey().Bytes(), nil
+}
+
+// RecipientPublicKey derives the X25519 public key of a recipient private key.
+func RecipientPublicKey(private []byte) (public []byte, err error) {
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	return key.PublicKey().Bytes(), nil
+}
+
+// ParseRecipient validates an X25519 public key.
+func ParseRecipient(public []byte) (err error) {
+	_, err = ecdh.X25519().NewPublicKey(public)
+	return err
+}
+
+func keySlotCipher(shared, ephemeral, recipient []byte) (aead cipher.AEAD, err error) {
+
+	// Salt the derivation with both public keys of the agreement.
+	salt := append(append([]byte{}, ephemeral...), recipient...)
+	kek, err := hkdf.Key(sha256.New, shared, salt, keySlotInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// WrapKey wraps a record key for a recipient X25519 public key. Each slot
+// derives a fresh key from a fresh ephemeral key, so a zero nonce is not
+// reused.
+func WrapKey(recipient, key []byte) (slot KeySlot, err error) {
+	public, err := ecdh.X25519().NewPublicKey(recipient)
+	if err != nil {
+		return slot, err
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return slot, err
+	}
+	shared, err := ephemeral.ECDH(public)
+	if err != nil {
+		return slot, err
+	}
+
+	slot.Recipient, slot.Ephemeral = public.Bytes(), ephemeral.PublicKey().Bytes()
+	aead, err := keySlotCipher(shared, slot.Ephemeral, slot.Recipient)
+	if err != nil {
+		return KeySlot{}, err
+	}
+	slot.Wrapped = aead.Seal(nil, make([]byte, aead.NonceSize()), key, slot.Recipient)
+	return slot, nil
+}
+
+// UnwrapKey recovers a record key from a key slot with the recipient's
+// X25519 private key.
+func UnwrapKey(private []byte, slot KeySlot) (key []byte, err error) {
+	privateKey, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(privateKey.PublicKey().Bytes(), slot.Recipient) {
+		return nil, ErrNoKeySlot
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(slot.Ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := privateKey.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := keySlotCipher(shared, slot.Ephemeral, slot.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, aead.NonceSize()), slot.Wrapped, slot.Recipient)
+}
+
+// FindKeySlot returns the key slot of a recipient X25519 public key.
+func FindKeySlot(slots []KeySlot, recipient []byte) (slot KeySlot, err error) {
+	for _, slot = range slots {
+		if len(slot.Recipient) > 0 && bytes.Equal(slot.Recipient, recipient) {
+			return slot, nil
+		}
+	}
+	return KeySlot{}, ErrNoKeySlot
+}
+
+// ParseMemberKey validates a member AES key.
+func ParseMemberKey(member []byte) (err error) {
+	_, err = aes.NewCipher(member)
+	return err
+}
+
+// MemberKeyID identifies the key slot of a member key without revealing it.
+func MemberKeyID(member []byte) (keyID []byte) {
+	sum := sha256.Sum256(append([]byte(memberKeyInfo), member...))
+	return sum[:]
+}
+
+func memberCipher(member []byte) (aead cipher.AEAD, err error) {
+	block, err := aes.NewCipher(member)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// WrapMemberKey wraps a record key under a member AES key, so the member can
+// retrieve the record with their own key.
+func WrapMemberKey(member, key []byte) (slot KeySlot, err error) {
+	aead, err := memberCipher(member)
+	if err != nil {
+		return slot, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return slot, err
+	}
+
+	slot.KeyID = MemberKeyID(member)
+	slot.Wrapped = aead.Seal(nonce, nonce, key, slot.KeyID)
+	return slot, nil
+}
+
+// MemberKey recovers a record key from the key slot of a member key.
+func MemberKey(slots []KeySlot, member []byte) (key []byte, err error) {
+	keyID := MemberKeyID(member)
+	for _, slot := range slots {
+		if !bytes.Equal(slot.KeyID, keyID) {
+			continue
+		}
+		aead, err := memberCipher(member)
+		if err != nil {
+			return nil, err
+		} else if len(slot.Wrapped) < aead.NonceSize() {
+			return nil, errors.New("Wrapped record key too short")
+		}
+		nonce, remainder := slot.Wrapped[:aead.NonceSize()], slot.Wrapped[aead.NonceSize():]
+		return aead.Open(nil, nonce, remainder, slot.KeyID)
+	}
+	return nil, ErrNoKeySlot
+}
+
+// KeySlotID identifies a key slot by its recipient public key or member key ID.
+func KeySlotID(slot KeySlot) (slotID []byte) {
+	if len(slot.KeyID) > 0 {
+		return slot.KeyID
+	}
+	return slot.Recipient
+}
+
+// SetKeySlot adds a key slot, replacing any slot with the same identifier.
+func SetKeySlot(slots []KeySlot, slot KeySlot) (result []KeySlot) {
+	for i := range slots {
+		if bytes.Equal(KeySlotID(slots[i]), KeySlotID(slot)) {
+			result = append([]KeySlot{}, slots...)
+			result[i] = slot
+			return result
+		}
+	}
+	return append(append([]KeySlot{}, slots...), slot)
+}
+
+// RemoveKeySlot removes the key slot with the given identifier.
+func RemoveKeySlot(slots []KeySlot, slotID []byte) (result []KeySlot, err error) {
+	for i := range slots {
+		if bytes.Equal(KeySlotID(slots[i]), slotID) {
+			result = append(append([]KeySlot{}, slots[:i]...), slots[i+1:]...)
+			return result, nil
+		}
+	}
+	return slots, ErrNoKeySlot
+}
+
+// AuthorizeOwner verifies a presented key is the record key itself, against the
+// commitment sealed in the record metadata. Member keys only grant reads, so
+// they cannot delete a record or manage its key slots.
+func AuthorizeOwner(meta RecordMetadata, presented []byte) (err error) {
+
+	// Records stored before key commitments were sealed cannot be verified.
+	if len(meta.KeyHash) == 0 || subtle.ConstantTimeCompare(meta.KeyHash, KeyHash(presented)) != 1 {
+		return ErrKeyMismatch
+	}
+	return nil
+}
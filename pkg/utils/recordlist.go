@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholder for an empty cursor or attribute in socket protocol messages.
+const emptyField = "-"
+
+func formatHex(data []byte) (field string) {
+	if len(data) == 0 {
+		return emptyField
+	}
+	return hex.EncodeToString(data)
+}
+
+func parseHex(field string) (data []byte, err error) {
+	if field == emptyField {
+		return nil, nil
+	}
+	return hex.DecodeString(field)
+}
+
+// UnixMilli converts a record timestamp to Unix milliseconds. Records stored
+// before timestamps were kept report zero.
+func UnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// FromUnixMilli converts Unix milliseconds reported by UnixMilli to a record
+// timestamp.
+func FromUnixMilli(msec int64) time.Time {
+	if msec == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(msec)
+}
+
+// FormatRecordList encodes a page of record summaries for the socket
+// protocol: the next cursor (or "-") followed by one hex name, size, creation
+// and update time (Unix milliseconds), hex content type and hex sealed
+// metadata field per record, comma separated. Empty attributes are "-".
+func FormatRecordList(records []RecordSummary, next string) (message string) {
+	if next == "" {
+		next = emptyField
+	}
+
+	fields := []string{next}
+	for _, record := range records {
+		fields = append(fields, strings.Join([]string{
+			hex.EncodeToString(record.Name),
+			strconv.FormatInt(record.Size, 10),
+			strconv.FormatInt(UnixMilli(record.Created), 10),
+			strconv.FormatInt(UnixMilli(record.Updated), 10),
+			formatHex([]byte(record.ContentType)),
+			formatHex(record.Metadata),
+		}, ","))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// ParseRecordList decodes a page of record summaries encoded by
+// FormatRecordList.
+func ParseRecordList(message string) (records []RecordSummary, next string, err error) {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return nil, "", errors.New("Malformed response")
+	}
+
+	next = fields[0]
+	if next == emptyField {
+		next = ""
+	}
+
+	for _, field := range fields[1:] {
+		attrs := strings.Split(field, ",")
+		if len(attrs) != 6 {
+			return nil, "", errors.New("Malformed response")
+		}
+
+		var record RecordSummary
+		if record.Name, err = hex.DecodeString(attrs[0]); err != nil {
+			return nil, "", err
+		}
+		if record.Size, err = strconv.ParseInt(attrs[1], 10, 64); err != nil {
+			return nil, "", err
+		}
+		var created, updated int64
+		if created, err = strconv.ParseInt(attrs[2], 10, 64); err != nil {
+			return nil, "", err
+		}
+		if updated, err = strconv.ParseInt(attrs[3], 10, 64); err != nil {
+			return nil, "", err
+		}
+		record.Created, record.Updated = FromUnixMilli(created), FromUnixMilli(updated)
+		var contentType []byte
+		if contentType, err = parseHex(attrs[4]); err != nil {
+			return nil, "", err
+		}
+		record.ContentType = string(contentType)
+		if record.Metadata, err = parseHex(attrs[5]); err != nil {
+			return nil, "", err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, next, nil
+}
+
+// OpenRecordList decrypts the sealed names and, under the metadata cipher, the
+// metadata of records listed for a user ID. Records stored without metadata report the size of their contents,
+// excluding the nonce and tag the front-end adds to every record.
+func OpenRecordList(aead, metaAEAD cipher.AEAD, id []byte, records []RecordSummary) (err error) {
+	overhead := int64(aead.NonceSize() + aead.Overhead())
+	for i := range records {
+		if records[i].Name, err = OpenName(aead, id, records[i].Name); err != nil {
+			return err
+		}
+		if len(records[i].Metadata) == 0 {
+			records[i].Size = max(records[i].Size-overhead, 0)
+			continue
+		}
+
+		meta, err := OpenMetadata(metaAEAD, id, records[i].Name, records[i].Metadata)
+		if err != nil {
+			return err
+		}
+		records[i].Size, records[i].ContentType = meta.Size, meta.ContentType
+		records[i].Metadata = nil
+	}
+
+	return nil
+}
+
+// OpenRecordInfo decrypts the sealed metadata of a named record for a user ID
+// into its record attributes.
+func OpenRecordInfo(aead cipher.AEAD, id, name []byte, info RecordInfo) (result RecordInfo, err error) {
+	meta, err := OpenMetadata(aead, id, name, info.Metadata)
+	if err != nil {
+		return RecordInfo{}, err
+	}
+
+	info.Size, info.ContentType, info.Labels = meta.Size, meta.ContentType, meta.Labels
+	info.Chunked, info.Compression = meta.Chunked, meta.Compression
+	info.Padding, info.Unpadded = meta.Padding, meta.Unpadded
+	info.KeySlots, info.Digest = meta.KeySlots, meta.Digest
+	info.Metadata = nil
+	return info, nil
+}
+
+// FormatRecordInfo encodes record attributes for the socket protocol: the
+// version, creation and update time (Unix milliseconds), size, hex content
+// type, hex JSON labels, hex sealed metadata and hex record signatures, space
+// separated. Empty attributes are "-".
+func FormatRecordInfo(info RecordInfo) (message string) {
+	var labels []byte
+	if len(info.Labels) > 0 {
+		labels, _ = json.Marshal(info.Labels)
+	}
+
+	return strings.Join([]string{
+		strconv.FormatInt(info.Version, 10),
+		strconv.FormatInt(UnixMilli(info.Created), 10),
+		strconv.FormatInt(UnixMilli(info.Updated), 10),
+		strconv.FormatInt(info.Size, 10),
+		formatHex([]byte(info.ContentType)),
+		formatHex(labels),
+		formatHex(info.Metadata),
+		formatHex(info.Signatures),
+	}, " ")
+}
+
+// Number of fields encoded by FormatRecordInfo.
+const RecordInfoFields = 8
+
+// ParseRecordInfo decodes record attributes encoded by FormatRecordInfo.
+func ParseRecordInfo(fields []string) (info RecordInfo, err error) {
+	if len(fields) != RecordInfoFields {
+		return RecordInfo{}, errors.New("Malformed response")
+	}
+
+	if info.Version, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return RecordInfo{}, err
+	}
+	var created, updated int64
+	if created, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return RecordInfo{}, err
+	}
+	if updated, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+		return RecordInfo{}, err
+	}
+	info.Created, info.Updated = FromUnixMilli(created), FromUnixMilli(updated)
+	if info.Size, err = strconv.ParseInt(fields[3], 10, 64); err != nil {
+		return RecordInfo{}, err
+	}
+
+	var contentType, labels []byte
+	if contentType, err = parseHex(fields[4]); err != nil {
+		return RecordInfo{}, err
+	}
+	info.ContentType = string(contentType)
+	if labels, err = parseHex(fields[5]); err != nil {
+		return RecordInfo{}, err
+	}
+	if len(labels) > 0 {
+		if err = json.Unmarshal(labels, &info.Labels); err != nil {
+			return RecordInfo{}, err
+		}
+	}
+	if info.Metadata, err = parseHex(fields[6]); err != nil {
+		return RecordInfo{}, err
+	}
+	if info.Signatures, err = parseHex(fields[7]); err != nil {
+		return RecordInfo{}, err
+	}
+
+	return info, nil
+}
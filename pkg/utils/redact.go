@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Redaction policies for the user IDs and record names logged: hashed, so the
+// records of one user can still be matched, or dropped.
+const (
+	RedactHash = "hash"
+	RedactDrop = "drop"
+)
+
+// Keys of log attributes holding user IDs or record names, redacted by policy.
+var redactedKeys = map[string]bool{
+	"id":   true,
+	"name": true,
+}
+
+// Keys of log attributes holding keys, credentials or record contents, never
+// logged whatever the policy.
+var secretKeys = map[string]bool{
+	"key":       true,
+	"apiKey":    true,
+	"token":     true,
+	"share":     true,
+	"record":    true,
+	"payload":   true,
+	"data":      true,
+	"signature": true,
+	"configs":   true,
+}
+
+// Redaction policy in force, with the key of its hashes, if any.
+type redaction struct {
+	policy  string
+	hashKey []byte
+}
+
+var logRedaction atomic.Pointer[redaction]
+
+func setRedaction(policy, hashKey string) (err error) {
+	switch policy {
+	case "":
+		policy = RedactHash
+	case RedactHash, RedactDrop:
+	default:
+		return errors.New("ConfigureLogger cannot be configured with invalid logRedaction " + policy)
+	}
+	logRedaction.Store(&redaction{policy: policy, hashKey: []byte(hashKey)})
+	return nil
+}
+
+// Hash of a logged value, keyed when a hash key is configured so values
+// cannot be recovered by hashing guesses.
+func (r *redaction) hash(value string) string {
+	var sum []byte
+	if len(r.hashKey) > 0 {
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		sum = mac.Sum(nil)
+	} else {
+		digest := sha256.Sum256([]byte(value))
+		sum = digest[:]
+	}
+	return hex.EncodeToString(sum[:8])
+}
+
+// Redacts a log attribute by its key. Dropped attributes are returned empty,
+// which handlers omit.
+func redactAttr(a slog.Attr) slog.Attr {
+	switch {
+	case secretKeys[a.Key]:
+		return slog.Attr{}
+	case redactedKeys[a.Key]:
+		r := logRedaction.Load()
+		if r == nil || r.policy == RedactDrop || a.Value.String() == "" {
+			return slog.Attr{}
+		}
+		return slog.String(a.Key, r.hash(a.Value.String()))
+	}
+	return a
+}
+
+// ErrorLevel is the level errors are logged at: errors of the service itself
+// at Error, and those of its callers, such as missing records or mismatched
+// keys, at Warn.
+func ErrorLevel(err error) slog.Level {
+	if ErrorType(err) == "internal" {
+		return slog.LevelError
+	}
+	return slog.LevelWarn
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Constants
+const idHexStr = "4a5448"
+const nameHexStr = "6e6f746573"
+const logHashKeyStr = "log-hash-key"
+
+// Hashes of the test ID and name, unkeyed and keyed with the test hash key.
+const idHashStr = "af39c6dd1bbb8721"
+const idKeyedHashStr = "d463dac601b607d7"
+const nameHashStr = "1189c7478acd6d65"
+
+// Restores the redaction policy in force once a test ends.
+func keepRedaction(t *testing.T) {
+	prior := logRedaction.Load()
+	t.Cleanup(func() { logRedaction.Store(prior) })
+}
+
+// redactAttr() - Test Method
+func TestRedact_redactAttr(t *testing.T) {
+	keepRedaction(t)
+
+	tests := []struct {
+		name    string
+		policy  string
+		hashKey string
+		unset   bool
+		attr    slog.Attr
+		want    slog.Attr
+	}{
+		{
+			name:   "should hash user IDs",
+			policy: RedactHash,
+			attr:   slog.String("id", idHexStr),
+			want:   slog.String("id", idHashStr),
+		},
+		{
+			name:   "should hash record names",
+			policy: RedactHash,
+			attr:   slog.String("name", nameHexStr),
+			want:   slog.String("name", nameHashStr),
+		},
+		{
+			name:    "should hash user IDs with hash key",
+			policy:  RedactHash,
+			hashKey: logHashKeyStr,
+			attr:    slog.String("id", idHexStr),
+			want:    slog.String("id", idKeyedHashStr),
+		},
+		{
+			name:   "should hash under default policy",
+			policy: "",
+			attr:   slog.String("id", idHexStr),
+			want:   slog.String("id", idHashStr),
+		},
+		{
+			name:   "should drop user IDs",
+			policy: RedactDrop,
+			attr:   slog.String("id", idHexStr),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should drop record names",
+			policy: RedactDrop,
+			attr:   slog.String("name", nameHexStr),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should drop empty user IDs rather than hash them",
+			policy: RedactHash,
+			attr:   slog.String("id", ""),
+			want:   slog.Attr{},
+		},
+		{
+			name:  "should drop user IDs before a policy is configured",
+			unset: true,
+			attr:  slog.String("id", idHexStr),
+			want:  slog.Attr{},
+		},
+		{
+			name:   "should drop record keys whatever the policy",
+			policy: RedactHash,
+			attr:   slog.String("key", "00112233"),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should drop API keys whatever the policy",
+			policy: RedactHash,
+			attr:   slog.String("apiKey", "store-api-key"),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should drop record contents whatever the policy",
+			policy: RedactHash,
+			attr:   slog.String("data", "secret"),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should drop configs whatever the policy",
+			policy: RedactDrop,
+			attr:   slog.Any("configs", map[string]string{"idKey": "secret"}),
+			want:   slog.Attr{},
+		},
+		{
+			name:   "should keep other attributes",
+			policy: RedactDrop,
+			attr:   slog.String("client", "ci"),
+			want:   slog.String("client", "ci"),
+		},
+		{
+			name:   "should keep other attributes under hash policy",
+			policy: RedactHash,
+			attr:   slog.Int64("version", 3),
+			want:   slog.Int64("version", 3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.unset {
+				logRedaction.Store(nil)
+			} else {
+				assert.NoError(t, setRedaction(test.policy, test.hashKey))
+			}
+			assert.Equal(t, test.want, redactAttr(test.attr))
+		})
+	}
+}
+
+// setRedaction() - Test Method
+func TestRedact_setRedaction(t *testing.T) {
+	keepRedaction(t)
+
+	tests := []struct {
+		name       string
+		policy     string
+		hashKey    string
+		wantPolicy string
+		wantErr    string
+	}{
+		{
+			name:       "should default to hashing",
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should set hash policy with key",
+			policy:     RedactHash,
+			hashKey:    logHashKeyStr,
+			wantPolicy: RedactHash,
+		},
+		{
+			name:       "should set drop policy",
+			policy:     RedactDrop,
+			wantPolicy: RedactDrop,
+		},
+		{
+			name:    "should fail with invalid policy",
+			policy:  "mask",
+			wantErr: "ConfigureLogger cannot be configured with invalid logRedaction mask",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logRedaction.Store(nil)
+			err := setRedaction(test.policy, test.hashKey)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				assert.Nil(t, logRedaction.Load())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &redaction{policy: test.wantPolicy, hashKey: []byte(test.hashKey)}, logRedaction.Load())
+		})
+	}
+}
+
+// Records logged through a handler redacting their attributes - Test Method
+func TestRedact_handler(t *testing.T) {
+	keepRedaction(t)
+
+	tests := []struct {
+		name   string
+		policy string
+		want   map[string]any
+	}{
+		{
+			name:   "should log hashed user IDs and names",
+			policy: RedactHash,
+			want:   map[string]any{"msg": "stored", "id": idHashStr, "name": nameHashStr, "client": "ci"},
+		},
+		{
+			name:   "should log without user IDs and names",
+			policy: RedactDrop,
+			want:   map[string]any{"msg": "stored", "client": "ci"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NoError(t, setRedaction(test.policy, ""))
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+						return slog.Attr{}
+					}
+					return redactAttr(a)
+				},
+			}))
+			logger.Info("stored", "id", idHexStr, "name", nameHexStr, "key", "00112233", "client", "ci")
+
+			var got map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
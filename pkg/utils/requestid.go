@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header carrying request IDs on the REST API, accepted from callers and
+// returned in responses.
+const RequestIDHeader = "X-Request-ID"
+
+// Option of v1 protocol requests carrying their request ID, sent last.
+const RequestIDOption = "REQUEST"
+
+// GRPC metadata key carrying request IDs to the back-end service.
+const requestIDMetadata = "x-request-id"
+
+// Request IDs accepted from callers are printable ASCII without spaces, of at
+// most this length, so they fit the v1 protocol and cannot forge log lines.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() (requestID string) {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ValidRequestID reports whether a request ID may be accepted from a caller.
+func ValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// ContextWithRequestID carries a request ID in ctx, for logging and passing
+// on to the back-end service.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried in ctx, if any.
+func RequestIDFromContext(ctx context.Context) (requestID string) {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ = ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// CutRequestID removes the request ID option ending a v1 protocol request,
+// returning the request without it and the request ID.
+func CutRequestID(message string) (request, requestID string) {
+	trimmed := strings.TrimRight(message, " \n")
+	i := strings.LastIndexByte(trimmed, ' ')
+	if i < 0 {
+		return message, ""
+	}
+	j := strings.LastIndexByte(trimmed[:i], ' ')
+	if j < 0 || trimmed[j+1:i] != RequestIDOption {
+		return message, ""
+	}
+	return trimmed[:j], trimmed[i+1:]
+}
+
+// Adds the request ID of ctx, if any, to the outgoing metadata of a GRPC call.
+func outgoingRequestID(ctx context.Context) context.Context {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return metadata.AppendToOutgoingContext(ctx, requestIDMetadata, requestID)
+	}
+	return ctx
+}
+
+// Carries the request ID of incoming GRPC metadata in ctx, or a new one for
+// requests without a valid ID.
+func incomingRequestID(ctx context.Context) context.Context {
+	requestID := ""
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDMetadata); len(values) > 0 {
+		requestID = values[0]
+	}
+	if !ValidRequestID(requestID) {
+		requestID = NewRequestID()
+	}
+	return ContextWithRequestID(ctx, requestID)
+}
+
+// RequestIDUnaryClientInterceptor passes the request ID of GRPC calls in their
+// metadata.
+func RequestIDUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+	return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+// RequestIDStreamClientInterceptor passes the request ID of GRPC streams in
+// their metadata.
+func RequestIDStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (cs grpc.ClientStream, err error) {
+	return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+}
+
+// RequestIDUnaryServerInterceptor carries the request ID of GRPC requests in
+// their context.
+func RequestIDUnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp any, err error) {
+	return handler(incomingRequestID(ctx), req)
+}
+
+// RequestIDStreamServerInterceptor carries the request ID of GRPC streams in
+// their context.
+func RequestIDStreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: incomingRequestID(ss.Context())})
+}
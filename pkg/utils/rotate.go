@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Permissions of log files and their directory, readable by the service
+// group only.
+const (
+	logFilePerm = 0o640
+	logDirPerm  = 0o750
+)
+
+// Format of the timestamps naming rotated log files.
+const rotatedFormat = "20060102-150405.000"
+
+// Rotation and retention of a log file. Zero settings turn the rule off.
+type rotation struct {
+	maxSize  int64         // Bytes written before rotating.
+	interval time.Duration // Time after opening before rotating.
+	maxFiles int           // Rotated files kept.
+	maxAge   time.Duration // Age of rotated files kept.
+	compress bool          // Rotated files are gzipped.
+}
+
+// Log file written at a fixed path, rotated by size and age to files named by
+// their rotation time, and reopened on SIGHUP for log shippers that move the
+// file themselves.
+type rotatingFile struct {
+	dir, service string
+	rotation
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+
+	hup      chan os.Signal
+	done     chan struct{}
+	cleaning sync.WaitGroup
+}
+
+func openRotatingFile(dir, service string, r rotation) (f *rotatingFile, err error) {
+	f = &rotatingFile{dir: dir, service: service, rotation: r}
+	if err = f.open(); err != nil {
+		return nil, err
+	}
+
+	f.hup, f.done = make(chan os.Signal, 1), make(chan struct{})
+	signal.Notify(f.hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-f.hup:
+				f.Reopen()
+			case <-f.done:
+				return
+			}
+		}
+	}()
+	return f, nil
+}
+
+// Path of the file being written.
+func (f *rotatingFile) path() string {
+	return filepath.Join(f.dir, f.service+".log")
+}
+
+// Opens the file for appending, picking up the size of any existing file and
+// its modification time, so files left by earlier processes still rotate on
+// their interval.
+func (f *rotatingFile) open() (err error) {
+	file, err := os.OpenFile(f.path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, logFilePerm)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.opened = file, info.Size(), info.ModTime()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.due(int64(len(p))) {
+		f.rotate()
+	}
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Reports whether a write of n bytes is due a rotation first. Empty files are
+// never rotated, so oversized records are still written.
+func (f *rotatingFile) due(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	return (f.maxSize > 0 && f.size+n > f.maxSize) || (f.interval > 0 && time.Since(f.opened) >= f.interval)
+}
+
+// Moves the file aside under its rotation time and opens a new one, then
+// compresses and prunes rotated files in the background. Should the move
+// fail, writing carries on to the current file.
+func (f *rotatingFile) rotate() {
+	rotated := filepath.Join(f.dir, f.service+"."+time.Now().UTC().Format(rotatedFormat)+".log")
+	if err := os.Rename(f.path(), rotated); err != nil {
+		return
+	}
+	old := f.file
+	if err := f.open(); err != nil {
+		f.file = old
+		return
+	}
+	old.Close()
+
+	f.cleaning.Add(1)
+	go func() {
+		defer f.cleaning.Done()
+		if f.compress {
+			gzipFile(rotated)
+		}
+		f.prune()
+	}()
+}
+
+// Reopen closes the file and opens it afresh at its path, after a log shipper
+// has moved it aside.
+func (f *rotatingFile) Reopen() (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	old := f.file
+	if err = f.open(); err != nil {
+		return err
+	}
+	return old.Close()
+}
+
+// Removes rotated files beyond the number or age kept, newest kept first.
+// Files named by the start time of earlier processes are rotated files too.
+func (f *rotatingFile) prune() {
+	if f.maxFiles <= 0 && f.maxAge <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(filepath.Join(f.dir, f.service+".*.log*"))
+	if err != nil {
+		return
+	}
+
+	type rotatedFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []rotatedFile
+	for _, path := range matches {
+		if !strings.HasSuffix(path, ".log") && !strings.HasSuffix(path, ".log.gz") {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			files = append(files, rotatedFile{path, info.ModTime()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+
+	for i, file := range files {
+		if (f.maxFiles > 0 && i >= f.maxFiles) || (f.maxAge > 0 && time.Since(file.modTime) > f.maxAge) {
+			os.Remove(file.path)
+		}
+	}
+}
+
+// Compresses a rotated file to a gzip file beside it, removing the original
+// once written.
+func gzipFile(path string) (err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, logFilePerm)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	if _, err = io.Copy(zw, in); err == nil {
+		err = zw.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+// Close stops reopening on SIGHUP, waits for rotated files to be compressed
+// and closes the file.
+func (f *rotatingFile) Close() (err error) {
+	signal.Stop(f.hup)
+	close(f.done)
+	f.cleaning.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
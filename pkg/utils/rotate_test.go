@@ -0,0 +1,267 @@
+package utils
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Constants
+const logServiceStr = "feserver"
+
+// Rotated files of the test service, oldest name first.
+func rotatedFiles(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, logServiceStr+".*.log*"))
+	assert.NoError(t, err)
+	sort.Strings(matches)
+	return matches
+}
+
+// Contents of a file, decompressed when gzipped.
+func readLogFile(t *testing.T, path string) string {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var r io.Reader = file
+	if filepath.Ext(path) == ".gz" {
+		zr, err := gzip.NewReader(file)
+		assert.NoError(t, err)
+		r = zr
+	}
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+// Write() - Test Method
+func TestRotate_Write(t *testing.T) {
+	tests := []struct {
+		name         string
+		rotation     rotation
+		existing     string
+		existingAge  time.Duration
+		writes       []string
+		wantCurrent  string
+		wantRotated  []string
+		wantCompress bool
+	}{
+		{
+			name:        "should append without rotating within limits",
+			rotation:    rotation{maxSize: 64, interval: time.Hour},
+			writes:      []string{"first\n", "second\n"},
+			wantCurrent: "first\nsecond\n",
+		},
+		{
+			name:        "should rotate once size limit would be exceeded",
+			rotation:    rotation{maxSize: 10},
+			writes:      []string{"first\n", "second\n"},
+			wantCurrent: "second\n",
+			wantRotated: []string{"first\n"},
+		},
+		{
+			name:        "should write oversized records to empty files",
+			rotation:    rotation{maxSize: 4},
+			writes:      []string{"oversized\n"},
+			wantCurrent: "oversized\n",
+		},
+		{
+			name:        "should rotate by size of existing file",
+			rotation:    rotation{maxSize: 10},
+			existing:    "earlier\n",
+			writes:      []string{"first\n"},
+			wantCurrent: "first\n",
+			wantRotated: []string{"earlier\n"},
+		},
+		{
+			name:        "should rotate existing file past its interval",
+			rotation:    rotation{interval: time.Hour},
+			existing:    "earlier\n",
+			existingAge: 2 * time.Hour,
+			writes:      []string{"first\n", "second\n"},
+			wantCurrent: "first\nsecond\n",
+			wantRotated: []string{"earlier\n"},
+		},
+		{
+			name:        "should not rotate existing file within its interval",
+			rotation:    rotation{interval: time.Hour},
+			existing:    "earlier\n",
+			existingAge: time.Minute,
+			writes:      []string{"first\n"},
+			wantCurrent: "earlier\nfirst\n",
+		},
+		{
+			name:        "should not rotate without limits",
+			existing:    "earlier\n",
+			existingAge: 48 * time.Hour,
+			writes:      []string{"first\n"},
+			wantCurrent: "earlier\nfirst\n",
+		},
+		{
+			name:         "should compress rotated files",
+			rotation:     rotation{maxSize: 10, compress: true},
+			writes:       []string{"first\n", "second\n"},
+			wantCurrent:  "second\n",
+			wantRotated:  []string{"first\n"},
+			wantCompress: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, logServiceStr+".log")
+			if test.existing != "" {
+				assert.NoError(t, os.WriteFile(path, []byte(test.existing), logFilePerm))
+				modTime := time.Now().Add(-test.existingAge)
+				assert.NoError(t, os.Chtimes(path, modTime, modTime))
+			}
+
+			f, err := openRotatingFile(dir, logServiceStr, test.rotation)
+			assert.NoError(t, err)
+			for _, write := range test.writes {
+				n, err := f.Write([]byte(write))
+				assert.NoError(t, err)
+				assert.Equal(t, len(write), n)
+			}
+
+			// Closing waits for rotated files to be compressed.
+			assert.NoError(t, f.Close())
+
+			assert.Equal(t, test.wantCurrent, readLogFile(t, path))
+			rotated := rotatedFiles(t, dir)
+			assert.Len(t, rotated, len(test.wantRotated))
+			for i, want := range test.wantRotated {
+				assert.Equal(t, test.wantCompress, filepath.Ext(rotated[i]) == ".gz")
+				assert.Equal(t, want, readLogFile(t, rotated[i]))
+			}
+		})
+	}
+}
+
+// prune() - Test Method
+func TestRotate_prune(t *testing.T) {
+
+	// Rotated files named and modified one day apart, newest first.
+	names := []string{
+		logServiceStr + ".20240105-000000.000.log.gz",
+		logServiceStr + ".20240104-000000.000.log",
+		logServiceStr + ".20240103-000000.000.log.gz",
+		logServiceStr + ".20240102-000000.000.log",
+	}
+
+	tests := []struct {
+		name     string
+		rotation rotation
+		wantKept []string
+	}{
+		{
+			name:     "should keep all files without limits",
+			wantKept: names,
+		},
+		{
+			name:     "should keep newest files up to maximum",
+			rotation: rotation{maxFiles: 2},
+			wantKept: names[:2],
+		},
+		{
+			name:     "should remove files beyond maximum age",
+			rotation: rotation{maxAge: 60 * time.Hour},
+			wantKept: names[:3],
+		},
+		{
+			name:     "should apply the tighter of maximum and age",
+			rotation: rotation{maxFiles: 3, maxAge: 36 * time.Hour},
+			wantKept: names[:2],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for i, name := range names {
+				path := filepath.Join(dir, name)
+				assert.NoError(t, os.WriteFile(path, []byte(name), logFilePerm))
+				modTime := time.Now().Add(-time.Duration(i*24+1) * time.Hour)
+				assert.NoError(t, os.Chtimes(path, modTime, modTime))
+			}
+
+			// The current file and files of other services are never pruned.
+			current := filepath.Join(dir, logServiceStr+".log")
+			other := filepath.Join(dir, "beserver.20240101-000000.000.log")
+			for _, path := range []string{current, other} {
+				assert.NoError(t, os.WriteFile(path, nil, logFilePerm))
+				old := time.Now().Add(-30 * 24 * time.Hour)
+				assert.NoError(t, os.Chtimes(path, old, old))
+			}
+
+			f := &rotatingFile{dir: dir, service: logServiceStr, rotation: test.rotation}
+			f.prune()
+
+			var kept []string
+			for _, path := range rotatedFiles(t, dir) {
+				kept = append(kept, filepath.Base(path))
+			}
+			want := append([]string(nil), test.wantKept...)
+			sort.Strings(want)
+			assert.Equal(t, want, kept)
+			assert.FileExists(t, current)
+			assert.FileExists(t, other)
+		})
+	}
+}
+
+// Reopen() - Test Method
+func TestRotate_Reopen(t *testing.T) {
+	tests := []struct {
+		name        string
+		moved       bool
+		wantCurrent string
+		wantMoved   string
+	}{
+		{
+			name:        "should reopen a new file once moved aside",
+			moved:       true,
+			wantCurrent: "second\n",
+			wantMoved:   "first\n",
+		},
+		{
+			name:        "should reopen the same file when not moved",
+			wantCurrent: "first\nsecond\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, logServiceStr+".log")
+			movedPath := filepath.Join(dir, "shipped.log")
+
+			f, err := openRotatingFile(dir, logServiceStr, rotation{maxSize: 64})
+			assert.NoError(t, err)
+			_, err = f.Write([]byte("first\n"))
+			assert.NoError(t, err)
+
+			// Log shippers move the file aside, then signal a reopen.
+			if test.moved {
+				assert.NoError(t, os.Rename(path, movedPath))
+			}
+			assert.NoError(t, f.Reopen())
+			_, err = f.Write([]byte("second\n"))
+			assert.NoError(t, err)
+			assert.NoError(t, f.Close())
+
+			assert.Equal(t, test.wantCurrent, readLogFile(t, path))
+			if test.moved {
+				assert.Equal(t, test.wantMoved, readLogFile(t, movedPath))
+			}
+			assert.Empty(t, rotatedFiles(t, dir))
+		})
+	}
+}
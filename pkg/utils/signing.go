@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"strings"
+)
+
+// Domains separating envelope and content signatures, so neither may stand in
+// for the other.
+const (
+	envelopeSignatureDomain = "enc-server-go record envelope signature v1"
+	contentSignatureDomain  = "enc-server-go record content signature v1"
+)
+
+// Length of signer key IDs, truncated from the SHA-256 of the public key.
+const SignerKeyIDSize = 8
+
+// Returned when a signature names a key ID not among the trusted keys.
+var ErrSignerUnknown = errors.New("Record signed by an unknown key")
+
+// Returned when a signature or signed digest does not verify.
+var ErrSignatureInvalid = errors.New("Record signature does not verify")
+
+// Returned when signatures are required and a record carries none.
+var ErrSignatureMissing = errors.New("Record is not signed")
+
+// Signature by an Ed25519 key, identified by its key ID so verifiers may
+// trust several keys and signers may rotate.
+type Signature struct {
+	KeyID     []byte `json:"keyId"`
+	Signature []byte `json:"signature"`
+}
+
+// Signatures stored alongside a record. The envelope signature is made by the
+// front-end service over the sealed record attributes, which hold a digest of
+// the stored ciphertext. The content signature is supplied by the storing
+// client over the record contents.
+type RecordSignatures struct {
+	Envelope *Signature `json:"envelope,omitempty"`
+	Content  *Signature `json:"content,omitempty"`
+}
+
+type Signer interface {
+
+	// Signs the sealed attributes of a record stored under the sealed ID and
+	// name, and any content signature, returning the encoded record
+	// signatures. Nil when neither signing nor a content signature applies.
+	SignEnvelope(id, name, metadata []byte, content *Signature) (signatures []byte, err error)
+
+	// Verifies encoded record signatures over the sealed attributes of a
+	// record, returning any content signature to verify once the record
+	// contents are read.
+	VerifyEnvelope(id, name, metadata, signatures []byte) (content *Signature, err error)
+
+	// Verifies a content signature over the digest of the contents of a named
+	// record of a user ID.
+	VerifyContent(id, name, digest []byte, content *Signature) (err error)
+
+	// Reports whether envelopes are signed, so records carry a digest of
+	// their ciphertext.
+	Signing() bool
+}
+
+type signerImpl struct {
+	private ed25519.PrivateKey
+	keyID   []byte
+	trusted map[string]ed25519.PublicKey
+	require bool
+}
+
+// SignerKeyID identifies an Ed25519 public key.
+func SignerKeyID(public ed25519.PublicKey) []byte {
+	sum := sha256.Sum256(public)
+	return sum[:SignerKeyIDSize]
+}
+
+func signatureMessage(domain string, fields ...[]byte) (message []byte) {
+
+	// Length-prefix each field after the domain so fields cannot run together.
+	message = []byte(domain)
+	for _, field := range fields {
+		message = binary.BigEndian.AppendUint64(message, uint64(len(field)))
+		message = append(message, field...)
+	}
+	return message
+}
+
+func envelopeMessage(id, name, metadata []byte, content *Signature) []byte {
+	var contentID, contentSig []byte
+	if content != nil {
+		contentID, contentSig = content.KeyID, content.Signature
+	}
+	return signatureMessage(envelopeSignatureDomain, id, name, metadata, contentID, contentSig)
+}
+
+func contentMessage(id, name, digest []byte) []byte {
+	return signatureMessage(contentSignatureDomain, id, name, digest)
+}
+
+// SignContent signs the contents of a named record of a user ID with a
+// client Ed25519 key, for the front-end service to verify and store.
+func SignContent(private ed25519.PrivateKey, id, name, record []byte) (content *Signature) {
+	digest := sha256.Sum256(record)
+	return &Signature{
+		KeyID:     SignerKeyID(private.Public().(ed25519.PublicKey)),
+		Signature: ed25519.Sign(private, contentMessage(id, name, digest[:])),
+	}
+}
+
+func (s *signerImpl) verify(message []byte, sig *Signature) (err error) {
+	public, ok := s.trusted[string(sig.KeyID)]
+	if !ok {
+		return ErrSignerUnknown
+	}
+	if !ed25519.Verify(public, message, sig.Signature) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+func (s *signerImpl) SignEnvelope(id, name, metadata []byte, content *Signature) (signatures []byte, err error) {
+	sigs := RecordSignatures{Content: content}
+	if s.private != nil {
+		sigs.Envelope = &Signature{
+			KeyID:     s.keyID,
+			Signature: ed25519.Sign(s.private, envelopeMessage(id, name, metadata, content)),
+		}
+	}
+	if sigs.Envelope == nil && sigs.Content == nil {
+		return nil, nil
+	}
+	return json.Marshal(sigs)
+}
+
+func (s *signerImpl) VerifyEnvelope(id, name, metadata, signatures []byte) (content *Signature, err error) {
+	var sigs RecordSignatures
+	if len(signatures) > 0 {
+		if err = json.Unmarshal(signatures, &sigs); err != nil {
+			return nil, ErrSignatureInvalid
+		}
+	}
+
+	// Records without an envelope signature predate signing, and are only
+	// refused once signatures are required.
+	if sigs.Envelope == nil {
+		if s.require {
+			return nil, ErrSignatureMissing
+		}
+		return sigs.Content, nil
+	}
+	if err = s.verify(envelopeMessage(id, name, metadata, sigs.Content), sigs.Envelope); err != nil {
+		return nil, err
+	}
+	return sigs.Content, nil
+}
+
+func (s *signerImpl) VerifyContent(id, name, digest []byte, content *Signature) (err error) {
+	return s.verify(contentMessage(id, name, digest), content)
+}
+
+func (s *signerImpl) Signing() bool {
+	return s.private != nil
+}
+
+// ContentSigner returns the key ID of the content signature among encoded
+// record signatures, or nil for records without one.
+func ContentSigner(signatures []byte) (keyID []byte) {
+	var sigs RecordSignatures
+	if len(signatures) == 0 || json.Unmarshal(signatures, &sigs) != nil || sigs.Content == nil {
+		return nil
+	}
+	return sigs.Content.KeyID
+}
+
+// Reader verifying the SHA-256 digest of its input once read to its end.
+type digestReader struct {
+	r      io.Reader
+	h      hash.Hash
+	digest []byte
+}
+
+// NewDigestReader returns a reader failing with ErrSignatureInvalid in place
+// of io.EOF when its input does not match the given SHA-256 digest.
+func NewDigestReader(r io.Reader, digest []byte) io.Reader {
+	return &digestReader{r: r, h: sha256.New(), digest: digest}
+}
+
+func (d *digestReader) Read(p []byte) (n int, err error) {
+	n, err = d.r.Read(p)
+	d.h.Write(p[:n])
+	if errors.Is(err, io.EOF) && subtle.ConstantTimeCompare(d.h.Sum(nil), d.digest) != 1 {
+		return n, ErrSignatureInvalid
+	}
+	return n, err
+}
+
+// Reader verifying a content signature over its input once read to its end.
+type contentReader struct {
+	r       io.Reader
+	h       hash.Hash
+	verify  func(digest []byte) error
+	checked bool
+	failed  error
+}
+
+// NewContentReader returns a reader failing in place of io.EOF when the
+// content signature of a named record does not verify over its input.
+func NewContentReader(s Signer, r io.Reader, id, name []byte, content *Signature) io.Reader {
+	return &contentReader{r: r, h: sha256.New(), verify: func(digest []byte) error {
+		return s.VerifyContent(id, name, digest, content)
+	}}
+}
+
+func (c *contentReader) Read(p []byte) (n int, err error) {
+	n, err = c.r.Read(p)
+	c.h.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if !c.checked {
+			c.checked, c.failed = true, c.verify(c.h.Sum(nil))
+		}
+		if c.failed != nil {
+			return n, c.failed
+		}
+	}
+	return n, err
+}
+
+// VerifyDigest reports whether data matches a SHA-256 digest.
+func VerifyDigest(data, digest []byte) (err error) {
+	sum := sha256.Sum256(data)
+	if subtle.ConstantTimeCompare(sum[:], digest) != 1 {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+func parseSignerKey(keyStr string) (public ed25519.PublicKey, err error) {
+	key, err := hex.DecodeString(strings.TrimSpace(keyStr))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("MakeSigner cannot be configured with invalid verifyKeys")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+func MakeSigner(configs map[string]string) (s Signer, err error) {
+	impl := &signerImpl{
+		trusted: map[string]ed25519.PublicKey{},
+		require: configs["requireSignatures"] == "true",
+	}
+
+	// The signing key is a hex Ed25519 seed. Its public key is trusted.
+	if seedStr := configs["signingKey"]; seedStr != "" {
+		seed, err := hex.DecodeString(seedStr)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			err = errors.New("MakeSigner cannot be configured with invalid signingKey")
+			return nil, err
+		}
+		impl.private = ed25519.NewKeyFromSeed(seed)
+		public := impl.private.Public().(ed25519.PublicKey)
+		impl.keyID = SignerKeyID(public)
+		impl.trusted[string(impl.keyID)] = public
+	}
+
+	// Verify keys are comma-separated hex Ed25519 public keys, such as those
+	// of retired signing keys and of clients supplying content signatures.
+	if keysStr := configs["verifyKeys"]; keysStr != "" {
+		for _, keyStr := range strings.Split(keysStr, ",") {
+			public, err := parseSignerKey(keyStr)
+			if err != nil {
+				return nil, err
+			}
+			impl.trusted[string(SignerKeyID(public))] = public
+		}
+	}
+
+	// Requiring signatures without signing would refuse every record stored.
+	if impl.require && impl.private == nil {
+		err = errors.New("MakeSigner cannot be configured with requireSignatures without signingKey")
+		return nil, err
+	}
+	return impl, nil
+}
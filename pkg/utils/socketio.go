@@ -2,9 +2,12 @@ package utils
 
 import (
 	"bufio"
+	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"net"
+	"strings"
+	"time"
 )
 
 // Responding objects must fulfill this interface.
@@ -12,10 +15,41 @@ type Responder interface {
 	Respond(message string) (response []byte)
 }
 
+// Responders keeping per-connection state, such as the API key a client
+// authenticated with, hand out a fresh responder for each client session,
+// given the IP address of the client.
+type SessionResponder interface {
+	Responder
+	Session(clientIP string) (responder Responder)
+}
+
+// Responders logging and passing on the request ID of each request take it
+// in the context of the request.
+type ContextResponder interface {
+	Responder
+	RespondContext(ctx context.Context, message string) (response []byte)
+}
+
 // SocketIO configuration.
 type SocketIO struct {
-	port      string
-	responder Responder
+	port        string
+	metricsPort string
+	responder   Responder
+}
+
+// Records a request and the error response it may have received. Requests are
+// named by their type, save malformed requests, whose type may be anything.
+func observeSocketRequest(message string, start time.Time, response []byte) {
+	operation, _, _ := strings.Cut(strings.TrimSpace(message), " ")
+	errType := ""
+	if errMsg, failed := strings.CutPrefix(strings.TrimRight(string(response), "\n"), "ERROR "); failed {
+		if strings.HasPrefix(errMsg, RateLimitedCode+" ") {
+			errType = "rate_limited"
+		} else if errType = ErrorMessageType(errMsg); errType == "bad_request" {
+			operation = "malformed"
+		}
+	}
+	ObserveRequest(operation, start, errType)
 }
 
 func (s *SocketIO) session(l net.Listener) (err error) {
@@ -26,20 +60,40 @@ func (s *SocketIO) session(l net.Listener) (err error) {
 		return err
 	}
 	defer c.Close()
-	log.Println("Client session opened")
+	clientIP, _, _ := net.SplitHostPort(c.RemoteAddr().String())
+	slog.Info("Client session opened", "clientIP", clientIP)
+
+	// Session responders keep state for this connection only.
+	responder := s.responder
+	if sr, ok := responder.(SessionResponder); ok {
+		responder = sr.Session(clientIP)
+	}
 
 	for {
 		// Read transmitted messages.
 		var message string
 		if message, err = bufio.NewReader(c).ReadString('\n'); err != nil {
 			if err.Error() == "EOF" {
-				log.Println("Client session closed")
+				slog.Info("Client session closed", "clientIP", clientIP)
 				return nil
 			}
 		}
 
-		// Compose and transmit response.
-		response := s.responder.Respond(message)
+		// Compose and transmit response, under the request ID the client sent
+		// or a new one.
+		start := time.Now()
+		var response []byte
+		if cr, ok := responder.(ContextResponder); ok {
+			request, requestID := CutRequestID(message)
+			if !ValidRequestID(requestID) {
+				request, requestID = message, NewRequestID()
+			}
+			message = request
+			response = cr.RespondContext(ContextWithRequestID(context.Background(), requestID), message)
+		} else {
+			response = responder.Respond(message)
+		}
+		observeSocketRequest(message, start, response)
 		if _, err = c.Write(response); err != nil {
 			return err
 		}
@@ -48,8 +102,13 @@ func (s *SocketIO) session(l net.Listener) (err error) {
 
 func (s *SocketIO) Start() (err error) {
 
+	// Serve metrics on their own port when configured.
+	if err = StartMetricsListener(s.metricsPort); err != nil {
+		return err
+	}
+
 	// Start listener on port.
-	log.Println("Starting server.")
+	slog.Info("Starting server", "port", s.port)
 	l, err := net.Listen("tcp", ":"+s.port)
 	if err != nil {
 		return err
@@ -80,8 +139,9 @@ func MakeSocketIO(configs map[string]string, responder Responder) (s *SocketIO,
 
 	// Build socket IO with responder reference.
 	s = &SocketIO{
-		port:      configs["port"],
-		responder: responder,
+		port:        configs["port"],
+		metricsPort: configs["metricsPort"],
+		responder:   responder,
 	}
 
 	return s, nil
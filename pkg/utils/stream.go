@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// Records too large to seal in one call are sealed as a stream: a random
+// nonce prefix followed by segments of at most StreamSegmentSize plaintext
+// bytes, each sealed under the record key. Segment nonces are the prefix, a
+// big-endian segment counter and a final-segment flag, so segments cannot be
+// reordered, dropped or truncated without detection.
+
+// Plaintext bytes sealed per stream segment.
+const StreamSegmentSize = 64 * 1024
+
+// Random nonce prefix bytes leading a sealed stream.
+const StreamPrefixSize = 7
+
+// Segment counter and final-segment flag bytes completing each nonce.
+const streamCounterSize = 4
+const streamFlagSize = 1
+
+// Returned when a sealed stream ends before its final segment.
+var ErrStreamTruncated = errors.New("Sealed stream truncated")
+
+// Returned when a stream exceeds the segments addressable by its counter.
+var ErrStreamTooLong = errors.New("Sealed stream too long")
+
+func streamNonce(prefix []byte, counter uint32, last bool) (nonce []byte) {
+
+	// Prefix, segment counter and final-segment flag.
+	nonce = binary.BigEndian.AppendUint32(append([]byte{}, prefix...), counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+type streamSealer struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	w       io.Writer
+	buf     []byte
+	counter uint32
+	closed  bool
+}
+
+// NewStreamSealer writes the nonce prefix to w and returns a writer sealing
+// plaintext into segments as it arrives. Close seals the final segment.
+func NewStreamSealer(aead cipher.AEAD, prefix []byte, w io.Writer) (sealer io.WriteCloser, err error) {
+	if aead.NonceSize() != len(prefix)+streamCounterSize+streamFlagSize {
+		return nil, errors.New("Stream nonce prefix does not match cipher")
+	}
+	if _, err = w.Write(prefix); err != nil {
+		return nil, err
+	}
+
+	sealer = &streamSealer{
+		aead:   aead,
+		prefix: prefix,
+		w:      w,
+		buf:    make([]byte, 0, StreamSegmentSize),
+	}
+	return sealer, nil
+}
+
+func (s *streamSealer) seal(last bool) (err error) {
+	if !last && s.counter == math.MaxUint32 {
+		return ErrStreamTooLong
+	}
+
+	sealed := s.aead.Seal(nil, streamNonce(s.prefix, s.counter, last), s.buf, nil)
+	s.counter++
+	s.buf = s.buf[:0]
+	_, err = s.w.Write(sealed)
+	return err
+}
+
+func (s *streamSealer) Write(p []byte) (n int, err error) {
+	if s.closed {
+		return 0, errors.New("Write to closed stream")
+	}
+
+	for len(p) > 0 {
+
+		// Full segments are sealed only once more plaintext follows, so the
+		// final segment is always sealed by Close.
+		if len(s.buf) == cap(s.buf) {
+			if err = s.seal(false); err != nil {
+				return n, err
+			}
+		}
+		copied := copy(s.buf[len(s.buf):cap(s.buf)], p)
+		s.buf = s.buf[:len(s.buf)+copied]
+		p = p[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+func (s *streamSealer) Close() (err error) {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.seal(true)
+}
+
+type streamOpener struct {
+	aead    cipher.AEAD
+	r       io.Reader
+	prefix  []byte
+	in      []byte
+	pending int
+	out     []byte
+	counter uint32
+	done    bool
+}
+
+// NewStreamOpener returns a reader opening a stream sealed by NewStreamSealer.
+// Plaintext is only released once its segment authenticates; truncated
+// streams fail with ErrStreamTruncated.
+func NewStreamOpener(aead cipher.AEAD, r io.Reader) (opener io.Reader) {
+
+	// One byte beyond a full segment is read ahead to detect the final segment.
+	return &streamOpener{
+		aead: aead,
+		r:    r,
+		in:   make([]byte, StreamSegmentSize+aead.Overhead()+1),
+	}
+}
+
+func (o *streamOpener) next() (err error) {
+
+	// Read the nonce prefix leading the stream.
+	if o.prefix == nil {
+		prefix := make([]byte, o.aead.NonceSize()-streamCounterSize-streamFlagSize)
+		if _, err = io.ReadFull(o.r, prefix); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrStreamTruncated
+		} else if err != nil {
+			return err
+		}
+		o.prefix = prefix
+	}
+
+	// A short read marks the final segment.
+	read, err := io.ReadFull(o.r, o.in[o.pending:])
+	total := o.pending + read
+	last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !last {
+		return err
+	}
+	if last && total < o.aead.Overhead() {
+		return ErrStreamTruncated
+	} else if !last && o.counter == math.MaxUint32 {
+		return ErrStreamTooLong
+	}
+
+	segment := o.in[:min(total, len(o.in)-1)]
+	if o.out, err = o.aead.Open(o.out[:0], streamNonce(o.prefix, o.counter, last), segment, nil); err != nil {
+		return err
+	}
+	o.counter++
+
+	// Carry the read-ahead byte into the next segment.
+	if last {
+		o.done = true
+	} else {
+		o.in[0], o.pending = o.in[len(o.in)-1], 1
+	}
+	return nil
+}
+
+func (o *streamOpener) Read(p []byte) (n int, err error) {
+	for len(o.out) == 0 {
+		if o.done {
+			return 0, io.EOF
+		}
+		if err = o.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, o.out)
+	o.out = o.out[n:]
+	return n, nil
+}
+
+// OpenStream opens a sealed stream held in memory.
+func OpenStream(aead cipher.AEAD, sealed []byte) (plain []byte, err error) {
+	return io.ReadAll(NewStreamOpener(aead, bytes.NewReader(sealed)))
+}
@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Setting of traceFile exporting spans to stdout rather than a file.
+const TraceStdout = "stdout"
+
+// Tracer creating the spans of every service. It records nothing until a
+// tracer provider is installed by InitTracer.
+var tracer = otel.Tracer("enc-server-go")
+
+// Trace context travels between services in W3C traceparent headers.
+var tracePropagator = propagation.TraceContext{}
+
+// InitTracer exports the spans of a service as JSON lines to the file at
+// traceFile, or to stdout when set to "stdout". Spans are written as they end,
+// so none is lost when the service exits. Without the setting spans are not
+// recorded. The returned function flushes the exporter and closes its file.
+func InitTracer(serviceName string, configs map[string]string) (shutdown func(), err error) {
+	path := configs["traceFile"]
+	if path == "" {
+		return func() {}, nil
+	}
+
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if path != TraceStdout {
+		if file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600); err != nil {
+			return nil, errors.New("Error opening trace file: " + err.Error())
+		}
+		w = file
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, errors.New("Error creating trace exporter: " + err.Error())
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		provider.Shutdown(context.Background())
+		if file != nil {
+			file.Close()
+		}
+	}, nil
+}
+
+// StartSpan starts a span for an operation, as a child of any span in ctx.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartRequestSpan starts the server span of an HTTP request, continuing any
+// trace its caller passed in a traceparent header.
+func StartRequestSpan(r *http.Request, name string) (context.Context, trace.Span) {
+	ctx := tracePropagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), requestIDAttribute(ctx)))
+}
+
+// Names the request ID of a span, so traces and logs can be matched.
+func requestIDAttribute(ctx context.Context) attribute.KeyValue {
+	return attribute.String("request.id", RequestIDFromContext(ctx))
+}
+
+// EndRequestSpan ends the server span of an HTTP request to a route, marking
+// it failed for server errors.
+func EndRequestSpan(span trace.Span, route string, status int) {
+	span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	span.End()
+}
+
+// EndSpan ends a span, marking it failed with the error of the operation it
+// covers, if any. Deferred with a pointer to a named error result.
+func EndSpan(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// ContextWithSpan carries the span of parent into ctx, so calls made under ctx
+// are traced as its children while keeping the deadline of ctx.
+func ContextWithSpan(ctx, parent context.Context) context.Context {
+	if parent == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(parent))
+}
+
+// Carrier of trace context in GRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() (keys []string) {
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Starts the client span of a GRPC call, adding its trace context to the
+// outgoing metadata.
+func startClientSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	tracePropagator.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// Starts the server span of a GRPC request, continuing the trace in its
+// incoming metadata.
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = tracePropagator.Extract(ctx, metadataCarrier(md))
+	return tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(requestIDAttribute(ctx)))
+}
+
+// Ends a GRPC span with the status code of its call.
+func endRPCSpan(span trace.Span, err error) {
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+	EndSpan(span, &err)
+}
+
+// TracingUnaryClientInterceptor traces the GRPC calls a client makes.
+func TracingUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+	ctx, span := startClientSpan(ctx, method)
+	err = invoker(ctx, method, req, reply, cc, opts...)
+	endRPCSpan(span, err)
+	return err
+}
+
+// Client stream ending its span once the stream finishes or is abandoned.
+type tracedClientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *tracedClientStream) end(err error) {
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		endRPCSpan(s.span, err)
+	})
+}
+
+func (s *tracedClientStream) RecvMsg(m any) (err error) {
+	if err = s.ClientStream.RecvMsg(m); err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+// TracingStreamClientInterceptor traces the GRPC streams a client opens, until
+// they finish or their context ends.
+func TracingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (cs grpc.ClientStream, err error) {
+	ctx, span := startClientSpan(ctx, method)
+	if cs, err = streamer(ctx, desc, cc, method, opts...); err != nil {
+		endRPCSpan(span, err)
+		return nil, err
+	}
+
+	traced := &tracedClientStream{ClientStream: cs, span: span}
+	go func() {
+		<-ctx.Done()
+		traced.end(ctx.Err())
+	}()
+	return traced, nil
+}
+
+// TracingUnaryServerInterceptor traces the GRPC requests a server serves.
+func TracingUnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp any, err error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	resp, err = handler(ctx, req)
+	endRPCSpan(span, err)
+	return resp, err
+}
+
+// Server stream carrying a context derived from its own, such as one holding
+// its span.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// TracingStreamServerInterceptor traces the GRPC streams a server serves.
+func TracingStreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	err = handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	endRPCSpan(span, err)
+	return err
+}
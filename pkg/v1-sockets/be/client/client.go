@@ -2,8 +2,12 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
+	"io"
+	"strconv"
 	"strings"
 
 	"enc-server-go/pkg/utils"
@@ -12,36 +16,178 @@ import (
 // Client implementation
 type clientImpl struct {
 	conn utils.Conn
+	ctx  context.Context
 }
 
-func (c *clientImpl) StoreRecord(id, record []byte) (err error) {
+func nameOption(name []byte) (option string) {
+
+	// The default record is addressed without a name.
+	if len(name) == 0 {
+		return ""
+	}
+	return " NAME " + hex.EncodeToString(name)
+}
+
+func preconditionOptions(match utils.Precondition) (options string) {
+
+	// Precondition options carry HTTP If-Match and If-None-Match values.
+	ifMatch, ifNoneMatch := match.Headers()
+	if ifMatch != "" {
+		options += " IFMATCH " + ifMatch
+	}
+	if ifNoneMatch != "" {
+		options += " IFNONEMATCH " + ifNoneMatch
+	}
+
+	return options
+}
+
+// Sends a request, passing on the request ID of the client context last.
+func (c *clientImpl) getResponse(request string) (message string, err error) {
+	if requestID := utils.RequestIDFromContext(c.ctx); requestID != "" {
+		request += " " + utils.RequestIDOption + " " + requestID
+	}
+	return c.conn.GetResponse(request + "\n")
+}
+
+func responseError(message string) (err error) {
+
+	// Failed preconditions and mismatched keys are reported as distinct errors.
+	switch message {
+	case "ERROR " + utils.ErrPreconditionFailed.Error():
+		return utils.ErrPreconditionFailed
+	case "ERROR " + utils.ErrKeyMismatch.Error():
+		return utils.ErrKeyMismatch
+	}
+	return errors.New(message)
+}
+
+func (c *clientImpl) StoreRecord(id, name, record []byte, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
 	recordStr := hex.EncodeToString(record)
 
+	// Append store options.
+	request := "STORE " + idStr + " " + recordStr + nameOption(name)
+	if opts.OneTime {
+		request += " ONETIME " + hex.EncodeToString(opts.KeyHash)
+	}
+	if len(opts.Metadata) > 0 {
+		request += " METADATA " + hex.EncodeToString(opts.Metadata)
+	}
+	if len(opts.Signatures) > 0 {
+		request += " SIGNATURES " + hex.EncodeToString(opts.Signatures)
+	}
+	request += preconditionOptions(opts.Match)
+
 	// Write request to server.
-	message, err := c.conn.GetResponse("STORE " + idStr + " " + recordStr + "\n")
+	message, err := c.getResponse(request)
 	if err != nil {
-		return err
+		return info, err
 	}
 
 	// Process response.
 	if strings.HasPrefix(message, "ERROR") {
-		err = errors.New(message)
-		return err
+		err = responseError(message)
+		return info, err
 	}
 
-	return nil
+	// Decode stored record attributes.
+	fields := strings.Split(message, " ")
+	if fields[0] != "SUCCESS" {
+		err = errors.New("Malformed response")
+		return info, err
+	}
+	return utils.ParseRecordInfo(fields[1:])
 }
 
-func (c *clientImpl) RetrieveRecord(id []byte) (record []byte, err error) {
+func (c *clientImpl) RetrieveRecord(id, name []byte, opts utils.RetrieveOptions) (record []byte, info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
 
+	// Attribute requests never read record contents.
+	verb := "RETRIEVE "
+	if opts.MetadataOnly {
+		verb = "STAT "
+	}
+
+	// Append retrieve options.
+	request := verb + idStr + nameOption(name)
+	if len(opts.KeyHash) > 0 && !opts.MetadataOnly {
+		request += " KEYHASH " + hex.EncodeToString(opts.KeyHash)
+	}
+	if opts.Version > 0 {
+		request += " VERSION " + strconv.FormatInt(opts.Version, 10)
+	}
+
 	// Write request to server.
-	message, err := c.conn.GetResponse("RETRIEVE " + idStr + "\n")
+	message, err := c.getResponse(request)
+	if err != nil {
+		return nil, info, err
+	}
+
+	// Process response.
+	if strings.HasPrefix(message, "ERROR") {
+		err = responseError(message)
+		return nil, info, err
+	}
+
+	// Split record from its attributes.
+	fields := strings.Split(message, " ")
+	if !opts.MetadataOnly {
+
+		// Decode record from hex.
+		if record, err = hex.DecodeString(fields[0]); err != nil {
+			return nil, info, err
+		}
+		fields = fields[1:]
+	}
+
+	if info, err = utils.ParseRecordInfo(fields); err != nil {
+		return nil, info, err
+	}
+	return record, info, nil
+}
+
+func (c *clientImpl) StoreStream(id, name []byte, record io.Reader, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
+
+	// The socket protocol carries records whole, so streams are buffered.
+	data, err := io.ReadAll(record)
+	if err != nil {
+		return info, err
+	}
+	if opts.SealMetadata != nil {
+		if opts.Metadata, err = opts.SealMetadata(); err != nil {
+			return info, err
+		}
+	}
+	if opts.SignMetadata != nil {
+		if opts.Signatures, err = opts.SignMetadata(opts.Metadata); err != nil {
+			return info, err
+		}
+	}
+	return c.StoreRecord(id, name, data, opts)
+}
+
+func (c *clientImpl) RetrieveStream(id, name []byte, opts utils.RetrieveOptions) (record io.ReadCloser, info utils.RecordInfo, err error) {
+
+	// The socket protocol carries records whole, so streams are buffered.
+	data, info, err := c.RetrieveRecord(id, name, opts)
+	if err != nil {
+		return nil, info, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), info, nil
+}
+
+func (c *clientImpl) ListVersions(id, name []byte) (versions []int64, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+
+	// Write request to server.
+	message, err := c.getResponse("VERSIONS " + idStr + nameOption(name))
 	if err != nil {
 		return nil, err
 	}
@@ -52,34 +198,107 @@ func (c *clientImpl) RetrieveRecord(id []byte) (record []byte, err error) {
 		return nil, err
 	}
 
-	// Decode record from hex.
-	if record, err = hex.DecodeString(message); err != nil {
-		return nil, err
+	// Decode versions.
+	for _, field := range strings.Fields(message) {
+		var version int64
+		if version, err = strconv.ParseInt(field, 10, 64); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
 	}
 
-	return record, nil
+	return versions, nil
 }
 
-func (c *clientImpl) DeleteRecord(id []byte) (err error) {
+func (c *clientImpl) ListRecords(id []byte, opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
 
+	// Append list options.
+	request := "LIST " + idStr
+	if opts.Cursor != "" {
+		request += " CURSOR " + opts.Cursor
+	}
+	if opts.Limit > 0 {
+		request += " LIMIT " + strconv.FormatInt(opts.Limit, 10)
+	}
+
 	// Write request to server.
-	message, err := c.conn.GetResponse("DELETE " + idStr + "\n")
+	message, err := c.getResponse(request)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	// Process response.
 	if strings.HasPrefix(message, "ERROR") {
 		err = errors.New(message)
+		return nil, "", err
+	}
+
+	return utils.ParseRecordList(message)
+}
+
+func (c *clientImpl) DeleteRecord(id, name []byte, opts utils.DeleteOptions) (err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+
+	// Write request to server.
+	request := "DELETE " + idStr + nameOption(name) + preconditionOptions(opts.Match)
+	message, err := c.getResponse(request)
+	if err != nil {
+		return err
+	}
+
+	// Process response.
+	if strings.HasPrefix(message, "ERROR") {
+		err = responseError(message)
 		return err
 	}
 
 	return nil
 }
 
+func (c *clientImpl) UpdateMetadata(id, name, prior, metadata, signatures []byte) (info utils.RecordInfo, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+	request := "METADATA " + idStr + " " + hex.EncodeToString(metadata) + nameOption(name) +
+		" PRIOR " + hex.EncodeToString(prior)
+	if len(signatures) > 0 {
+		request += " SIGNATURES " + hex.EncodeToString(signatures)
+	}
+
+	// Write request to server.
+	message, err := c.getResponse(request)
+	if err != nil {
+		return info, err
+	}
+
+	// Process response.
+	if strings.HasPrefix(message, "ERROR") {
+		err = responseError(message)
+		return info, err
+	}
+
+	// Decode updated record attributes.
+	fields := strings.Split(message, " ")
+	if fields[0] != "SUCCESS" {
+		err = errors.New("Malformed response")
+		return info, err
+	}
+	return utils.ParseRecordInfo(fields[1:])
+}
+
+// The socket protocol carries no trace context, so calls are not traced, but
+// it passes on the request ID of ctx.
+func (c *clientImpl) WithContext(ctx context.Context) utils.ClientBE {
+	copied := *c
+	copied.ctx = ctx
+	return &copied
+}
+
 func MakeClient(configs map[string]string) (c utils.ClientBE, err error) {
 
 	// Verify required configurations.
@@ -1,8 +1,11 @@
 package client
 
 import (
+	"context"
+	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,25 +25,77 @@ const recordHexStr = "5041594c4f4144535041594c4f4144535041594c4f414453" +
 
 const badClientMessage = "MakeClient missing configuration serverAddr"
 
+const keyHashHexStr = "2f8ad1bd3e2be0a2c7e6bb4a4e0b5e6e2e7d2b8d5e0c1f3a7b9c4d6e8f0a1b2c"
+
+const nameStr = "notes"
+const nameHexStr = "6e6f746573"
+
+const metadataStr = "meta"
+const metadataHexStr = "6d657461"
+
+const signaturesStr = "sigs"
+const signaturesHexStr = "73696773"
+
 const storeSuccessMessage = "STORE " + idHexStr + " " + recordHexStr + "\n"
-const storeSuccessResponse = ""
+const storeNamedMessage = "STORE " + idHexStr + " " + recordHexStr + " NAME " + nameHexStr + "\n"
+const storeOneTimeMessage = "STORE " + idHexStr + " " + recordHexStr + " ONETIME " + keyHashHexStr + "\n"
+const storeMetadataMessage = "STORE " + idHexStr + " " + recordHexStr + " METADATA " + metadataHexStr +
+	" SIGNATURES " + signaturesHexStr + "\n"
+const storeSuccessResponse = "SUCCESS 1 1700000000000 0 0 - - - -"
+const storeMatchMessage = "STORE " + idHexStr + " " + recordHexStr + " IFMATCH \"1\"\n"
+const storePreconditionResponse = "ERROR record precondition failed"
 const storeFailMessage = "STORE  \n"
 const storeFailResponse = "ERROR Malformed request\n"
 
 const retrieveSuccessMessage = "RETRIEVE " + idHexStr + "\n"
-const retrieveSuccessResponse = recordHexStr
+const retrieveNamedMessage = "RETRIEVE " + idHexStr + " NAME " + nameHexStr + "\n"
+const retrieveOptionsMessage = "RETRIEVE " + idHexStr + " KEYHASH " + keyHashHexStr + " VERSION 1\n"
+const retrieveSuccessResponse = recordHexStr + " 1 1700000000000 0 0 - - " + metadataHexStr + " -"
+const retrieveMalformedResponse = recordHexStr + " 1"
 const retrieveFailMessage = "RETRIEVE \n"
+const retrieveMismatchResponse = "ERROR record key does not match"
 const retrieveFailResponse = "ERROR Malformed request\n"
 
+const statSuccessMessage = "STAT " + idHexStr + " NAME " + nameHexStr + " VERSION 1\n"
+const statSuccessResponse = "1 1700000000000 0 0 - - " + metadataHexStr + " -"
+
+const versionsSuccessMessage = "VERSIONS " + idHexStr + "\n"
+const versionsNamedMessage = "VERSIONS " + idHexStr + " NAME " + nameHexStr + "\n"
+const versionsRequestIDMessage = "VERSIONS " + idHexStr + " NAME " + nameHexStr + " REQUEST req-1\n"
+const versionsSuccessResponse = "3 2 1"
+const versionsFailMessage = "VERSIONS \n"
+const versionsFailResponse = "ERROR Malformed request\n"
+
+const listSuccessMessage = "LIST " + idHexStr + "\n"
+const listOptionsMessage = "LIST " + idHexStr + " CURSOR " + nameHexStr + " LIMIT 1\n"
+const listSuccessResponse = nameHexStr + " " + nameHexStr + ",44,1700000000000,0,-," + metadataHexStr
+const listFailResponse = "ERROR Malformed request\n"
+
 const deleteSuccessMessage = "DELETE " + idHexStr + "\n"
 const deleteSuccessResponse = ""
+const deleteNamedMessage = "DELETE " + idHexStr + " NAME " + nameHexStr + "\n"
+const deleteMatchMessage = "DELETE " + idHexStr + " IFNONEMATCH *\n"
 const deleteFailMessage = "DELETE \n"
 const deleteFailResponse = "ERROR Malformed request\n"
 
+const updateMetadataMessage = "METADATA " + idHexStr + " " + metadataHexStr + " NAME " + nameHexStr +
+	" PRIOR " + keyHashHexStr + " SIGNATURES " + signaturesHexStr + "\n"
+const updateMetadataResponse = "SUCCESS 1 1700000000000 0 0 - - " + metadataHexStr + " " + signaturesHexStr
+const updateMetadataFailMessage = "METADATA " + idHexStr + " " + metadataHexStr + " PRIOR " + keyHashHexStr + "\n"
+
 // Test Variables
 var (
-	id     = []byte(idStr)
-	record = []byte(recordStr)
+	id         = []byte(idStr)
+	name       = []byte(nameStr)
+	record     = []byte(recordStr)
+	metadata   = []byte(metadataStr)
+	signatures = []byte(signaturesStr)
+	created    = time.UnixMilli(1700000000000)
+
+	keyHash = func() []byte {
+		h, _ := hex.DecodeString(keyHashHexStr)
+		return h
+	}()
 
 	goodClientConfig = map[string]string{
 		"serverAddr": serverAddr}
@@ -72,6 +127,22 @@ func (c MockConn) GetResponse(message string) (response string, err error) {
 		assert.Equal(c.t, storeSuccessMessage, message)
 		return storeSuccessResponse, nil
 
+	case "StoreNamed":
+		assert.Equal(c.t, storeNamedMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreMetadata":
+		assert.Equal(c.t, storeMetadataMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreOneTime":
+		assert.Equal(c.t, storeOneTimeMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreMatch":
+		assert.Equal(c.t, storeMatchMessage, message)
+		return storePreconditionResponse, nil
+
 	case "Retrieve":
 		if c.fail == "GetResponse" {
 			assert.Equal(c.t, retrieveFailMessage, message)
@@ -80,6 +151,50 @@ func (c MockConn) GetResponse(message string) (response string, err error) {
 		assert.Equal(c.t, retrieveSuccessMessage, message)
 		return retrieveSuccessResponse, nil
 
+	case "RetrieveNamed":
+		assert.Equal(c.t, retrieveNamedMessage, message)
+		return retrieveSuccessResponse, nil
+
+	case "RetrieveOptions":
+		assert.Equal(c.t, retrieveOptionsMessage, message)
+		return retrieveSuccessResponse, nil
+
+	case "RetrieveMismatch":
+		assert.Equal(c.t, retrieveOptionsMessage, message)
+		return retrieveMismatchResponse, nil
+
+	case "RetrieveMalformed":
+		assert.Equal(c.t, retrieveSuccessMessage, message)
+		return retrieveMalformedResponse, nil
+
+	case "Stat":
+		assert.Equal(c.t, statSuccessMessage, message)
+		return statSuccessResponse, nil
+
+	case "Versions":
+		if c.fail == "GetResponse" {
+			assert.Equal(c.t, versionsFailMessage, message)
+			return versionsFailResponse, nil
+		}
+		assert.Equal(c.t, versionsSuccessMessage, message)
+		return versionsSuccessResponse, nil
+
+	case "VersionsNamed":
+		assert.Equal(c.t, versionsNamedMessage, message)
+		return versionsSuccessResponse, nil
+
+	case "VersionsRequestID":
+		assert.Equal(c.t, versionsRequestIDMessage, message)
+		return versionsSuccessResponse, nil
+
+	case "List":
+		if c.fail == "GetResponse" {
+			assert.Equal(c.t, listSuccessMessage, message)
+			return listFailResponse, nil
+		}
+		assert.Equal(c.t, listOptionsMessage, message)
+		return listSuccessResponse, nil
+
 	case "Delete":
 		if c.fail == "GetResponse" {
 			assert.Equal(c.t, deleteFailMessage, message)
@@ -87,6 +202,22 @@ func (c MockConn) GetResponse(message string) (response string, err error) {
 		}
 		assert.Equal(c.t, deleteSuccessMessage, message)
 		return deleteSuccessResponse, nil
+
+	case "DeleteNamed":
+		assert.Equal(c.t, deleteNamedMessage, message)
+		return deleteSuccessResponse, nil
+
+	case "DeleteMatch":
+		assert.Equal(c.t, deleteMatchMessage, message)
+		return deleteSuccessResponse, nil
+
+	case "Metadata":
+		if c.fail == "GetResponse" {
+			assert.Equal(c.t, updateMetadataFailMessage, message)
+			return storePreconditionResponse, nil
+		}
+		assert.Equal(c.t, updateMetadataMessage, message)
+		return updateMetadataResponse, nil
 	}
 
 	return "", nil
@@ -135,13 +266,15 @@ func TestClient_StoreRecord(t *testing.T) {
 	}
 	type args struct {
 		id     []byte
+		name   []byte
 		record []byte
+		opts   utils.StoreOptions
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    []byte
+		want    utils.RecordInfo
 		wantErr error
 	}{
 		{
@@ -149,10 +282,59 @@ func TestClient_StoreRecord(t *testing.T) {
 			fields: fields{
 				conn: MockConn{t, "Store", ""},
 			},
+			want: utils.RecordInfo{Version: 1, Created: created},
+			args: args{
+				id:     id,
+				record: record,
+			},
+		},
+		{
+			name: "should run named store successfully",
+			fields: fields{
+				conn: MockConn{t, "StoreNamed", ""},
+			},
+			args: args{
+				id:     id,
+				name:   name,
+				record: record,
+			},
+			want: utils.RecordInfo{Version: 1, Created: created},
+		},
+		{
+			name: "should run store with metadata successfully",
+			fields: fields{
+				conn: MockConn{t, "StoreMetadata", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts:   utils.StoreOptions{Metadata: metadata, Signatures: signatures},
+			},
+			want: utils.RecordInfo{Version: 1, Created: created},
+		},
+		{
+			name: "should run one-time store successfully",
+			fields: fields{
+				conn: MockConn{t, "StoreOneTime", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts:   utils.StoreOptions{OneTime: true, KeyHash: keyHash},
+			},
+			want: utils.RecordInfo{Version: 1, Created: created},
+		},
+		{
+			name: "should return a failed precondition",
+			fields: fields{
+				conn: MockConn{t, "StoreMatch", ""},
+			},
 			args: args{
 				id:     id,
 				record: record,
+				opts:   utils.StoreOptions{Match: utils.Precondition{IfMatch: 1}},
 			},
+			wantErr: utils.ErrPreconditionFailed,
 		},
 		{
 			name: "should return an error",
@@ -173,7 +355,8 @@ func TestClient_StoreRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			err := c.StoreRecord(test.args.id, test.args.record)
+			got, err := c.StoreRecord(test.args.id, test.args.name, test.args.record, test.args.opts)
+			assert.Equal(t, test.want, got)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -186,34 +369,219 @@ func TestClient_RetrieveRecord(t *testing.T) {
 		conn utils.Conn
 	}
 	type args struct {
-		id []byte
+		id   []byte
+		name []byte
+		opts utils.RetrieveOptions
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		want     []byte
+		wantInfo utils.RecordInfo
+		wantErr  error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				conn: MockConn{t, "Retrieve", ""},
+			},
+			args: args{
+				id: id,
+			},
+			want:     record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Metadata: metadata},
+		},
+		{
+			name: "should run metadata-only retrieve successfully",
+			fields: fields{
+				conn: MockConn{t, "Stat", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+				opts: utils.RetrieveOptions{KeyHash: keyHash, Version: 1, MetadataOnly: true},
+			},
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Metadata: metadata},
+		},
+		{
+			name: "should fail on malformed response",
+			fields: fields{
+				conn: MockConn{t, "RetrieveMalformed", ""},
+			},
+			args: args{
+				id: id,
+			},
+			wantErr: errors.New("Malformed response"),
+		},
+		{
+			name: "should run named retrieve successfully",
+			fields: fields{
+				conn: MockConn{t, "RetrieveNamed", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+			},
+			want:     record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Metadata: metadata},
+		},
+		{
+			name: "should run with retrieve options successfully",
+			fields: fields{
+				conn: MockConn{t, "RetrieveOptions", ""},
+			},
+			args: args{
+				id:   id,
+				opts: utils.RetrieveOptions{KeyHash: keyHash, Version: 1},
+			},
+			want:     record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Metadata: metadata},
+		},
+		{
+			name: "should fail on mismatched key",
+			fields: fields{
+				conn: MockConn{t, "RetrieveMismatch", ""},
+			},
+			args: args{
+				id:   id,
+				opts: utils.RetrieveOptions{KeyHash: keyHash, Version: 1},
+			},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should return an error",
+			fields: fields{
+				conn: MockConn{t, "Retrieve", "GetResponse"},
+			},
+			args: args{
+				id: []byte(""),
+			},
+			wantErr: errors.New(retrieveFailResponse),
+		},
+	}
+
+	for _, test := range tests {
+		c := clientImpl{
+			conn: test.fields.conn,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, info, err := c.RetrieveRecord(test.args.id, test.args.name, test.args.opts)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantInfo, info)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// ListVersions() - Test Method
+func TestClient_ListVersions(t *testing.T) {
+
+	type fields struct {
+		conn utils.Conn
+	}
+	type args struct {
+		id   []byte
+		name []byte
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    []byte
+		want    []int64
 		wantErr error
 	}{
 		{
 			name: "should run successfully",
 			fields: fields{
-				conn: MockConn{t, "Retrieve", ""},
+				conn: MockConn{t, "Versions", ""},
 			},
 			args: args{
 				id: id,
 			},
-			want: record,
+			want: []int64{3, 2, 1},
+		},
+		{
+			name: "should run named list successfully",
+			fields: fields{
+				conn: MockConn{t, "VersionsNamed", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+			},
+			want: []int64{3, 2, 1},
 		},
 		{
 			name: "should return an error",
 			fields: fields{
-				conn: MockConn{t, "Retrieve", "GetResponse"},
+				conn: MockConn{t, "Versions", "GetResponse"},
 			},
 			args: args{
 				id: []byte(""),
 			},
-			wantErr: errors.New(retrieveFailResponse),
+			wantErr: errors.New(versionsFailResponse),
+		},
+	}
+
+	for _, test := range tests {
+		c := clientImpl{
+			conn: test.fields.conn,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.ListVersions(test.args.id, test.args.name)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// ListRecords() - Test Method
+func TestClient_ListRecords(t *testing.T) {
+
+	type fields struct {
+		conn utils.Conn
+	}
+	type args struct {
+		id   []byte
+		opts utils.ListOptions
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		want     []utils.RecordSummary
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				conn: MockConn{t, "List", ""},
+			},
+			args: args{
+				id:   id,
+				opts: utils.ListOptions{Cursor: nameHexStr, Limit: 1},
+			},
+			want: []utils.RecordSummary{{
+				Name:     name,
+				Size:     44,
+				Created:  created,
+				Metadata: metadata,
+			}},
+			wantNext: nameHexStr,
+		},
+		{
+			name: "should return an error",
+			fields: fields{
+				conn: MockConn{t, "List", "GetResponse"},
+			},
+			args: args{
+				id: id,
+			},
+			wantErr: errors.New(listFailResponse),
 		},
 	}
 
@@ -223,8 +591,9 @@ func TestClient_RetrieveRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			got, err := c.RetrieveRecord(test.args.id)
+			got, next, err := c.ListRecords(test.args.id, test.args.opts)
 			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantNext, next)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -237,7 +606,9 @@ func TestClient_DeleteRecord(t *testing.T) {
 		conn utils.Conn
 	}
 	type args struct {
-		id []byte
+		id   []byte
+		name []byte
+		opts utils.DeleteOptions
 	}
 	tests := []struct {
 		name    string
@@ -254,6 +625,26 @@ func TestClient_DeleteRecord(t *testing.T) {
 				id: id,
 			},
 		},
+		{
+			name: "should run named delete successfully",
+			fields: fields{
+				conn: MockConn{t, "DeleteNamed", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+			},
+		},
+		{
+			name: "should run with preconditions successfully",
+			fields: fields{
+				conn: MockConn{t, "DeleteMatch", ""},
+			},
+			args: args{
+				id:   id,
+				opts: utils.DeleteOptions{Match: utils.Precondition{IfNotExists: true}},
+			},
+		},
 		{
 			name: "should return an error",
 			fields: fields{
@@ -272,8 +663,103 @@ func TestClient_DeleteRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			err := c.DeleteRecord(test.args.id)
+			err := c.DeleteRecord(test.args.id, test.args.name, test.args.opts)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// UpdateMetadata() - Test Method
+func TestClient_UpdateMetadata(t *testing.T) {
+
+	type fields struct {
+		conn utils.Conn
+	}
+	type args struct {
+		id         []byte
+		name       []byte
+		prior      []byte
+		metadata   []byte
+		signatures []byte
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantInfo utils.RecordInfo
+		wantErr  error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				conn: MockConn{t, "Metadata", ""},
+			},
+			args: args{
+				id:         id,
+				name:       name,
+				prior:      keyHash,
+				metadata:   metadata,
+				signatures: signatures,
+			},
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Metadata: metadata, Signatures: signatures},
+		},
+		{
+			name: "should fail on changed prior metadata",
+			fields: fields{
+				conn: MockConn{t, "Metadata", "GetResponse"},
+			},
+			args: args{
+				id:       id,
+				prior:    keyHash,
+				metadata: metadata,
+			},
+			wantErr: utils.ErrPreconditionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		c := clientImpl{
+			conn: test.fields.conn,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			info, err := c.UpdateMetadata(test.args.id, test.args.name, test.args.prior, test.args.metadata,
+				test.args.signatures)
+			assert.Equal(t, test.wantInfo, info)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
 }
+
+// WithContext() - Test Method
+func TestClient_WithContext(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		config string
+		ctx    context.Context
+	}{
+		{
+			name:   "should pass on the request ID of the context last",
+			config: "VersionsRequestID",
+			ctx:    utils.ContextWithRequestID(context.Background(), "req-1"),
+		},
+		{
+			name:   "should send requests without a request ID unchanged",
+			config: "VersionsNamed",
+			ctx:    context.Background(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &clientImpl{conn: MockConn{t, test.config, ""}}
+			versions, err := c.WithContext(test.ctx).ListVersions(id, name)
+			assert.Equal(t, []int64{3, 2, 1}, versions)
+			assert.NoError(t, err)
+
+			// The client itself keeps no context.
+			assert.Nil(t, c.ctx)
+		})
+	}
+}
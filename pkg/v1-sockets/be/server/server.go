@@ -1,7 +1,11 @@
 package server
 
 import (
-	"log"
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
 
 	"enc-server-go/pkg/utils"
@@ -19,90 +23,388 @@ type serverImpl struct {
 	socketIO *utils.SocketIO
 }
 
-func (s *serverImpl) storeRecord(id, record string) (err error) {
+func parseOptions(fields []string, allowed []string) (opts map[string]string, ok bool) {
+
+	// Options are transmitted as name and value pairs.
+	if len(fields)%2 != 0 {
+		return nil, false
+	}
+
+	opts = make(map[string]string, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		if !slices.Contains(allowed, fields[i]) {
+			return nil, false
+		}
+		opts[fields[i]] = fields[i+1]
+	}
+
+	return opts, true
+}
+
+func parsePrecondition(opts map[string]string) (match utils.Precondition, err error) {
+
+	// Precondition options carry HTTP If-Match and If-None-Match values.
+	return utils.ParsePrecondition(opts["IFMATCH"], opts["IFNONEMATCH"])
+}
+
+func recordInfo(entry utils.Entry) (info utils.RecordInfo, err error) {
+
+	// Sealed metadata and signatures are stored as hex strings.
+	metadata, err := hex.DecodeString(entry.Metadata)
+	if err != nil {
+		return utils.RecordInfo{}, err
+	}
+	signatures, err := hex.DecodeString(entry.Signatures)
+	if err != nil {
+		return utils.RecordInfo{}, err
+	}
+
+	info = utils.RecordInfo{
+		Version:    entry.Version,
+		Created:    entry.Created,
+		Updated:    entry.Updated,
+		Metadata:   metadata,
+		Signatures: signatures,
+	}
+	return info, nil
+}
+
+func (s *serverImpl) storeRecord(entry utils.Entry, match utils.Precondition) (stored utils.Entry, err error) {
 
 	// Call data store wrapper store method.
-	if err = s.db.StoreRecord(id, record); err != nil {
-		return err
+	if stored, err = s.db.StoreRecord(entry, match); err != nil {
+		return utils.Entry{}, err
 	}
 
-	return nil
+	return stored, nil
 }
 
-func (s *serverImpl) retrieveRecord(id string) (record string, err error) {
+func (s *serverImpl) retrieveRecord(id, name, keyHash string, version int64) (entry utils.Entry, err error) {
 
 	// Call data store wrapper retrieve method.
-	if record, err = s.db.RetrieveRecord(id); err != nil {
-		return "", err
+	if entry, err = s.db.RetrieveRecord(id, name, keyHash, version); err != nil {
+		return utils.Entry{}, err
 	}
 
-	return record, nil
+	// Streamed records are read back from their chunks.
+	if entry.Record, err = utils.LoadRecord(s.db, entry); err != nil {
+		return utils.Entry{}, err
+	}
+
+	return entry, nil
 }
 
-func (s *serverImpl) deleteRecord(id string) (err error) {
+func (s *serverImpl) statRecord(id, name string, version int64) (entry utils.Entry, err error) {
+
+	// Call data store wrapper stat method.
+	if entry, err = s.db.StatRecord(id, name, version); err != nil {
+		return utils.Entry{}, err
+	}
+
+	return entry, nil
+}
+
+func parseVersion(opts map[string]string) (version int64, err error) {
+
+	// Zero addresses the current version.
+	if val, ok := opts["VERSION"]; ok {
+		if version, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+
+	return version, nil
+}
+
+func (s *serverImpl) listVersions(id, name string) (versions []int64, err error) {
+
+	// Call data store wrapper list versions method.
+	if versions, err = s.db.ListVersions(id, name); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *serverImpl) listRecords(id, cursor string, limit int64) (records []utils.RecordSummary, next string, err error) {
+
+	// Call data store wrapper list records method.
+	entries, next, err := s.db.ListRecords(id, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Summarize entries without their contents.
+	for _, entry := range entries {
+		name, err := hex.DecodeString(entry.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		metadata, err := hex.DecodeString(entry.Metadata)
+		if err != nil {
+			return nil, "", err
+		}
+		records = append(records, utils.RecordSummary{
+			Name:     name,
+			Size:     entry.Size,
+			Created:  entry.Created,
+			Updated:  entry.Updated,
+			Metadata: metadata,
+		})
+	}
+
+	return records, next, nil
+}
+
+func (s *serverImpl) deleteRecord(id, name string, match utils.Precondition) (err error) {
 
 	// Call data store wrapper delete method.
-	if err = s.db.DeleteRecord(id); err != nil {
+	if err = s.db.DeleteRecord(id, name, match); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (s *serverImpl) updateMetadata(id, name, prior, metadata, signatures string) (updated utils.Entry, err error) {
+
+	// Call data store wrapper metadata update method.
+	if updated, err = s.db.UpdateMetadata(id, name, prior, metadata, signatures); err != nil {
+		return utils.Entry{}, err
+	}
+
+	return updated, nil
+}
+
 func (s *serverImpl) Respond(message string) (response []byte) {
+	return s.RespondContext(context.Background(), message)
+}
+
+func (s *serverImpl) RespondContext(ctx context.Context, message string) (response []byte) {
 
 	message = strings.TrimRight(message, " \n")
-	log.Println(message)
 
-	// Split message.
+	// Split message, logging the request by its verb and ID only.
 	fields := strings.Split(message, " ")
+	if len(fields) > 1 {
+		slog.InfoContext(ctx, "BE server received a request", "verb", fields[0], "id", fields[1])
+	} else {
+		slog.InfoContext(ctx, "BE server received a request", "verb", fields[0])
+	}
 
 	// Compose response.
 	switch fields[0] {
 	case "STORE":
 		const expectedFields = 3
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:],
+			[]string{"NAME", "ONETIME", "IFMATCH", "IFNONEMATCH", "METADATA", "SIGNATURES"})
+		if !ok {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
-		id, record := fields[1], fields[2]
+		entry := utils.Entry{
+			Id:         fields[1],
+			Name:       opts["NAME"],
+			Record:     fields[2],
+			Metadata:   opts["METADATA"],
+			Signatures: opts["SIGNATURES"],
+		}
+
+		// One-time records carry the commitment to their key.
+		if keyHash, ok := opts["ONETIME"]; ok {
+			entry.OneTime, entry.KeyHash = true, keyHash
+		}
 
-		if err := s.storeRecord(id, record); err != nil {
+		match, err := parsePrecondition(opts)
+		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
-		response = []byte("SUCCESS\n")
+
+		stored, err := s.storeRecord(entry, match)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		info, err := recordInfo(stored)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte("SUCCESS " + utils.FormatRecordInfo(info) + "\n")
 
 	case "RETRIEVE":
 		const expectedFields = 2
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"NAME", "KEYHASH", "VERSION"})
+		if !ok {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		id := fields[1]
+
+		version, err := parseVersion(opts)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		entry, err := s.retrieveRecord(id, opts["NAME"], opts["KEYHASH"], version)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		info, err := recordInfo(entry)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte(entry.Record + " " + utils.FormatRecordInfo(info) + "\n")
+
+	case "STAT":
+		const expectedFields = 2
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"NAME", "VERSION"})
+		if !ok {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
 		id := fields[1]
 
-		record, err := s.retrieveRecord(id)
+		version, err := parseVersion(opts)
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
-		response = []byte(record + "\n")
+
+		entry, err := s.statRecord(id, opts["NAME"], version)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		info, err := recordInfo(entry)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte(utils.FormatRecordInfo(info) + "\n")
+
+	case "VERSIONS":
+		const expectedFields = 2
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"NAME"})
+		if !ok {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		id := fields[1]
+
+		versions, err := s.listVersions(id, opts["NAME"])
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		versionStrs := make([]string, len(versions))
+		for i, version := range versions {
+			versionStrs[i] = strconv.FormatInt(version, 10)
+		}
+		response = []byte(strings.Join(versionStrs, " ") + "\n")
+
+	case "LIST":
+		const expectedFields = 2
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"CURSOR", "LIMIT"})
+		if !ok {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		id := fields[1]
+
+		// Zero selects the default page size.
+		var limit int64
+		if val, ok := opts["LIMIT"]; ok {
+			var err error
+			if limit, err = strconv.ParseInt(val, 10, 64); err != nil {
+				response = []byte("ERROR " + err.Error() + "\n")
+				return response
+			}
+		}
+
+		records, next, err := s.listRecords(id, opts["CURSOR"], limit)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte(utils.FormatRecordList(records, next) + "\n")
 
 	case "DELETE":
 		const expectedFields = 2
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"NAME", "IFMATCH", "IFNONEMATCH"})
+		if !ok {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
 		id := fields[1]
 
-		err := s.deleteRecord(id)
+		match, err := parsePrecondition(opts)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		err = s.deleteRecord(id, opts["NAME"], match)
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
 		response = []byte("\n")
 
+	case "METADATA":
+		const expectedFields = 3
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		opts, ok := parseOptions(fields[expectedFields:], []string{"NAME", "PRIOR", "SIGNATURES"})
+		if !ok {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+		id := fields[1]
+
+		updated, err := s.updateMetadata(id, opts["NAME"], opts["PRIOR"], fields[2], opts["SIGNATURES"])
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		info, err := recordInfo(updated)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte("SUCCESS " + utils.FormatRecordInfo(info) + "\n")
+
 	default:
 		response = []byte("ERROR Malformed request\n")
 	}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/maps"
@@ -15,6 +17,15 @@ const port = "8888"
 const mongoURI = "mongodb://localhost:27017"
 
 const idHexEncStr = "396263343233393039616335acc30dd405c51d37675d4e0002a526ae113d56"
+const nameHexEncStr = "6c9a1f3e5b7d20c4a8e6f1d3b5c7e9a0"
+const keyHashHexStr = "2f8ad1bd3e2be0a2c7e6bb4a4e0b5e6e2e7d2b8d5e0c1f3a7b9c4d6e8f0a1b2c"
+const metadataHexStr = "3962633432333930396163353a4c0e1f2d3b5a69788796a5b4c3d2e1"
+const signaturesHexStr = "7b22656e76656c6f7065223a7b7d7d"
+const streamID = "5f3c8e2a9b1d4f60a7c2e8d1b3f5a9c0"
+
+// Record attributes as encoded on the socket protocol.
+const storedInfo = "1 1700000000000 1700000600000 0 - - - -"
+const retrievedInfo = "1 1700000000000 1700000600000 0 - - " + metadataHexStr + " " + signaturesHexStr
 
 const recordHexEncStr = "396263343233393039616335b6d61c6839a0dda2524d19b4e5d" +
 	"ac5a1fda8902ad2701ced5c31c89088c3151d039ee27d003b75c3a140141c05da496572142eb" +
@@ -22,6 +33,9 @@ const recordHexEncStr = "396263343233393039616335b6d61c6839a0dda2524d19b4e5d" +
 
 // Test Variables
 var (
+	created = time.UnixMilli(1700000000000)
+	updated = time.UnixMilli(1700000600000)
+
 	goodDBConfig = map[string]string{
 		"port":     port,
 		"mongoURI": mongoURI,
@@ -74,31 +88,143 @@ type MockDB struct {
 	fail string
 }
 
-func (db *MockDB) StoreRecord(id, record string) (err error) {
+func (db *MockDB) StoreRecord(entry utils.Entry, match utils.Precondition) (stored utils.Entry, err error) {
 	if db.fail == "Store" {
-		return errors.New(badDBClientMessage)
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	} else if match.IfMatch > 1 {
+		return utils.Entry{}, utils.ErrPreconditionFailed
 	}
-	assert.Equal(db.t, idHexEncStr, id)
-	assert.Equal(db.t, recordHexEncStr, record)
-	return nil
+	assert.Equal(db.t, idHexEncStr, entry.Id)
+	assert.Equal(db.t, recordHexEncStr, entry.Record)
+	if entry.Name != "" {
+		assert.Equal(db.t, nameHexEncStr, entry.Name)
+	}
+	if entry.OneTime {
+		assert.Equal(db.t, keyHashHexStr, entry.KeyHash)
+	}
+	if entry.Metadata != "" {
+		assert.Equal(db.t, metadataHexStr, entry.Metadata)
+	}
+	if entry.Signatures != "" {
+		assert.Equal(db.t, signaturesHexStr, entry.Signatures)
+	}
+	return utils.Entry{Version: 1, Created: created, Updated: updated}, nil
 }
 
-func (db *MockDB) RetrieveRecord(id string) (record string, err error) {
+func (db *MockDB) RetrieveRecord(id, name, keyHash string, version int64) (entry utils.Entry, err error) {
 	if db.fail == "Retrieve" {
-		return "", errors.New(badDBClientMessage)
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	if keyHash != "" {
+		assert.Equal(db.t, keyHashHexStr, keyHash)
+	}
+	if version > 1 {
+		return utils.Entry{}, utils.ErrVersionNotFound
+	}
+	if db.fail == "Streamed" || db.fail == "Chunks" {
+		return utils.Entry{Id: id, Name: name, Stream: streamID, Chunks: 2, Version: 1,
+			Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+	}
+	return utils.Entry{Id: id, Name: name, Record: recordHexEncStr, Version: 1,
+		Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+}
+
+func (db *MockDB) StatRecord(id, name string, version int64) (entry utils.Entry, err error) {
+	if db.fail == "Stat" {
+		return utils.Entry{}, errors.New(badDBClientMessage)
 	}
 	assert.Equal(db.t, idHexEncStr, id)
-	return recordHexEncStr, nil
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	if version > 1 {
+		return utils.Entry{}, utils.ErrVersionNotFound
+	}
+	return utils.Entry{Id: id, Name: name, Version: 1,
+		Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
 }
 
-func (db *MockDB) DeleteRecord(id string) (err error) {
+func (db *MockDB) ListVersions(id, name string) (versions []int64, err error) {
+	if db.fail == "Versions" {
+		return nil, errors.New(badDBClientMessage)
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	return []int64{3, 2, 1}, nil
+}
+
+func (db *MockDB) DeleteRecord(id, name string, match utils.Precondition) (err error) {
 	if db.fail == "Delete" {
 		return errors.New(badDBClientMessage)
+	} else if match.IfMatch > 1 {
+		return utils.ErrPreconditionFailed
 	}
 	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
 	return nil
 }
 
+func (db *MockDB) ListRecords(id, cursor string, limit int64) (entries []utils.Entry, next string, err error) {
+	if db.fail == "List" {
+		return nil, "", errors.New(badDBClientMessage)
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	entries = []utils.Entry{{Name: nameHexEncStr, Size: 44, Metadata: metadataHexStr,
+		Created: created, Updated: updated}}
+	if limit == 1 && cursor == "" {
+		return entries, nameHexEncStr, nil
+	}
+	return entries, "", nil
+}
+
+func (db *MockDB) UpdateMetadata(id, name, prior, metadata, signatures string) (entry utils.Entry, err error) {
+	if db.fail == "Metadata" {
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	} else if prior != metadataHexStr {
+		return utils.Entry{}, utils.ErrPreconditionFailed
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	assert.Equal(db.t, metadataHexStr, metadata)
+	assert.Equal(db.t, signaturesHexStr, signatures)
+	return utils.Entry{Version: 1, Metadata: metadata, Signatures: signatures, Created: created, Updated: updated}, nil
+}
+
+func (db *MockDB) StoreChunks(stream string, next func() (data string, err error)) (chunks int64, err error) {
+	return 0, errors.New(badDBClientMessage)
+}
+
+func (db *MockDB) RetrieveChunks(stream string, chunks int64, fn func(data string) error) (err error) {
+	if db.fail == "Chunks" {
+		return utils.ErrChunkMissing
+	}
+	assert.Equal(db.t, streamID, stream)
+	assert.Equal(db.t, int64(2), chunks)
+	half := len(recordHexEncStr) / 2
+	if err = fn(recordHexEncStr[:half]); err != nil {
+		return err
+	}
+	return fn(recordHexEncStr[half:])
+}
+
+func (db *MockDB) DeleteChunks(streams []string) (err error) {
+	return errors.New(badDBClientMessage)
+}
+
+func (db *MockDB) WithContext(ctx context.Context) utils.DB {
+	return db
+}
+
 // MakeServer() - Test Method
 func TestServer_MakeServer(t *testing.T) {
 
@@ -166,7 +292,64 @@ func TestServer_Respond(t *testing.T) {
 				db: &MockDB{t, ""},
 			},
 			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr},
-			want: []byte("SUCCESS\n"),
+			want: []byte("SUCCESS " + storedInfo + "\n"),
+		},
+		{
+			name: "should run one-time StoreRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " ONETIME " + keyHashHexStr},
+			want: []byte("SUCCESS " + storedInfo + "\n"),
+		},
+		{
+			name: "should run named StoreRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " NAME " + nameHexEncStr},
+			want: []byte("SUCCESS " + storedInfo + "\n"),
+		},
+		{
+			name: "should run StoreRecord() with metadata successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " METADATA " + metadataHexStr +
+				" SIGNATURES " + signaturesHexStr},
+			want: []byte("SUCCESS " + storedInfo + "\n"),
+		},
+		{
+			name: "should fail on unrecognized StoreRecord() option",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " FOO " + keyHashHexStr},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should run conditional StoreRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " IFMATCH \"1\""},
+			want: []byte("SUCCESS " + storedInfo + "\n"),
+		},
+		{
+			name: "should fail on mismatched StoreRecord() revision",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " IFMATCH \"2\""},
+			want: []byte("ERROR " + utils.ErrPreconditionFailed.Error() + "\n"),
+		},
+		{
+			name: "should fail on malformed StoreRecord() precondition",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STORE " + idHexEncStr + " " + recordHexEncStr + " IFNONEMATCH \"1\""},
+			want: []byte("ERROR If-None-Match only supports *\n"),
 		},
 		{
 			name: "should run RetrieveRecord() successfully",
@@ -174,7 +357,167 @@ func TestServer_Respond(t *testing.T) {
 				db: &MockDB{t, ""},
 			},
 			args: args{"RETRIEVE " + idHexEncStr},
-			want: []byte(recordHexEncStr + "\n"),
+			want: []byte(recordHexEncStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should run RetrieveRecord() with options successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexEncStr + " KEYHASH " + keyHashHexStr + " VERSION 1"},
+			want: []byte(recordHexEncStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should run named RetrieveRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexEncStr + " NAME " + nameHexEncStr},
+			want: []byte(recordHexEncStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should run streamed RetrieveRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, "Streamed"},
+			},
+			args: args{"RETRIEVE " + idHexEncStr},
+			want: []byte(recordHexEncStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should fail on missing RetrieveRecord() chunks",
+			fields: fields{
+				db: &MockDB{t, "Chunks"},
+			},
+			args: args{"RETRIEVE " + idHexEncStr},
+			want: []byte("ERROR " + utils.ErrChunkMissing.Error() + "\n"),
+		},
+		{
+			name: "should fail on unpaired RetrieveRecord() option",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexEncStr + " " + keyHashHexStr},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on unretained RetrieveRecord() version",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexEncStr + " VERSION 2"},
+			want: []byte("ERROR " + utils.ErrVersionNotFound.Error() + "\n"),
+		},
+		{
+			name: "should run StatRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STAT " + idHexEncStr + " NAME " + nameHexEncStr + " VERSION 1"},
+			want: []byte(retrievedInfo + "\n"),
+		},
+		{
+			name: "should fail on unretained StatRecord() version",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STAT " + idHexEncStr + " VERSION 2"},
+			want: []byte("ERROR " + utils.ErrVersionNotFound.Error() + "\n"),
+		},
+		{
+			name: "should fail on unrecognized StatRecord() option",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STAT " + idHexEncStr + " KEYHASH " + keyHashHexStr},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on StatRecord() token count",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"STAT"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on database client StatRecord()",
+			fields: fields{
+				db: &MockDB{t, "Stat"},
+			},
+			args: args{"STAT " + idHexEncStr},
+			want: []byte("ERROR " + badDBClientMessage + "\n"),
+		},
+		{
+			name: "should run ListVersions() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"VERSIONS " + idHexEncStr},
+			want: []byte("3 2 1\n"),
+		},
+		{
+			name: "should run named ListVersions() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"VERSIONS " + idHexEncStr + " NAME " + nameHexEncStr},
+			want: []byte("3 2 1\n"),
+		},
+		{
+			name: "should fail on ListVersions() token count",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"VERSIONS"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on database client ListVersions()",
+			fields: fields{
+				db: &MockDB{t, "Versions"},
+			},
+			args: args{"VERSIONS " + idHexEncStr},
+			want: []byte("ERROR " + badDBClientMessage + "\n"),
+		},
+		{
+			name: "should run ListRecords() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"LIST " + idHexEncStr},
+			want: []byte("- " + nameHexEncStr + ",44,1700000000000,1700000600000,-," + metadataHexStr + "\n"),
+		},
+		{
+			name: "should run paged ListRecords() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"LIST " + idHexEncStr + " LIMIT 1"},
+			want: []byte(nameHexEncStr + " " + nameHexEncStr + ",44,1700000000000,1700000600000,-," + metadataHexStr + "\n"),
+		},
+		{
+			name: "should fail on invalid ListRecords() limit",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"LIST " + idHexEncStr + " LIMIT all"},
+			want: []byte("ERROR strconv.ParseInt: parsing \"all\": invalid syntax\n"),
+		},
+		{
+			name: "should fail on ListRecords() token count",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"LIST"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on database client ListRecords()",
+			fields: fields{
+				db: &MockDB{t, "List"},
+			},
+			args: args{"LIST " + idHexEncStr + " CURSOR " + nameHexEncStr},
+			want: []byte("ERROR " + badDBClientMessage + "\n"),
 		},
 		{
 			name: "should run DeleteRecord() successfully",
@@ -182,7 +525,7 @@ func TestServer_Respond(t *testing.T) {
 				db: &MockDB{t, ""},
 			},
 			args: args{"RETRIEVE " + idHexEncStr},
-			want: []byte(recordHexEncStr + "\n"),
+			want: []byte(recordHexEncStr + " " + retrievedInfo + "\n"),
 		},
 		{
 			name: "should fail on StoreRecord() token count",
@@ -216,6 +559,38 @@ func TestServer_Respond(t *testing.T) {
 			args: args{"RETRIEVE " + idHexEncStr},
 			want: []byte("ERROR " + badDBClientMessage + "\n"),
 		},
+		{
+			name: "should run conditional DeleteRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"DELETE " + idHexEncStr + " IFMATCH *"},
+			want: []byte("\n"),
+		},
+		{
+			name: "should run named DeleteRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"DELETE " + idHexEncStr + " NAME " + nameHexEncStr},
+			want: []byte("\n"),
+		},
+		{
+			name: "should fail on mismatched DeleteRecord() revision",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"DELETE " + idHexEncStr + " IFMATCH \"2\""},
+			want: []byte("ERROR " + utils.ErrPreconditionFailed.Error() + "\n"),
+		},
+		{
+			name: "should fail on unrecognized DeleteRecord() option",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"DELETE " + idHexEncStr + " ONETIME " + keyHashHexStr},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
 		{
 			name: "should fail on DeleteRecord() token count",
 			fields: fields{
@@ -232,6 +607,47 @@ func TestServer_Respond(t *testing.T) {
 			args: args{"DELETE " + idHexEncStr},
 			want: []byte("ERROR " + badDBClientMessage + "\n"),
 		},
+		{
+			name: "should run UpdateMetadata() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"METADATA " + idHexEncStr + " " + metadataHexStr + " NAME " + nameHexEncStr +
+				" PRIOR " + metadataHexStr + " SIGNATURES " + signaturesHexStr},
+			want: []byte("SUCCESS " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should fail on changed UpdateMetadata() prior metadata",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"METADATA " + idHexEncStr + " " + metadataHexStr + " PRIOR " + keyHashHexStr},
+			want: []byte("ERROR " + utils.ErrPreconditionFailed.Error() + "\n"),
+		},
+		{
+			name: "should fail on unrecognized UpdateMetadata() option",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"METADATA " + idHexEncStr + " " + metadataHexStr + " VERSION 1"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on UpdateMetadata() token count",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{"METADATA " + idHexEncStr},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on database client UpdateMetadata()",
+			fields: fields{
+				db: &MockDB{t, "Metadata"},
+			},
+			args: args{"METADATA " + idHexEncStr + " " + metadataHexStr + " PRIOR " + metadataHexStr},
+			want: []byte("ERROR " + badDBClientMessage + "\n"),
+		},
 	}
 
 	for _, test := range tests {
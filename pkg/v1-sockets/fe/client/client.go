@@ -3,83 +3,243 @@ package client
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"strconv"
 	"strings"
 
 	"enc-server-go/pkg/utils"
 )
 
+// Returned for recipient records, which the socket protocol does not carry.
+var ErrRecipientsUnsupported = errors.New("Recipient records are not supported by the v1 socket protocol")
+
+// Returned for split record keys, which the socket protocol does not carry.
+var ErrSharesUnsupported = errors.New("Key shares are not supported by the v1 socket protocol")
+
 // Client implementation.
 type clientImpl struct {
 	conn utils.Conn
 }
 
-func (c *clientImpl) StoreRecord(id, record []byte) (key []byte, err error) {
+func nameOption(name []byte) (option string) {
+
+	// The default record is addressed without a name.
+	if len(name) == 0 {
+		return ""
+	}
+	return " NAME " + hex.EncodeToString(name)
+}
+
+func preconditionOptions(match utils.Precondition) (options string) {
+
+	// Precondition options carry HTTP If-Match and If-None-Match values.
+	ifMatch, ifNoneMatch := match.Headers()
+	if ifMatch != "" {
+		options += " IFMATCH " + ifMatch
+	}
+	if ifNoneMatch != "" {
+		options += " IFNONEMATCH " + ifNoneMatch
+	}
+
+	return options
+}
+
+func responseError(message string) (err error) {
+
+	// Failed preconditions and rate limits are reported as distinct errors.
+	if message == "ERROR "+utils.ErrPreconditionFailed.Error() {
+		return utils.ErrPreconditionFailed
+	}
+	if strings.HasPrefix(message, "ERROR "+utils.RateLimitedCode+" ") {
+		return utils.ErrRateLimited
+	}
+	return errors.New(message)
+}
+
+func (c *clientImpl) StoreRecord(id, name, record []byte, opts utils.StoreOptions) (key []byte, info utils.RecordInfo, err error) {
+	if len(opts.Recipients) > 0 {
+		return nil, info, ErrRecipientsUnsupported
+	}
 
 	// Encode data as hex strings.
 	idStr := hex.EncodeToString(id)
 	recordStr := hex.EncodeToString(record)
 
+	// Append store options.
+	request := "STORE " + idStr + " " + recordStr + nameOption(name)
+	if opts.OneTime {
+		request += " ONETIME"
+	}
+	if opts.NoCompress {
+		request += " NOCOMPRESS"
+	}
+	if opts.ContentType != "" {
+		request += " TYPE " + hex.EncodeToString([]byte(opts.ContentType))
+	}
+	if len(opts.Labels) > 0 {
+		labels, err := json.Marshal(opts.Labels)
+		if err != nil {
+			return nil, info, err
+		}
+		request += " LABELS " + hex.EncodeToString(labels)
+	}
+	if opts.ContentSignature != nil {
+		request += " SIGNER " + hex.EncodeToString(opts.ContentSignature.KeyID) +
+			" SIGNATURE " + hex.EncodeToString(opts.ContentSignature.Signature)
+	}
+	request += preconditionOptions(opts.Match)
+
 	// Write request to server.
-	message, err := c.conn.GetResponse("STORE " + idStr + " " + recordStr + "\n")
+	message, err := c.conn.GetResponse(request + "\n")
 	if err != nil {
-		return nil, err
+		return nil, info, err
 	}
 
 	// Check for error.
 	if strings.HasPrefix(message, "ERROR") {
-		err = errors.New(message)
-		return nil, err
+		err = responseError(message)
+		return nil, info, err
 	}
 
+	// Split key from record attributes.
+	fields := strings.Split(message, " ")
+
 	// Decode response
-	if key, err = hex.DecodeString(message); err != nil {
-		return nil, err
+	if key, err = hex.DecodeString(fields[0]); err != nil {
+		return nil, info, err
+	}
+	if info, err = utils.ParseRecordInfo(fields[1:]); err != nil {
+		return nil, info, err
 	}
 
-	return key, nil
+	return key, info, nil
 }
 
-func (c *clientImpl) RetrieveRecord(id, key []byte) (record []byte, err error) {
+func (c *clientImpl) RetrieveRecord(id, name, key []byte) (record []byte, info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
 	keyStr := hex.EncodeToString(key)
 
 	// Write request to server.
-	message, err := c.conn.GetResponse("RETRIEVE " + idStr + " " + keyStr + "\n")
+	request := "RETRIEVE " + idStr + " " + keyStr + nameOption(name)
+	message, err := c.conn.GetResponse(request + "\n")
 	if err != nil {
-		return nil, err
+		return nil, info, err
 	}
 
 	// Check for error.
 	if strings.HasPrefix(message, "ERROR") {
-		err = errors.New(message)
-		return nil, err
+		err = responseError(message)
+		return nil, info, err
 	}
 
+	// Split record from record attributes.
+	fields := strings.Split(message, " ")
+
 	// Decode response
-	if record, err = hex.DecodeString(message); err != nil {
-		return nil, err
+	if record, err = hex.DecodeString(fields[0]); err != nil {
+		return nil, info, err
+	}
+	if info, err = utils.ParseRecordInfo(fields[1:]); err != nil {
+		return nil, info, err
 	}
 
-	return record, nil
+	// Content signatures are verified by the server before it responds.
+	info.Signer = utils.ContentSigner(info.Signatures)
+	return record, info, nil
 }
 
-func (c *clientImpl) DeleteRecord(id []byte) (err error) {
+func (c *clientImpl) StoreRecordShares(id, name, record []byte, shares, threshold int,
+	opts utils.StoreOptions) (keyShares [][]byte, info utils.RecordInfo, err error) {
+	return nil, info, ErrSharesUnsupported
+}
+
+func (c *clientImpl) RetrieveRecordShares(id, name []byte, keyShares [][]byte) (record []byte, info utils.RecordInfo, err error) {
+	return nil, info, ErrSharesUnsupported
+}
+
+func (c *clientImpl) RetrieveRecordFor(id, name, private []byte) (record []byte, info utils.RecordInfo, err error) {
+	return nil, info, ErrRecipientsUnsupported
+}
+
+func (c *clientImpl) AddRecipients(id, name, key []byte, recipients, members [][]byte) (slots [][]byte, err error) {
+	return nil, ErrRecipientsUnsupported
+}
+
+func (c *clientImpl) RevokeRecipient(id, name, key, slot []byte) (err error) {
+	return ErrRecipientsUnsupported
+}
+
+func (c *clientImpl) StatRecord(id, name []byte) (info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
 
 	// Write request to server.
-	message, err := c.conn.GetResponse("DELETE " + idStr + "\n")
+	message, err := c.conn.GetResponse("STAT " + idStr + nameOption(name) + "\n")
 	if err != nil {
-		return err
+		return info, err
+	}
+
+	// Check for error.
+	if strings.HasPrefix(message, "ERROR") {
+		err = responseError(message)
+		return info, err
+	}
+
+	return utils.ParseRecordInfo(strings.Split(message, " "))
+}
+
+func (c *clientImpl) ListRecords(id []byte, opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+
+	// Append list options.
+	request := "LIST " + idStr
+	if opts.Cursor != "" {
+		request += " CURSOR " + opts.Cursor
+	}
+	if opts.Limit > 0 {
+		request += " LIMIT " + strconv.FormatInt(opts.Limit, 10)
+	}
+
+	// Write request to server.
+	message, err := c.conn.GetResponse(request + "\n")
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Check for error.
 	if strings.HasPrefix(message, "ERROR") {
 		err = errors.New(message)
+		return nil, "", err
+	}
+
+	return utils.ParseRecordList(message)
+}
+
+func (c *clientImpl) DeleteRecord(id, name, key []byte, opts utils.DeleteOptions) (err error) {
+
+	// Encode data as hex strings. Admins may omit the record key as "-".
+	idStr := hex.EncodeToString(id)
+	keyStr := "-"
+	if key != nil {
+		keyStr = hex.EncodeToString(key)
+	}
+
+	// Write request to server.
+	request := "DELETE " + idStr + " " + keyStr + nameOption(name) + preconditionOptions(opts.Match)
+	message, err := c.conn.GetResponse(request + "\n")
+	if err != nil {
+		return err
+	}
+
+	// Check for error.
+	if strings.HasPrefix(message, "ERROR") {
+		err = responseError(message)
 		return err
 	}
 
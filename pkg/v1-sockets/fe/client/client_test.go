@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"enc-server-go/pkg/utils"
 
@@ -24,26 +25,80 @@ const recordHexStr = "5041594c4f4144535041594c4f4144535041594c4f414453" +
 const keyHexStr = "a3fa4f280ab48300e3cde091a1c47b5b96344af34579df" +
 	"632374018b03ec20f8"
 
+const nameStr = "notes"
+const nameHexStr = "6e6f746573"
+
+const contentTypeStr = "text/plain"
+const contentTypeHexStr = "746578742f706c61696e"
+const labelsHexStr = "7b22656e76223a2270726f64227d"
+
+const signerHexStr = "e830b24eac1944eb"
+const signatureHexStr = "3785f2beb250fa39d3"
+
+// Hex JSON record signatures holding a content signature by the signer.
+const signaturesHexStr = "7b22636f6e74656e74223a7b226b65794964223a223644437954717" +
+	"75a524f733d222c227369676e6174757265223a224e34587976724a512b6a6e54227d7d"
+
 const badClientMessage = "MakeClient missing configuration serverAddr"
 
 const storeSuccessMessage = "STORE " + idHexStr + " " + recordHexStr + "\n"
+const storeNamedMessage = "STORE " + idHexStr + " " + recordHexStr + " NAME " + nameHexStr + "\n"
+const storeAttributesMessage = "STORE " + idHexStr + " " + recordHexStr + " TYPE " + contentTypeHexStr +
+	" LABELS " + labelsHexStr + "\n"
+const storeSignedMessage = "STORE " + idHexStr + " " + recordHexStr + " SIGNER " + signerHexStr +
+	" SIGNATURE " + signatureHexStr + "\n"
+const storeOptionsMessage = "STORE " + idHexStr + " " + recordHexStr + " ONETIME NOCOMPRESS IFNONEMATCH *\n"
+const storePreconditionResponse = "ERROR record precondition failed"
 const storeFailMessage = "STORE  \n"
 const storeFailResponse = "ERROR Malformed request\n"
 
 const retrieveSuccessMessage = "RETRIEVE " + idHexStr + " " + keyHexStr + "\n"
-const retrieveSuccessResponse = recordHexStr
+const retrieveNamedMessage = "RETRIEVE " + idHexStr + " " + keyHexStr + " NAME " + nameHexStr + "\n"
+const retrieveSuccessResponse = recordHexStr + " 2 1700000000000 1700000600000 64 " +
+	contentTypeHexStr + " " + labelsHexStr + " - -"
+const retrieveSignedResponse = recordHexStr + " 2 1700000000000 1700000600000 64 - - - " + signaturesHexStr
+const retrieveMalformedResponse = recordHexStr + " 2"
+const rateLimitedResponse = "ERROR RATELIMITED 2"
 const retrieveFailMessage = "RETRIEVE  \n"
 const retrieveFailResponse = "ERROR Malformed request\n"
 
-const deleteSuccessMessage = "DELETE " + idHexStr + "\n"
+const statSuccessMessage = "STAT " + idHexStr + " NAME " + nameHexStr + "\n"
+const statSuccessResponse = "2 1700000000000 1700000600000 64 " + contentTypeHexStr + " " + labelsHexStr + " - -"
+const statFailMessage = "STAT \n"
+const statFailResponse = "ERROR Malformed request\n"
+
+const listSuccessMessage = "LIST " + idHexStr + " CURSOR abc LIMIT 2\n"
+const listSuccessResponse = "- " + nameHexStr + ",64,1700000000000,1700000600000," + contentTypeHexStr + ",-"
+const listFailMessage = "LIST \n"
+const listFailResponse = "ERROR Malformed request\n"
+
+const deleteSuccessMessage = "DELETE " + idHexStr + " " + keyHexStr + "\n"
+const deleteNamedMessage = "DELETE " + idHexStr + " " + keyHexStr + " NAME " + nameHexStr + "\n"
+const deleteMatchMessage = "DELETE " + idHexStr + " " + keyHexStr + " IFMATCH \"2\"\n"
+const deleteAdminMessage = "DELETE " + idHexStr + " -\n"
 const deleteSuccessResponse = ""
-const deleteFailMessage = "DELETE \n"
+const deleteFailMessage = "DELETE  " + keyHexStr + "\n"
 const deleteFailResponse = "ERROR Malformed request\n"
 
 // Test Variables
 var (
 	id     = []byte(idStr)
+	name   = []byte(nameStr)
 	record = []byte(recordStr)
+	labels = map[string]string{"env": "prod"}
+
+	created = time.UnixMilli(1700000000000)
+	updated = time.UnixMilli(1700000600000)
+
+	retrievedInfo = utils.RecordInfo{Version: 2, Created: created, Updated: updated, Size: 64,
+		ContentType: contentTypeStr, Labels: labels}
+
+	signer, _    = hex.DecodeString(signerHexStr)
+	signature, _ = hex.DecodeString(signatureHexStr)
+	signatures   = func() []byte {
+		s, _ := hex.DecodeString(signaturesHexStr)
+		return s
+	}()
 
 	key = func() []byte {
 		s, _ := hex.DecodeString(keyHexStr)
@@ -62,7 +117,7 @@ var (
 		conn: goodConn,
 	}
 
-	storeSuccessResponse = keyHexStr
+	storeSuccessResponse = keyHexStr + " 1 1700000000000 1700000000000 64 - - - -"
 )
 
 // Mock Connection
@@ -82,14 +137,61 @@ func (c *MockConn) GetResponse(message string) (response string, err error) {
 		assert.Equal(c.t, storeSuccessMessage, message)
 		return storeSuccessResponse, nil
 
+	case "StoreNamed":
+		assert.Equal(c.t, storeNamedMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreAttributes":
+		assert.Equal(c.t, storeAttributesMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreSigned":
+		assert.Equal(c.t, storeSignedMessage, message)
+		return storeSuccessResponse, nil
+
+	case "StoreOptions":
+		assert.Equal(c.t, storeOptionsMessage, message)
+		return storePreconditionResponse, nil
+
 	case "Retrieve":
 		if c.fail == "GetResponse" {
 			assert.Equal(c.t, retrieveFailMessage, message)
 			return retrieveFailResponse, nil
 		}
 		assert.Equal(c.t, retrieveSuccessMessage, message)
+		if c.fail == "RateLimit" {
+			return rateLimitedResponse, nil
+		}
+		return retrieveSuccessResponse, nil
+
+	case "RetrieveNamed":
+		assert.Equal(c.t, retrieveNamedMessage, message)
 		return retrieveSuccessResponse, nil
 
+	case "RetrieveSigned":
+		assert.Equal(c.t, retrieveSuccessMessage, message)
+		return retrieveSignedResponse, nil
+
+	case "RetrieveMalformed":
+		assert.Equal(c.t, retrieveSuccessMessage, message)
+		return retrieveMalformedResponse, nil
+
+	case "Stat":
+		if c.fail == "GetResponse" {
+			assert.Equal(c.t, statFailMessage, message)
+			return statFailResponse, nil
+		}
+		assert.Equal(c.t, statSuccessMessage, message)
+		return statSuccessResponse, nil
+
+	case "List":
+		if c.fail == "GetResponse" {
+			assert.Equal(c.t, listFailMessage, message)
+			return listFailResponse, nil
+		}
+		assert.Equal(c.t, listSuccessMessage, message)
+		return listSuccessResponse, nil
+
 	case "Delete":
 		if c.fail == "GetResponse" {
 			assert.Equal(c.t, deleteFailMessage, message)
@@ -97,6 +199,18 @@ func (c *MockConn) GetResponse(message string) (response string, err error) {
 		}
 		assert.Equal(c.t, deleteSuccessMessage, message)
 		return deleteSuccessResponse, nil
+
+	case "DeleteNamed":
+		assert.Equal(c.t, deleteNamedMessage, message)
+		return deleteSuccessResponse, nil
+
+	case "DeleteMatch":
+		assert.Equal(c.t, deleteMatchMessage, message)
+		return storePreconditionResponse, nil
+
+	case "DeleteAdmin":
+		assert.Equal(c.t, deleteAdminMessage, message)
+		return deleteSuccessResponse, nil
 	}
 
 	return "", nil
@@ -145,14 +259,17 @@ func TestClient_StoreRecord(t *testing.T) {
 	}
 	type args struct {
 		id     []byte
+		name   []byte
 		record []byte
+		opts   utils.StoreOptions
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []byte
-		wantErr error
+		name     string
+		fields   fields
+		args     args
+		want     []byte
+		wantInfo utils.RecordInfo
+		wantErr  error
 	}{
 		{
 			name: "should run successfully",
@@ -163,7 +280,63 @@ func TestClient_StoreRecord(t *testing.T) {
 				id:     id,
 				record: record,
 			},
-			want: key,
+			want:     key,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Updated: created, Size: 64},
+		},
+		{
+			name: "should run named store successfully",
+			fields: fields{
+				conn: &MockConn{t, "StoreNamed", ""},
+			},
+			args: args{
+				id:     id,
+				name:   name,
+				record: record,
+			},
+			want:     key,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Updated: created, Size: 64},
+		},
+		{
+			name: "should run store with attributes successfully",
+			fields: fields{
+				conn: &MockConn{t, "StoreAttributes", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts:   utils.StoreOptions{ContentType: contentTypeStr, Labels: labels},
+			},
+			want:     key,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Updated: created, Size: 64},
+		},
+		{
+			name: "should run signed store successfully",
+			fields: fields{
+				conn: &MockConn{t, "StoreSigned", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts:   utils.StoreOptions{ContentSignature: &utils.Signature{KeyID: signer, Signature: signature}},
+			},
+			want:     key,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Updated: created, Size: 64},
+		},
+		{
+			name: "should return a failed precondition",
+			fields: fields{
+				conn: &MockConn{t, "StoreOptions", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts: utils.StoreOptions{
+					OneTime:    true,
+					NoCompress: true,
+					Match:      utils.Precondition{IfNotExists: true},
+				},
+			},
+			wantErr: utils.ErrPreconditionFailed,
 		},
 		{
 			name: "should return an error",
@@ -176,6 +349,18 @@ func TestClient_StoreRecord(t *testing.T) {
 			},
 			wantErr: errors.New(storeFailResponse),
 		},
+		{
+			name: "should reject recipient records",
+			fields: fields{
+				conn: &MockConn{t, "Store", ""},
+			},
+			args: args{
+				id:     id,
+				record: record,
+				opts:   utils.StoreOptions{Recipients: [][]byte{key}},
+			},
+			wantErr: ErrRecipientsUnsupported,
+		},
 	}
 
 	for _, test := range tests {
@@ -184,8 +369,9 @@ func TestClient_StoreRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			got, err := c.StoreRecord(test.args.id, test.args.record)
+			got, info, err := c.StoreRecord(test.args.id, test.args.name, test.args.record, test.args.opts)
 			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantInfo, info)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -198,15 +384,17 @@ func TestClient_RetrieveRecord(t *testing.T) {
 		conn utils.Conn
 	}
 	type args struct {
-		id  []byte
-		key []byte
+		id   []byte
+		name []byte
+		key  []byte
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []byte
-		wantErr error
+		name     string
+		fields   fields
+		args     args
+		want     []byte
+		wantInfo utils.RecordInfo
+		wantErr  error
 	}{
 		{
 			name: "should run successfully",
@@ -217,7 +405,56 @@ func TestClient_RetrieveRecord(t *testing.T) {
 				id:  id,
 				key: key,
 			},
+			want:     record,
+			wantInfo: retrievedInfo,
+		},
+		{
+			name: "should run named retrieve successfully",
+			fields: fields{
+				conn: &MockConn{t, "RetrieveNamed", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+				key:  key,
+			},
+			want:     record,
+			wantInfo: retrievedInfo,
+		},
+		{
+			name: "should report the signer of a signed record",
+			fields: fields{
+				conn: &MockConn{t, "RetrieveSigned", ""},
+			},
+			args: args{
+				id:  id,
+				key: key,
+			},
 			want: record,
+			wantInfo: utils.RecordInfo{Version: 2, Created: created, Updated: updated, Size: 64,
+				Signatures: signatures, Signer: signer},
+		},
+		{
+			name: "should fail on malformed response",
+			fields: fields{
+				conn: &MockConn{t, "RetrieveMalformed", ""},
+			},
+			args: args{
+				id:  id,
+				key: key,
+			},
+			wantErr: errors.New("Malformed response"),
+		},
+		{
+			name: "should return a rate limit",
+			fields: fields{
+				conn: &MockConn{t, "Retrieve", "RateLimit"},
+			},
+			args: args{
+				id:  id,
+				key: key,
+			},
+			wantErr: utils.ErrRateLimited,
 		},
 		{
 			name: "should return an error",
@@ -238,8 +475,185 @@ func TestClient_RetrieveRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			got, err := c.RetrieveRecord(test.args.id, test.args.key)
+			got, info, err := c.RetrieveRecord(test.args.id, test.args.name, test.args.key)
 			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantInfo, info)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// RetrieveRecordFor() - Test Method
+func TestClient_RetrieveRecordFor(t *testing.T) {
+	c := clientImpl{
+		conn: &MockConn{t, "Retrieve", ""},
+	}
+
+	// Recipient records are not carried by the socket protocol.
+	got, info, err := c.RetrieveRecordFor(id, nil, key)
+	assert.Nil(t, got)
+	assert.Equal(t, utils.RecordInfo{}, info)
+	assert.Equal(t, ErrRecipientsUnsupported, err)
+}
+
+// StoreRecordShares() - Test Method
+func TestClient_StoreRecordShares(t *testing.T) {
+	c := clientImpl{
+		conn: &MockConn{t, "Store", ""},
+	}
+
+	// Split record keys are not carried by the socket protocol.
+	shares, info, err := c.StoreRecordShares(id, nil, record, 3, 2, utils.StoreOptions{})
+	assert.Nil(t, shares)
+	assert.Equal(t, utils.RecordInfo{}, info)
+	assert.Equal(t, ErrSharesUnsupported, err)
+}
+
+// RetrieveRecordShares() - Test Method
+func TestClient_RetrieveRecordShares(t *testing.T) {
+	c := clientImpl{
+		conn: &MockConn{t, "Retrieve", ""},
+	}
+
+	// Split record keys are not carried by the socket protocol.
+	got, info, err := c.RetrieveRecordShares(id, nil, [][]byte{key, key})
+	assert.Nil(t, got)
+	assert.Equal(t, utils.RecordInfo{}, info)
+	assert.Equal(t, ErrSharesUnsupported, err)
+}
+
+// AddRecipients() - Test Method
+func TestClient_AddRecipients(t *testing.T) {
+	c := clientImpl{
+		conn: &MockConn{t, "Retrieve", ""},
+	}
+
+	// Key slots are not managed through the socket protocol.
+	slots, err := c.AddRecipients(id, nil, key, nil, [][]byte{key})
+	assert.Nil(t, slots)
+	assert.Equal(t, ErrRecipientsUnsupported, err)
+}
+
+// RevokeRecipient() - Test Method
+func TestClient_RevokeRecipient(t *testing.T) {
+	c := clientImpl{
+		conn: &MockConn{t, "Retrieve", ""},
+	}
+
+	// Key slots are not managed through the socket protocol.
+	err := c.RevokeRecipient(id, nil, key, id)
+	assert.Equal(t, ErrRecipientsUnsupported, err)
+}
+
+// StatRecord() - Test Method
+func TestClient_StatRecord(t *testing.T) {
+
+	type fields struct {
+		conn utils.Conn
+	}
+	type args struct {
+		id   []byte
+		name []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    utils.RecordInfo
+		wantErr error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				conn: &MockConn{t, "Stat", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+			},
+			want: retrievedInfo,
+		},
+		{
+			name: "should return an error",
+			fields: fields{
+				conn: &MockConn{t, "Stat", "GetResponse"},
+			},
+			args: args{
+				id: []byte(""),
+			},
+			wantErr: errors.New(statFailResponse),
+		},
+	}
+
+	for _, test := range tests {
+		c := clientImpl{
+			conn: test.fields.conn,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.StatRecord(test.args.id, test.args.name)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// ListRecords() - Test Method
+func TestClient_ListRecords(t *testing.T) {
+
+	type fields struct {
+		conn utils.Conn
+	}
+	type args struct {
+		id   []byte
+		opts utils.ListOptions
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		want     []utils.RecordSummary
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				conn: &MockConn{t, "List", ""},
+			},
+			args: args{
+				id:   id,
+				opts: utils.ListOptions{Cursor: "abc", Limit: 2},
+			},
+			want: []utils.RecordSummary{{
+				Name:        name,
+				Size:        64,
+				ContentType: contentTypeStr,
+				Created:     created,
+				Updated:     updated,
+			}},
+		},
+		{
+			name: "should return an error",
+			fields: fields{
+				conn: &MockConn{t, "List", "GetResponse"},
+			},
+			args: args{
+				id: []byte(""),
+			},
+			wantErr: errors.New(listFailResponse),
+		},
+	}
+
+	for _, test := range tests {
+		c := clientImpl{
+			conn: test.fields.conn,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, next, err := c.ListRecords(test.args.id, test.args.opts)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantNext, next)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -252,7 +666,10 @@ func TestClient_DeleteRecord(t *testing.T) {
 		conn utils.Conn
 	}
 	type args struct {
-		id []byte
+		id   []byte
+		name []byte
+		key  []byte
+		opts utils.DeleteOptions
 	}
 	tests := []struct {
 		name    string
@@ -265,17 +682,51 @@ func TestClient_DeleteRecord(t *testing.T) {
 			fields: fields{
 				conn: &MockConn{t, "Delete", ""},
 			},
+			args: args{
+				id:  id,
+				key: key,
+			},
+		},
+		{
+			name: "should run named delete successfully",
+			fields: fields{
+				conn: &MockConn{t, "DeleteNamed", ""},
+			},
+			args: args{
+				id:   id,
+				name: name,
+				key:  key,
+			},
+		},
+		{
+			name: "should run admin delete without key successfully",
+			fields: fields{
+				conn: &MockConn{t, "DeleteAdmin", ""},
+			},
 			args: args{
 				id: id,
 			},
 		},
+		{
+			name: "should return a failed precondition",
+			fields: fields{
+				conn: &MockConn{t, "DeleteMatch", ""},
+			},
+			args: args{
+				id:   id,
+				key:  key,
+				opts: utils.DeleteOptions{Match: utils.Precondition{IfMatch: 2}},
+			},
+			wantErr: utils.ErrPreconditionFailed,
+		},
 		{
 			name: "should return an error",
 			fields: fields{
 				conn: &MockConn{t, "Delete", "GetResponse"},
 			},
 			args: args{
-				id: []byte(""),
+				id:  []byte(""),
+				key: key,
 			},
 			wantErr: errors.New(deleteFailResponse),
 		},
@@ -287,7 +738,7 @@ func TestClient_DeleteRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			err := c.DeleteRecord(test.args.id)
+			err := c.DeleteRecord(test.args.id, test.args.name, test.args.key, test.args.opts)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -2,10 +2,16 @@
 package server
 
 import (
+	"context"
 	"crypto/cipher"
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 
 	"enc-server-go/pkg/utils"
@@ -16,14 +22,50 @@ import (
 type serverImpl struct {
 	keygen utils.KeyGen
 
-	idNonce  []byte
-	idCipher cipher.AEAD
+	idNonce    []byte
+	idCipher   cipher.AEAD
+	nameKey    []byte
+	metaCipher cipher.AEAD
+
+	compressor utils.Compressor
+	padder     utils.Padder
+	signer     utils.Signer
+	auth       utils.Authenticator
+	limiter    utils.RateLimiter
+	auditor    utils.Auditor
 
 	beClient utils.ClientBE
 
 	socketIO *utils.SocketIO
 }
 
+// Client session, holding the IP address of the client and the API key it
+// authenticated with.
+type session struct {
+	server   *serverImpl
+	clientIP string
+	apiKey   string
+}
+
+// Scopes an API key must hold for each request.
+var requestScopes = map[string]string{
+	"STORE":    utils.ScopeStore,
+	"RETRIEVE": utils.ScopeRetrieve,
+	"STAT":     utils.ScopeRetrieve,
+	"LIST":     utils.ScopeRetrieve,
+	"DELETE":   utils.ScopeDelete,
+}
+
+// Audited operation of each request on a record. Requests reading records,
+// their attributes or a user's record list are audited as retrieves.
+var requestAudits = map[string]string{
+	"STORE":    utils.AuditStore,
+	"RETRIEVE": utils.AuditRetrieve,
+	"STAT":     utils.AuditRetrieve,
+	"LIST":     utils.AuditRetrieve,
+	"DELETE":   utils.AuditDelete,
+}
+
 func decodeHexArray(arr []string) (result [][]byte, err error) {
 
 	// Decode hex strings to byte arrays.
@@ -39,71 +81,379 @@ func decodeHexArray(arr []string) (result [][]byte, err error) {
 	return result, nil
 }
 
-func (s *serverImpl) storeRecord(id, record []byte) (key []byte, err error) {
+func parseOptions(fields []string, allowed []string) (name []byte, opts utils.StoreOptions, err error) {
+
+	// Flags stand alone, names and preconditions are name and value pairs.
+	var ifMatch, ifNoneMatch string
+	var signer, signature []byte
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case !slices.Contains(allowed, fields[i]):
+			return nil, opts, errors.New("Malformed request")
+		case fields[i] == "NAME" && i+1 < len(fields):
+			i++
+			if name, err = hex.DecodeString(fields[i]); err != nil {
+				return nil, opts, err
+			}
+		case fields[i] == "ONETIME":
+			opts.OneTime = true
+		case fields[i] == "NOCOMPRESS":
+			opts.NoCompress = true
+		case fields[i] == "IFMATCH" && i+1 < len(fields):
+			i++
+			ifMatch = fields[i]
+		case fields[i] == "IFNONEMATCH" && i+1 < len(fields):
+			i++
+			ifNoneMatch = fields[i]
+		case fields[i] == "TYPE" && i+1 < len(fields):
+			i++
+			var contentType []byte
+			if contentType, err = hex.DecodeString(fields[i]); err != nil {
+				return nil, opts, err
+			}
+			opts.ContentType = string(contentType)
+		case fields[i] == "LABELS" && i+1 < len(fields):
+			i++
+			var labels []byte
+			if labels, err = hex.DecodeString(fields[i]); err != nil {
+				return nil, opts, err
+			}
+			if err = json.Unmarshal(labels, &opts.Labels); err != nil {
+				return nil, opts, err
+			}
+		case fields[i] == "SIGNER" && i+1 < len(fields):
+			i++
+			if signer, err = hex.DecodeString(fields[i]); err != nil {
+				return nil, opts, err
+			}
+		case fields[i] == "SIGNATURE" && i+1 < len(fields):
+			i++
+			if signature, err = hex.DecodeString(fields[i]); err != nil {
+				return nil, opts, err
+			}
+		default:
+			return nil, opts, errors.New("Malformed request")
+		}
+	}
 
-	// Generate cipher entry for ID.
+	// Content signatures name the key ID of their signer.
+	if (signer == nil) != (signature == nil) {
+		return nil, opts, errors.New("Malformed request")
+	} else if signer != nil {
+		opts.ContentSignature = &utils.Signature{KeyID: signer, Signature: signature}
+	}
+
+	opts.Match, err = utils.ParsePrecondition(ifMatch, ifNoneMatch)
+	return name, opts, err
+}
+
+func parseListOptions(fields []string) (opts utils.ListOptions, err error) {
+
+	// List options are name and value pairs.
+	if len(fields)%2 != 0 {
+		return opts, errors.New("Malformed request")
+	}
+	for i := 0; i < len(fields); i += 2 {
+		switch fields[i] {
+		case "CURSOR":
+			opts.Cursor = fields[i+1]
+		case "LIMIT":
+			if opts.Limit, err = strconv.ParseInt(fields[i+1], 10, 64); err != nil {
+				return opts, err
+			}
+		default:
+			return opts, errors.New("Malformed request")
+		}
+	}
+
+	return opts, nil
+}
+
+func (s *serverImpl) storeRecord(ctx context.Context, id, name, record []byte,
+	opts utils.StoreOptions) (key []byte, info utils.RecordInfo, err error) {
+
+	// Generate cipher entries for ID and name.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Content signatures are verified over the record contents as stored.
+	if opts.ContentSignature != nil {
+		digest := sha256.Sum256(record)
+		if err = s.signer.VerifyContent(id, name, digest[:], opts.ContentSignature); err != nil {
+			return nil, info, err
+		}
+	}
 
 	// Generate random AES key.
 	if key, err = s.keygen.RandomKey(); err != nil {
-		return nil, err
+		return nil, info, err
 	}
 
 	// Generate key, cipher, and nonce for record.
 	cipher, err := s.keygen.GetGCMCipher(key)
 	if err != nil {
-		return nil, err
+		return nil, info, err
 	}
 
 	// Randomly generate nonce (initialization vector).
 	nonce, err := s.keygen.RandomNonce(cipher.NonceSize())
 	if err != nil {
-		return nil, err
+		return nil, info, err
+	}
+
+	// One-time records carry a commitment to their key.
+	if opts.OneTime {
+		opts.KeyHash = utils.KeyHash(key)
+	}
+
+	// Record attributes report the uncompressed size. The key commitment
+	// authorizes later changes to the record recipients.
+	meta := utils.RecordMetadata{
+		ContentType: opts.ContentType,
+		Size:        int64(len(record)),
+		Labels:      opts.Labels,
+		KeyHash:     utils.KeyHash(key),
+	}
+
+	// Compress record contents before sealing unless opted out.
+	if codec := s.compressor.Codec(); codec != "" && !opts.NoCompress {
+		if record, err = utils.Compress(s.compressor, record); err != nil {
+			return nil, info, err
+		}
+		meta.Compression = codec
+	}
+
+	// Pad record contents to hide their length when configured.
+	if scheme := s.padder.Scheme(); scheme != "" {
+		meta.Padding, meta.Unpadded = scheme, int64(len(record))
+		record = utils.Pad(s.padder, record)
 	}
 
-	// Generate cipher entry for record. Place in data store.
+	// Generate cipher entry for record. Signed records carry its digest.
 	recordEncrypt := cipher.Seal(nonce, nonce, record, nil)
-	if err = s.beClient.StoreRecord(idEncrypt, recordEncrypt); err != nil {
-		return nil, err
+	if s.signer.Signing() {
+		digest := sha256.Sum256(recordEncrypt)
+		meta.Digest = digest[:]
+	}
+
+	// Seal record attributes under the ID cipher with a fresh nonce, and
+	// sign them with any content signature.
+	metaNonce, err := s.keygen.RandomNonce(s.idCipher.NonceSize())
+	if err != nil {
+		return nil, info, err
+	}
+	if opts.Metadata, err = utils.SealMetadata(s.metaCipher, metaNonce, id, name, meta); err != nil {
+		return nil, info, err
+	}
+	if opts.Signatures, err = s.signer.SignEnvelope(idEncrypt, nameEncrypt, opts.Metadata, opts.ContentSignature); err != nil {
+		return nil, info, err
 	}
 
-	return key, nil
+	// Place record in data store.
+	if info, err = s.beClient.WithContext(ctx).StoreRecord(idEncrypt, nameEncrypt, recordEncrypt, opts); err != nil {
+		return nil, info, err
+	}
+
+	info.Size, info.ContentType, info.Labels = meta.Size, meta.ContentType, meta.Labels
+	return key, info, nil
 }
 
-func (s *serverImpl) retrieveRecord(id, key []byte) (record []byte, err error) {
+func (s *serverImpl) retrieveRecord(ctx context.Context, id, name, key []byte) (record []byte, info utils.RecordInfo, err error) {
 
-	// Generate fixed cipher entry for ID for lookup.
+	// Generate fixed cipher entries for ID and name for lookup.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
 
 	// Generate cipher for record.
 	cipher, err := s.keygen.GetGCMCipher(key)
 	if err != nil {
-		return nil, err
+		return nil, info, err
 	}
 
-	// Retrieve record from data store.
-	recordEncrypt, err := s.beClient.RetrieveRecord(idEncrypt)
+	// Retrieve record from data store. One-time records are consumed
+	// against the record key, which members unwrap from their key slot.
+	beClient := s.beClient.WithContext(ctx)
+	opts := utils.RetrieveOptions{KeyHash: utils.KeyHash(key)}
+	recordEncrypt, info, err := beClient.RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if errors.Is(err, utils.ErrKeyMismatch) {
+		if recordKey, memberErr := s.memberKey(ctx, id, name, key); memberErr == nil {
+			opts.KeyHash = utils.KeyHash(recordKey)
+			recordEncrypt, info, err = beClient.RetrieveRecord(idEncrypt, nameEncrypt, opts)
+		}
+	}
 	if err != nil {
-		return nil, err
+		return nil, info, err
+	}
+
+	// Verify record signatures, then decrypt record attributes and verify
+	// the digest of the cipher entry they carry.
+	content, err := s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures)
+	if err != nil {
+		return nil, utils.RecordInfo{}, err
+	}
+	if info, err = utils.OpenRecordInfo(s.metaCipher, id, name, info); err != nil {
+		return nil, info, err
+	}
+	if info.Digest != nil {
+		if err = utils.VerifyDigest(recordEncrypt, info.Digest); err != nil {
+			return nil, utils.RecordInfo{}, err
+		}
+	}
+
+	// Member keys unwrap the record key from their key slot.
+	if recordKey, err := utils.MemberKey(info.KeySlots, key); err == nil {
+		if cipher, err = s.keygen.GetGCMCipher(recordKey); err != nil {
+			return nil, info, err
+		}
+	}
+
+	// Decrypt record from cipher entry, reporting the size of the decrypted record.
+	if info.Chunked {
+		record, err = utils.OpenStream(cipher, recordEncrypt)
+	} else if len(recordEncrypt) < cipher.NonceSize() {
+		err = errors.New("Sealed record too short")
+	} else {
+
+		// Cipher entries failing authentication were sealed under another key.
+		nonce := recordEncrypt[:cipher.NonceSize()]
+		remainder := recordEncrypt[cipher.NonceSize():]
+		if record, err = cipher.Open(nil, nonce, remainder, nil); err != nil {
+			err = utils.ErrKeyMismatch
+		}
+	}
+	if err != nil {
+		return nil, info, err
+	}
+
+	// Strip padding, then decompress record contents compressed before sealing.
+	if info.Padding != "" {
+		if record, err = utils.Unpad(record, info.Unpadded); err != nil {
+			return nil, info, err
+		}
 	}
+	if record, err = utils.Decompress(s.compressor, info.Compression, info.Size, record); err != nil {
+		return nil, info, err
+	}
+	info.Size = int64(len(record))
 
-	// Decrypt record from cipher entry.
-	nonce := recordEncrypt[:cipher.NonceSize()]
-	remainder := recordEncrypt[cipher.NonceSize():]
-	if record, err = cipher.Open(nil, nonce, remainder, nil); err != nil {
+	// Verify any content signature over the record contents.
+	if content != nil {
+		digest := sha256.Sum256(record)
+		if err = s.signer.VerifyContent(id, name, digest[:], content); err != nil {
+			return nil, utils.RecordInfo{}, err
+		}
+		info.Signer = content.KeyID
+	}
+
+	return record, info, err
+}
+
+func (s *serverImpl) memberKey(ctx context.Context, id, name, member []byte) (key []byte, err error) {
+
+	// Record attributes are read without consuming one-time records.
+	info, err := s.statRecord(ctx, id, name)
+	if err != nil {
 		return nil, err
 	}
+	return utils.MemberKey(info.KeySlots, member)
+}
 
-	return record, err
+func (s *serverImpl) statRecord(ctx context.Context, id, name []byte) (info utils.RecordInfo, err error) {
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Retrieve record attributes from data store.
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	if _, info, err = s.beClient.WithContext(ctx).RetrieveRecord(idEncrypt, nameEncrypt, opts); err != nil {
+		return info, err
+	}
+
+	// Verify record signatures, then decrypt record attributes.
+	if _, err = s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures); err != nil {
+		return utils.RecordInfo{}, err
+	}
+	return utils.OpenRecordInfo(s.metaCipher, id, name, info)
 }
 
-func (s *serverImpl) deleteRecord(id []byte) (err error) {
+func (s *serverImpl) listRecords(ctx context.Context, id []byte,
+	opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
 
 	// Generate fixed cipher entry for ID for lookup.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
 
+	// List records from data store.
+	if records, next, err = s.beClient.WithContext(ctx).ListRecords(idEncrypt, opts); err != nil {
+		return nil, "", err
+	}
+
+	// Decrypt record names.
+	if err = utils.OpenRecordList(s.idCipher, s.metaCipher, id, records); err != nil {
+		return nil, "", err
+	}
+
+	return records, next, nil
+}
+
+func (s *serverImpl) proveKey(ctx context.Context, id, name, presented []byte) (version int64, err error) {
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Record attributes are read without consuming one-time records.
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	_, info, err := s.beClient.WithContext(ctx).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures); err != nil {
+		return 0, err
+	}
+	meta, err := utils.OpenMetadata(s.metaCipher, id, name, info.Metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	// Only the record key is verified, against its sealed commitment, so
+	// member keys cannot delete. Records stored before commitments were
+	// sealed prove the key by opening their cipher entry, which member keys
+	// also open, so keys holding a key slot are refused first.
+	err = utils.AuthorizeOwner(meta, presented)
+	_, memberErr := utils.MemberKey(meta.KeySlots, presented)
+	if errors.Is(err, utils.ErrKeyMismatch) && len(meta.KeyHash) == 0 && errors.Is(memberErr, utils.ErrNoKeySlot) {
+		if _, _, err = s.retrieveRecord(ctx, id, name, presented); err != nil {
+			err = utils.ErrKeyMismatch
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Version, nil
+}
+
+func (s *serverImpl) deleteRecord(ctx context.Context, id, name, key []byte, opts utils.DeleteOptions) (err error) {
+
+	// Prove ownership of the record unless deleted by an admin. Unless
+	// conditioned otherwise, the delete only applies to the revision the key
+	// was proven against.
+	if key != nil {
+		version, err := s.proveKey(ctx, id, name, key)
+		if err != nil {
+			return err
+		}
+		if opts.Match == (utils.Precondition{}) {
+			opts.Match.IfMatch = version
+		}
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
 	// Delete record from data store.
-	err = s.beClient.DeleteRecord(idEncrypt)
+	err = s.beClient.WithContext(ctx).DeleteRecord(idEncrypt, nameEncrypt, opts)
 	if err != nil {
 		return err
 	}
@@ -111,73 +461,304 @@ func (s *serverImpl) deleteRecord(id []byte) (err error) {
 	return nil
 }
 
+func (ss *session) Respond(message string) (response []byte) {
+	return ss.RespondContext(context.Background(), message)
+}
+
+func (ss *session) RespondContext(ctx context.Context, message string) (response []byte) {
+
+	// Split message.
+	fields := strings.Split(strings.TrimRight(message, " \n"), " ")
+	if fields[0] != "AUTH" {
+		return ss.server.respond(ctx, message, ss.apiKey, ss.clientIP)
+	}
+
+	// Authenticate the session, without logging the API key. Attempts are
+	// rate limited by client IP.
+	if response = ss.server.rateLimit(ctx, "AUTH", "ip:"+ss.clientIP); response != nil {
+		return response
+	}
+	if len(fields) != 2 {
+		response = []byte("ERROR Malformed request\n")
+		return response
+	}
+	client, err := ss.server.auth.Authenticate(fields[1])
+	if err != nil {
+		response = []byte("ERROR " + err.Error() + "\n")
+		return response
+	}
+	slog.InfoContext(ctx, "FE server authenticated a session", "client", client)
+	ss.apiKey = fields[1]
+
+	response = []byte("\n")
+	return response
+}
+
+func (s *serverImpl) admin(apiKey string) bool {
+
+	// Admins present an API key holding the admin scope, so there are none
+	// when authentication is off.
+	if !s.auth.Enabled() {
+		return false
+	}
+	_, err := s.auth.Authorize(apiKey, utils.ScopeAdmin)
+	return err == nil
+}
+
+func (s *serverImpl) rateLimit(ctx context.Context, verb string, keys ...string) (response []byte) {
+
+	// Rate limited requests are refused with a distinct error code, carrying
+	// the seconds to wait before retrying.
+	retryAfter, err := s.limiter.Allow(verb, keys...)
+	if err != nil {
+		slog.WarnContext(ctx, "FE server rateLimit error", "error", err)
+		response = []byte("ERROR " + utils.RateLimitedCode + " " +
+			strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))) + "\n")
+		return response
+	}
+	return nil
+}
+
+func (s *serverImpl) audit(ctx context.Context, operation, client string, fields []string, response []byte) {
+	if !s.auditor.Enabled() {
+		return
+	}
+
+	// Records are identified by the ID and any NAME option of the request.
+	event := utils.AuditEvent{
+		Operation: operation,
+		Principal: client,
+		Outcome:   utils.AuditOK,
+		RequestID: utils.RequestIDFromContext(ctx),
+	}
+	var id, name []byte
+	var err error
+	if len(fields) > 1 {
+		id, err = hex.DecodeString(fields[1])
+	}
+	if i := slices.Index(fields, "NAME"); err == nil && i > 0 && i+1 < len(fields) {
+		name, err = hex.DecodeString(fields[i+1])
+	}
+	if err == nil && len(id) > 0 {
+		event.RecordID = utils.AuditRecordID(s.idCipher.Seal(s.idNonce, s.idNonce, id, nil),
+			utils.SealName(s.idCipher, s.nameKey, id, name))
+	}
+	if errMsg, failed := strings.CutPrefix(strings.TrimRight(string(response), "\n"), "ERROR "); failed {
+		event.Outcome = errMsg
+	}
+
+	if err = s.auditor.Record(event); err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "FE server audit error", "error", err)
+	}
+}
+
+func (s *serverImpl) Session(clientIP string) (responder utils.Responder) {
+	return &session{server: s, clientIP: clientIP}
+}
+
 func (s *serverImpl) Respond(message string) (response []byte) {
+	return s.RespondContext(context.Background(), message)
+}
+
+func (s *serverImpl) RespondContext(ctx context.Context, message string) (response []byte) {
+	return s.respond(ctx, message, "", "")
+}
+
+func (s *serverImpl) respond(ctx context.Context, message, apiKey, clientIP string) (response []byte) {
 
 	message = strings.TrimRight(message, " \n")
-	log.Println(message)
 
-	// Split message.
+	// Split message, logging the request by its verb and the ID of record
+	// requests only, as other fields carry keys and record contents.
 	fields := strings.Split(message, " ")
+	if _, ok := requestScopes[fields[0]]; ok && len(fields) > 1 {
+		slog.InfoContext(ctx, "FE server received a request", "verb", fields[0], "id", fields[1])
+	} else {
+		slog.InfoContext(ctx, "FE server received a request", "verb", fields[0])
+	}
+
+	// Rate limit requests by client IP, and once authorized by a hash of the
+	// session API key and the user ID too.
+	if _, ok := requestScopes[fields[0]]; ok {
+		if response = s.rateLimit(ctx, fields[0], "ip:"+clientIP); response != nil {
+			return response
+		}
+	}
+
+	// Authorize request against the session API key, auditing record
+	// operations once responded to.
+	var client string
+	if operation, ok := requestAudits[fields[0]]; ok {
+		defer func() { s.audit(ctx, operation, client, fields, response) }()
+	}
+	if scope, ok := requestScopes[fields[0]]; ok {
+		var err error
+		if client, err = s.auth.Authorize(apiKey, scope); err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		var keys []string
+		if apiKey != "" && s.auth.Enabled() {
+			keys = append(keys, "key:"+utils.CredentialHash(apiKey))
+		}
+		if len(fields) > 1 {
+			keys = append(keys, "id:"+fields[1])
+		}
+		if response = s.rateLimit(ctx, fields[0], keys...); response != nil {
+			return response
+		}
+	}
 
 	// Compose response.
 	switch fields[0] {
 	case "STORE":
 		const expectedFields = 3
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
 
-		decodedBytes, err := decodeHexArray(fields[1:])
+		// Parse store options.
+		name, opts, err := parseOptions(fields[expectedFields:],
+			[]string{"NAME", "ONETIME", "IFMATCH", "IFNONEMATCH", "TYPE", "LABELS", "NOCOMPRESS", "SIGNER", "SIGNATURE"})
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		decodedBytes, err := decodeHexArray(fields[1:expectedFields])
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
 		id, record := decodedBytes[0], decodedBytes[1]
 
-		key, err := s.storeRecord(id, record)
+		key, info, err := s.storeRecord(ctx, id, name, record, opts)
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
-		response = []byte(hex.EncodeToString(key) + "\n")
+		response = []byte(hex.EncodeToString(key) + " " + utils.FormatRecordInfo(info) + "\n")
 
 	case "RETRIEVE":
 		const expectedFields = 3
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
 
-		decodedBytes, err := decodeHexArray(fields[1:])
+		// Parse retrieve options, which only carry the record name.
+		name, _, err := parseOptions(fields[expectedFields:], []string{"NAME"})
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		decodedBytes, err := decodeHexArray(fields[1:expectedFields])
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
 		id, key := decodedBytes[0], decodedBytes[1]
 
-		record, err := s.retrieveRecord(id, key)
+		record, info, err := s.retrieveRecord(ctx, id, name, key)
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
-		response = []byte(hex.EncodeToString(record) + "\n")
+		response = []byte(hex.EncodeToString(record) + " " + utils.FormatRecordInfo(info) + "\n")
 
-	case "DELETE":
+	case "STAT":
 		const expectedFields = 2
-		if len(fields) != expectedFields {
+		if len(fields) < expectedFields {
 			response = []byte("ERROR Malformed request\n")
 			return response
 		}
 
-		decodedBytes, err := decodeHexArray(fields[1:])
+		// Parse stat options, which only carry the record name.
+		name, _, err := parseOptions(fields[expectedFields:], []string{"NAME"})
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		decodedBytes, err := decodeHexArray(fields[1:expectedFields])
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
 		}
 		id := decodedBytes[0]
 
-		err = s.deleteRecord(id)
+		info, err := s.statRecord(ctx, id, name)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte(utils.FormatRecordInfo(info) + "\n")
+
+	case "LIST":
+		const expectedFields = 2
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+
+		// Parse list options.
+		opts, err := parseListOptions(fields[expectedFields:])
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		decodedBytes, err := decodeHexArray(fields[1:expectedFields])
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		id := decodedBytes[0]
+
+		records, next, err := s.listRecords(ctx, id, opts)
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		response = []byte(utils.FormatRecordList(records, next) + "\n")
+
+	case "DELETE":
+		const expectedFields = 3
+		if len(fields) < expectedFields {
+			response = []byte("ERROR Malformed request\n")
+			return response
+		}
+
+		// Parse delete options, which only carry the name and preconditions.
+		name, opts, err := parseOptions(fields[expectedFields:], []string{"NAME", "IFMATCH", "IFNONEMATCH"})
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+
+		// Admins may omit the record key as "-".
+		if fields[2] == "-" && !s.admin(apiKey) {
+			response = []byte("ERROR key not defined\n")
+			return response
+		}
+		decodedBytes, err := decodeHexArray(fields[1:2])
+		if err != nil {
+			response = []byte("ERROR " + err.Error() + "\n")
+			return response
+		}
+		id := decodedBytes[0]
+
+		var key []byte
+		if fields[2] != "-" {
+			if key, err = hex.DecodeString(fields[2]); err != nil {
+				response = []byte("ERROR " + err.Error() + "\n")
+				return response
+			}
+		}
+
+		err = s.deleteRecord(ctx, id, name, key, utils.DeleteOptions{Match: opts.Match})
 		if err != nil {
 			response = []byte("ERROR " + err.Error() + "\n")
 			return response
@@ -221,6 +802,37 @@ func MakeServer(configs map[string]string,
 		return nil, err
 	}
 
+	// Record names are sealed with nonces keyed apart from the ID cipher.
+	nameKey, err := utils.NameKey([]byte(configs["idKeyStr"]))
+	if err != nil {
+		return nil, err
+	}
+
+	// Record metadata is sealed under a key derived apart from the ID cipher.
+	metaKey, err := utils.MetadataKey([]byte(configs["idKeyStr"]))
+	if err != nil {
+		return nil, err
+	}
+	metaCipher, err := keygen.GetGCMCipher(metaKey)
+	if err != nil {
+		return nil, err
+	}
+
+	compressor, err := utils.MakeCompressor(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	padder, err := utils.MakePadder(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := utils.MakeSigner(configs)
+	if err != nil {
+		return nil, err
+	}
+
 	beClient, err := client.MakeClient(beClientConfigs)
 	if err != nil {
 		return nil, err
@@ -230,12 +842,33 @@ func MakeServer(configs map[string]string,
 	si := &serverImpl{
 		keygen: keygen,
 
-		idNonce:  []byte(configs["idNonceStr"]),
-		idCipher: idCipher,
+		idNonce:    []byte(configs["idNonceStr"]),
+		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+
+		compressor: compressor,
+		padder:     padder,
+		signer:     signer,
 
 		beClient: beClient,
 	}
 
+	// API keys held in a record are read through this server.
+	loadRecord := func(id, key []byte) ([]byte, error) {
+		record, _, err := si.retrieveRecord(context.Background(), id, nil, key)
+		return record, err
+	}
+	if si.auth, err = utils.MakeAuthenticator(configs, loadRecord); err != nil {
+		return nil, err
+	}
+	if si.limiter, err = utils.MakeRateLimiter(configs); err != nil {
+		return nil, err
+	}
+	if si.auditor, err = utils.MakeAuditor(configs, beClient); err != nil {
+		return nil, err
+	}
+
 	// Create socket IO with reference to response function.
 	if si.socketIO, err = utils.MakeSocketIO(configs, si); err != nil {
 		return nil, err
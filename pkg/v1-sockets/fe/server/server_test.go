@@ -1,12 +1,20 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/maps"
@@ -18,17 +26,37 @@ import (
 // Test Constants
 const idKeyStr = "vkAZAarLbZ6w0kmL2HJP3eU1ODCgVj4k"
 const idNonceStr = "9bc423909ac5"
+const memberKeyStr = "mQ3kT8vZ2pL6nR1wX9cY4bH7jF5sD0aE"
 const keySizeStr = "32"
 const port = "7777"
 
 const serverAddr = "enc-server-go-be:8888"
+const clientIP = "192.0.2.1"
 
 const badKeySizeStr = "fff"
+const badCompressionStr = "lz4"
+const badPaddingStr = "random"
+const badSigningKeyStr = "fff"
+const badAPIKeysRecordStr = "zz"
+const badRateLimitStr = "fast"
+
+const storeAPIKey = "store-api-key"
+const adminAPIKey = "admin-api-key"
 
 const idStr = "JTH"
 const idHexStr = "4a5448"
 const idHexEncStr = "396263343233393039616335acc30dd405c51d37675d4e0002a526ae113d56"
 
+const nameStr = "notes"
+const nameHexStr = "6e6f746573"
+
+const contentTypeStr = "text/plain"
+const contentTypeHexStr = "746578742f706c61696e"
+const labelsHexStr = "7b22656e76223a2270726f64227d"
+
+const signingKeyHexStr = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+const clientSeedStr = "4ccd089b28ff96da9db6c346ec114e0f"
+
 const recordStr = "PAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADS"
 const recordHexStr = "5041594c4f4144535041594c4f4144535041594c4f414453504159" +
 	"4c4f4144535041594c4f4144535041594c4f4144535041594c4f4144535041594c4f414453"
@@ -39,6 +67,7 @@ const recordHexEncStr = "396263343233393039616335b6d61c6839a0dda2524d19b4e5d" +
 // Test Variables
 var (
 	id     = []byte(idStr)
+	name   = []byte(nameStr)
 	record = []byte(recordStr)
 
 	idEnc, _ = hex.DecodeString(idHexEncStr)
@@ -50,9 +79,80 @@ var (
 
 	idKeyHexStr = hex.EncodeToString(idKey)
 
+	// Member key and the test key wrapped in its key slot.
+	memberKey      = []byte(memberKeyStr)
+	memberSlot, _  = utils.WrapMemberKey(memberKey, idKey)
+	otherMemberKey = idKey[:16]
+
 	keygen, _ = utils.MakeKeyGen(map[string]string{"keySize": keySizeStr})
 
-	idCipher, _ = keygen.GetGCMCipher([]byte(idKeyStr))
+	idCipher, _   = keygen.GetGCMCipher([]byte(idKeyStr))
+	metaCipher, _ = keygen.GetGCMCipher(metaKey)
+
+	nameKey, _  = utils.NameKey(idKey)
+	metaKey, _  = utils.MetadataKey(idKey)
+	auditKey, _ = utils.AuditKey(idKey)
+	nameEnc     = utils.SealName(idCipher, nameKey, id, name)
+
+	// Record sealed as a chunked stream under the test key.
+	chunkedEnc = func() []byte {
+		var sealed bytes.Buffer
+		sealer, _ := utils.NewStreamSealer(idCipher, idNonce[:utils.StreamPrefixSize], &sealed)
+		sealer.Write(record)
+		sealer.Close()
+		return sealed.Bytes()
+	}()
+
+	labels = map[string]string{"env": "prod"}
+
+	noCompressor, _      = utils.MakeCompressor(map[string]string{})
+	gzipCompressor, _    = utils.MakeCompressor(map[string]string{"compression": utils.CompressionGzip})
+	limitedCompressor, _ = utils.MakeCompressor(map[string]string{"compression": utils.CompressionGzip,
+		"maxDecompressedSize": "16"})
+
+	// Record compressed before being sealed under the test key.
+	compressedEnc = func() []byte {
+		compressed, _ := utils.Compress(gzipCompressor, record)
+		return idCipher.Seal(idNonce, idNonce, compressed, nil)
+	}()
+
+	noPadder, _    = utils.MakePadder(map[string]string{})
+	blockPadder, _ = utils.MakePadder(map[string]string{"padding": utils.PaddingBlock, "paddingBlockSize": "100"})
+
+	// Client signing key, trusted alongside the server signing key.
+	clientKey        = ed25519.NewKeyFromSeed([]byte(clientSeedStr))
+	clientKeyID      = utils.SignerKeyID(clientKey.Public().(ed25519.PublicKey))
+	contentSignature = utils.SignContent(clientKey, id, nil, record)
+
+	noSigner, _      = utils.MakeSigner(map[string]string{})
+	signingSigner, _ = utils.MakeSigner(map[string]string{"signingKey": signingKeyHexStr,
+		"verifyKeys": hex.EncodeToString(clientKey.Public().(ed25519.PublicKey))})
+	requireSigner, _ = utils.MakeSigner(map[string]string{"signingKey": signingKeyHexStr,
+		"requireSignatures": "true"})
+
+	// API key list holding a store and retrieve key and an admin key, and
+	// authenticators loading it from a record
+	apiKeysJSON = []byte(`[{"name":"ci","hash":"` + utils.HashAPIKey(storeAPIKey) + `","scopes":["store","retrieve"]},` +
+		`{"name":"ops","hash":"` + utils.HashAPIKey(adminAPIKey) + `","scopes":["admin"]}]`)
+
+	noAuth, _  = utils.MakeAuthenticator(map[string]string{}, nil)
+	keyAuth, _ = utils.MakeAuthenticator(map[string]string{"apiKeysRecord": idHexStr, "apiKeysRecordKey": idKeyHexStr},
+		func(id, key []byte) ([]byte, error) {
+			return apiKeysJSON, nil
+		})
+
+	// Rate limiter without limits.
+	noLimits, _ = utils.MakeRateLimiter(map[string]string{})
+	noAudit, _  = utils.MakeAuditor(map[string]string{}, nil)
+
+	// Record padded before being sealed under the test key.
+	paddedEnc = idCipher.Seal(idNonce, idNonce, utils.Pad(blockPadder, record), nil)
+
+	created = time.UnixMilli(1700000000000)
+
+	// Record attributes as encoded on the socket protocol.
+	storedInfo    = "1 1700000000000 0 64 - - - -"
+	retrievedInfo = "1 1700000000000 0 64 " + contentTypeHexStr + " " + labelsHexStr + " - -"
 
 	goodClientConfig = map[string]string{
 		"serverAddr": serverAddr}
@@ -67,9 +167,19 @@ var (
 	}
 
 	goodServer = &serverImpl{
-		keygen:   keygen,
-		idNonce:  idNonce,
-		idCipher: idCipher,
+		keygen:     keygen,
+		idNonce:    idNonce,
+		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+
+		compressor: noCompressor,
+		padder:     noPadder,
+		signer:     noSigner,
+		auth:       noAuth,
+		limiter:    noLimits,
+		auditor:    noAudit,
+
 		beClient: goodClient,
 	}
 
@@ -96,6 +206,42 @@ var (
 		return m
 	}()
 
+	badCompressionConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["compression"] = badCompressionStr
+		return m
+	}()
+
+	badPaddingConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["padding"] = badPaddingStr
+		return m
+	}()
+
+	badSigningConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["signingKey"] = badSigningKeyStr
+		return m
+	}()
+
+	badAuthConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["apiKeysRecord"] = badAPIKeysRecordStr
+		return m
+	}()
+
+	badAuditConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["auditRecordID"] = "zz"
+		return m
+	}()
+
+	badRateLimitConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["rateLimit"] = badRateLimitStr
+		return m
+	}()
+
 	badClientConfig = map[string]string{
 		"foo": "bar"}
 
@@ -110,12 +256,19 @@ var (
 const badServerMessage = "MakeServer missing configuration idKeyStr"
 const badClientMessage = "MakeClient missing configuration serverAddr"
 const badSocketIOMessage = "MakeSocketIO cannot be configured with empty port"
+const badCompressionMessage = "MakeCompressor cannot be configured with unsupported compression lz4"
+const badPaddingMessage = "MakePadder cannot be configured with unsupported padding random"
+const badSigningMessage = "MakeSigner cannot be configured with invalid signingKey"
+const badAuthMessage = "MakeAuthenticator cannot be configured with invalid apiKeysRecord"
+const badRateLimitMessage = "MakeRateLimiter cannot be configured with rateLimit " + badRateLimitStr
+const badAuditMessage = "MakeAuditor cannot be configured with invalid auditRecordID"
 const badRandomKeyMessage = "KeyGen.RandomKey error"
 const badGetGCMCipherMessage = "KeyGen.GetGCMKey error"
 const badRandomNonceMessage = "KeyGen.RandomNonce error"
 const badBEClientMessage = "Back-end client error"
 const badDecryptMessage = "cipher: message authentication failed"
 const badRequest = "Malformed request"
+const rateLimitedResponse = "ERROR RATELIMITED 1\n"
 const badDecode = "encoding/hex: invalid byte: U+0067 'g'"
 
 // Mock KeyGen
@@ -146,42 +299,227 @@ func (k *MockKeyGen) RandomNonce(nonceSize int) (nonce []byte, err error) {
 	return idNonce, nil
 }
 
+// Metadata sealed for the test record, under its name if named.
+func sealedMetadata(named bool, contentType string, labels map[string]string) []byte {
+	var recordName []byte
+	if named {
+		recordName = name
+	}
+	meta := utils.RecordMetadata{ContentType: contentType, Size: int64(len(record)), Labels: labels,
+		KeyHash: utils.KeyHash(idKey)}
+	sealed, _ := utils.SealMetadata(metaCipher, idNonce, id, recordName, meta)
+	return sealed
+}
+
+// Metadata sealed for the test record stored as a chunked stream.
+func chunkedMetadata() []byte {
+	meta := utils.RecordMetadata{Size: int64(len(record)), Chunked: true}
+	sealed, _ := utils.SealMetadata(metaCipher, idNonce, id, nil, meta)
+	return sealed
+}
+
+// Metadata sealed for the test record with a digest of its cipher entry,
+// and signatures over it under the sealed ID and name.
+func signedInfo(name, recordEnc []byte) utils.RecordInfo {
+	digest := sha256.Sum256(recordEnc)
+	meta := utils.RecordMetadata{Size: int64(len(record)), KeyHash: utils.KeyHash(idKey), Digest: digest[:]}
+	metadata, _ := utils.SealMetadata(metaCipher, idNonce, id, nil, meta)
+	signatures, _ := signingSigner.SignEnvelope(idEnc, name, metadata, contentSignature)
+	return utils.RecordInfo{Version: 1, Created: created, Metadata: metadata, Signatures: signatures}
+}
+
 // Mock Back-End Client
 type MockClient struct {
 	t    *testing.T
 	fail string
 }
 
-func (c *MockClient) StoreRecord(id, record []byte) (err error) {
+func (c *MockClient) StoreRecord(id, name, record []byte, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
 	if c.fail == "Store" {
-		return errors.New(badBEClientMessage)
+		return info, errors.New(badBEClientMessage)
+	} else if opts.Match.IfMatch > 1 {
+		return info, utils.ErrPreconditionFailed
 	}
 	assert.Equal(c.t, idEnc, id)
+	if name != nil {
+		assert.Equal(c.t, nameEnc, name)
+	}
+	if c.fail == "Compressed" {
+		// Record and attributes carry the compressed contents and codec.
+		compressed, err := idCipher.Open(nil, record[:len(idNonce)], record[len(idNonce):], nil)
+		assert.NoError(c.t, err)
+		plain, err := utils.Decompress(gzipCompressor, utils.CompressionGzip, int64(len(recordStr)), compressed)
+		assert.NoError(c.t, err)
+		assert.Equal(c.t, []byte(recordStr), plain)
+		meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, opts.Metadata)
+		assert.NoError(c.t, err)
+		assert.Equal(c.t, utils.RecordMetadata{Size: int64(len(recordStr)),
+			Compression: utils.CompressionGzip, KeyHash: utils.KeyHash(idKey)}, meta)
+		return utils.RecordInfo{Version: 1, Created: created}, nil
+	}
+	if c.fail == "Padded" {
+		// Record and attributes carry the padded contents and their length.
+		assert.Equal(c.t, paddedEnc, record)
+		meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, opts.Metadata)
+		assert.NoError(c.t, err)
+		assert.Equal(c.t, utils.RecordMetadata{Size: int64(len(recordStr)),
+			Padding: utils.PaddingBlock, Unpadded: int64(len(recordStr)), KeyHash: utils.KeyHash(idKey)}, meta)
+		return utils.RecordInfo{Version: 1, Created: created}, nil
+	}
 	assert.Equal(c.t, recordEnc, record)
-	return nil
+	if c.fail == "Signed" {
+		// Signed attributes carry the digest of the cipher entry.
+		assert.Equal(c.t, signedInfo(name, recordEnc).Metadata, opts.Metadata)
+		content, err := signingSigner.VerifyEnvelope(id, name, opts.Metadata, opts.Signatures)
+		assert.NoError(c.t, err)
+		assert.Equal(c.t, contentSignature, content)
+		return utils.RecordInfo{Version: 1, Created: created}, nil
+	}
+	assert.Nil(c.t, opts.Signatures)
+	if opts.OneTime {
+		assert.Equal(c.t, utils.KeyHash(idKey), opts.KeyHash)
+	} else {
+		assert.Nil(c.t, opts.KeyHash)
+	}
+	assert.Equal(c.t, sealedMetadata(name != nil, opts.ContentType, opts.Labels), opts.Metadata)
+	return utils.RecordInfo{Version: 1, Created: created}, nil
 }
 
-func (c *MockClient) RetrieveRecord(id []byte) (record []byte, err error) {
+func (c *MockClient) RetrieveRecord(id, name []byte, opts utils.RetrieveOptions) (record []byte, info utils.RecordInfo, err error) {
 	if c.fail == "Retrieve" {
-		return nil, errors.New(badBEClientMessage)
+		return nil, info, errors.New(badBEClientMessage)
 	} else if c.fail == "RetrieveCorrupt" {
 		// Corrupt nonce on encrypted record.
-		return recordEnc[1:], nil
+		return recordEnc[1:], info, nil
+	} else if c.fail == "RetrieveShort" {
+		// Encrypted record shorter than its nonce.
+		return recordEnc[:3], info, nil
+	} else if c.fail == "MetadataCorrupt" {
+		// Corrupt nonce on sealed metadata.
+		info.Metadata = sealedMetadata(false, contentTypeStr, labels)[1:]
+		return recordEnc, info, nil
+	}
+	if c.fail == "Chunked" {
+		info.Metadata = chunkedMetadata()
+		return chunkedEnc, info, nil
+	}
+	if c.fail == "Signed" || c.fail == "SignedTampered" || c.fail == "SignedDigest" {
+		// Tampered records carry signatures over other attributes, or a
+		// cipher entry other than the one signed.
+		info = signedInfo(name, recordEnc)
+		if c.fail == "SignedTampered" {
+			info.Metadata = sealedMetadata(false, contentTypeStr, labels)
+		} else if c.fail == "SignedDigest" {
+			return paddedEnc, info, nil
+		}
+		return recordEnc, info, nil
+	}
+	if c.fail == "Compressed" || c.fail == "CompressedUndersized" {
+		// Undersized records decompress beyond the size sealed with them.
+		meta := utils.RecordMetadata{Size: int64(len(recordStr)), Compression: utils.CompressionGzip}
+		if c.fail == "CompressedUndersized" {
+			meta.Size = 16
+		}
+		metadata, _ := utils.SealMetadata(metaCipher, idNonce, []byte(idStr), nil, meta)
+		return compressedEnc, utils.RecordInfo{Version: 1, Created: created, Metadata: metadata}, nil
+	}
+	if c.fail == "Member" || c.fail == "MemberOneTime" {
+		// One-time member records are only consumed against the record key.
+		meta := utils.RecordMetadata{Size: int64(len(recordStr)), KeySlots: []utils.KeySlot{memberSlot}}
+		metadata, _ := utils.SealMetadata(metaCipher, idNonce, []byte(idStr), nil, meta)
+		info = utils.RecordInfo{Version: 1, Created: created, Metadata: metadata}
+		if opts.MetadataOnly {
+			return nil, info, nil
+		} else if c.fail == "MemberOneTime" && !bytes.Equal(utils.KeyHash(idKey), opts.KeyHash) {
+			return nil, utils.RecordInfo{}, utils.ErrKeyMismatch
+		}
+		return recordEnc, info, nil
+	}
+	if c.fail == "Padded" || c.fail == "PaddedShort" {
+		// Short padded records record contents beyond their padded length.
+		meta := utils.RecordMetadata{Size: int64(len(recordStr)), Padding: utils.PaddingBlock,
+			Unpadded: int64(len(recordStr))}
+		if c.fail == "PaddedShort" {
+			meta.Unpadded = 200
+		}
+		metadata, _ := utils.SealMetadata(metaCipher, idNonce, []byte(idStr), nil, meta)
+		return paddedEnc, utils.RecordInfo{Version: 1, Created: created, Metadata: metadata}, nil
+	}
+
+	assert.Equal(c.t, idEnc, id)
+	if name != nil {
+		assert.Equal(c.t, nameEnc, name)
+	}
+	info = utils.RecordInfo{
+		Version:  1,
+		Created:  created,
+		Metadata: sealedMetadata(name != nil, contentTypeStr, labels),
+	}
+	if opts.MetadataOnly {
+		assert.Nil(c.t, opts.KeyHash)
+		return nil, info, nil
+	}
+	assert.Equal(c.t, utils.KeyHash(idKey), opts.KeyHash)
+	return recordEnc, info, nil
+}
+
+func (c *MockClient) StoreStream(id, name []byte, record io.Reader, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
+	data, err := io.ReadAll(record)
+	if err != nil {
+		return info, err
+	}
+	return c.StoreRecord(id, name, data, opts)
+}
+
+func (c *MockClient) RetrieveStream(id, name []byte, opts utils.RetrieveOptions) (record io.ReadCloser, info utils.RecordInfo, err error) {
+	data, info, err := c.RetrieveRecord(id, name, opts)
+	return io.NopCloser(bytes.NewReader(data)), info, err
+}
+
+func (c *MockClient) ListVersions(id, name []byte) (versions []int64, err error) {
+	assert.Equal(c.t, idEnc, id)
+	return []int64{1}, nil
+}
+
+func (c *MockClient) ListRecords(id []byte, opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
+	if c.fail == "List" {
+		return nil, "", errors.New(badBEClientMessage)
+	} else if c.fail == "ListCorrupt" {
+		// Corrupt nonce on sealed name.
+		return []utils.RecordSummary{{Name: nameEnc[1:]}}, "", nil
 	}
 
 	assert.Equal(c.t, idEnc, id)
-	return recordEnc, nil
+	records = []utils.RecordSummary{
+		{Name: nameEnc, Size: int64(len(recordEnc)), Created: created},
+		{Name: nameEnc, Size: int64(len(recordEnc)), Created: created,
+			Metadata: sealedMetadata(true, contentTypeStr, nil)},
+	}
+	return records, opts.Cursor, nil
 }
 
-func (c *MockClient) DeleteRecord(id []byte) (err error) {
+func (c *MockClient) DeleteRecord(id, name []byte, opts utils.DeleteOptions) (err error) {
 	if c.fail == "Delete" {
 		return errors.New(badBEClientMessage)
+	} else if opts.Match.IfMatch > 1 {
+		return utils.ErrPreconditionFailed
 	}
 
 	assert.Equal(c.t, idEnc, id)
+	if name != nil {
+		assert.Equal(c.t, nameEnc, name)
+	}
 	return nil
 }
 
+func (c *MockClient) UpdateMetadata(id, name, prior, metadata, signatures []byte) (info utils.RecordInfo, err error) {
+	return info, errors.New(badBEClientMessage)
+}
+
+func (c *MockClient) WithContext(ctx context.Context) utils.ClientBE {
+	return c
+}
+
 // MakeServer() - Test Method
 func TestServer_MakeServer(t *testing.T) {
 
@@ -221,11 +559,41 @@ func TestServer_MakeServer(t *testing.T) {
 			args:    args{badIdKeyConfig, goodClientConfig},
 			wantErr: aes.KeySizeError(0),
 		},
+		{
+			name:    "should fail building compressor",
+			args:    args{badCompressionConfig, goodClientConfig},
+			wantErr: errors.New(badCompressionMessage),
+		},
+		{
+			name:    "should fail building padder",
+			args:    args{badPaddingConfig, goodClientConfig},
+			wantErr: errors.New(badPaddingMessage),
+		},
+		{
+			name:    "should fail building signer",
+			args:    args{badSigningConfig, goodClientConfig},
+			wantErr: errors.New(badSigningMessage),
+		},
 		{
 			name:    "should fail building back-end client",
 			args:    args{goodServerConfig, badClientConfig},
 			wantErr: errors.New(badClientMessage),
 		},
+		{
+			name:    "should fail building authenticator",
+			args:    args{badAuthConfig, goodClientConfig},
+			wantErr: errors.New(badAuthMessage),
+		},
+		{
+			name:    "should fail building rate limiter",
+			args:    args{badRateLimitConfig, goodClientConfig},
+			wantErr: errors.New(badRateLimitMessage),
+		},
+		{
+			name:    "should fail building auditor",
+			args:    args{badAuditConfig, goodClientConfig},
+			wantErr: errors.New(badAuditMessage),
+		},
 		{
 			name:    "should fail building socket IO",
 			args:    args{badPortConfig, goodClientConfig},
@@ -246,19 +614,25 @@ func TestServer_MakeServer(t *testing.T) {
 func TestServer_store(t *testing.T) {
 
 	type fields struct {
-		keygen   utils.KeyGen
-		beClient utils.ClientBE
+		keygen     utils.KeyGen
+		compressor utils.Compressor
+		padder     utils.Padder
+		signer     utils.Signer
+		beClient   utils.ClientBE
 	}
 	type args struct {
 		id     []byte
+		name   []byte
 		record []byte
+		opts   utils.StoreOptions
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []byte
-		wantErr error
+		name     string
+		fields   fields
+		args     args
+		want     []byte
+		wantInfo utils.RecordInfo
+		wantErr  error
 	}{
 		{
 			name: "should run successfully",
@@ -266,8 +640,112 @@ func TestServer_store(t *testing.T) {
 				keygen:   &MockKeyGen{t, ""},
 				beClient: &MockClient{t, ""},
 			},
-			args: args{id, record},
+			args:     args{id, nil, record, utils.StoreOptions{}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run named store successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args:     args{id, name, record, utils.StoreOptions{}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run store with attributes successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args: args{id, name, record, utils.StoreOptions{ContentType: contentTypeStr, Labels: labels}},
 			want: idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				ContentType: contentTypeStr, Labels: labels},
+		},
+		{
+			name: "should run one-time store successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args:     args{id, nil, record, utils.StoreOptions{OneTime: true}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run compressed store successfully",
+			fields: fields{
+				keygen:     &MockKeyGen{t, ""},
+				compressor: gzipCompressor,
+				beClient:   &MockClient{t, "Compressed"},
+			},
+			args:     args{id, nil, record, utils.StoreOptions{}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run store opted out of compression successfully",
+			fields: fields{
+				keygen:     &MockKeyGen{t, ""},
+				compressor: gzipCompressor,
+				beClient:   &MockClient{t, ""},
+			},
+			args:     args{id, nil, record, utils.StoreOptions{NoCompress: true}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run padded store successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				padder:   blockPadder,
+				beClient: &MockClient{t, "Padded"},
+			},
+			args:     args{id, nil, record, utils.StoreOptions{}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should run signed store successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   signingSigner,
+				beClient: &MockClient{t, "Signed"},
+			},
+			args:     args{id, nil, record, utils.StoreOptions{ContentSignature: contentSignature}},
+			want:     idKey,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64},
+		},
+		{
+			name: "should fail on untrusted content signature",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, record, utils.StoreOptions{ContentSignature: contentSignature}},
+			wantErr: utils.ErrSignerUnknown,
+		},
+		{
+			name: "should fail on invalid content signature",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   signingSigner,
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, name, record, utils.StoreOptions{ContentSignature: contentSignature}},
+			wantErr: utils.ErrSignatureInvalid,
+		},
+		{
+			name: "should fail on mismatched revision",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, record, utils.StoreOptions{Match: utils.Precondition{IfMatch: 2}}},
+			wantErr: utils.ErrPreconditionFailed,
 		},
 		{
 			name: "should fail generating random key",
@@ -275,7 +753,7 @@ func TestServer_store(t *testing.T) {
 				keygen:   &MockKeyGen{t, "RandomKey"},
 				beClient: &MockClient{t, ""},
 			},
-			args:    args{id, record},
+			args:    args{id, nil, record, utils.StoreOptions{}},
 			wantErr: errors.New(badRandomKeyMessage),
 		},
 		{
@@ -284,7 +762,7 @@ func TestServer_store(t *testing.T) {
 				keygen:   &MockKeyGen{t, "GetGCMCipher"},
 				beClient: &MockClient{t, ""},
 			},
-			args:    args{id, record},
+			args:    args{id, nil, record, utils.StoreOptions{}},
 			wantErr: errors.New(badGetGCMCipherMessage),
 		},
 		{
@@ -293,7 +771,7 @@ func TestServer_store(t *testing.T) {
 				keygen:   &MockKeyGen{t, "RandomNonce"},
 				beClient: &MockClient{t, ""},
 			},
-			args:    args{id, record},
+			args:    args{id, nil, record, utils.StoreOptions{}},
 			wantErr: errors.New(badRandomNonceMessage),
 		},
 		{
@@ -302,22 +780,42 @@ func TestServer_store(t *testing.T) {
 				keygen:   &MockKeyGen{t, ""},
 				beClient: &MockClient{t, "Store"},
 			},
-			args:    args{id, record},
+			args:    args{id, nil, record, utils.StoreOptions{}},
 			wantErr: errors.New(badBEClientMessage),
 		},
 	}
 
 	for _, test := range tests {
+		compressor := test.fields.compressor
+		if compressor == nil {
+			compressor = noCompressor
+		}
+		padder := test.fields.padder
+		if padder == nil {
+			padder = noPadder
+		}
+		signer := test.fields.signer
+		if signer == nil {
+			signer = noSigner
+		}
 		s := &serverImpl{
-			keygen:   test.fields.keygen,
-			idNonce:  idNonce,
-			idCipher: idCipher,
+			keygen:     test.fields.keygen,
+			idNonce:    idNonce,
+			idCipher:   idCipher,
+			nameKey:    nameKey,
+			metaCipher: metaCipher,
+
+			compressor: compressor,
+			padder:     padder,
+			signer:     signer,
+
 			beClient: test.fields.beClient,
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			got, err := s.storeRecord(test.args.id, test.args.record)
+			got, info, err := s.storeRecord(context.Background(), test.args.id, test.args.name, test.args.record, test.args.opts)
 			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantInfo, info)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
@@ -327,19 +825,24 @@ func TestServer_store(t *testing.T) {
 func TestServer_retrieve(t *testing.T) {
 
 	type fields struct {
-		keygen   utils.KeyGen
-		beClient utils.ClientBE
+		keygen     utils.KeyGen
+		compressor utils.Compressor
+		padder     utils.Padder
+		signer     utils.Signer
+		beClient   utils.ClientBE
 	}
 	type args struct {
-		id  []byte
-		key []byte
+		id   []byte
+		name []byte
+		key  []byte
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []byte
-		wantErr error
+		name     string
+		fields   fields
+		args     args
+		want     []byte
+		wantInfo utils.RecordInfo
+		wantErr  error
 	}{
 		{
 			name: "should run successfully",
@@ -347,110 +850,523 @@ func TestServer_retrieve(t *testing.T) {
 				keygen:   &MockKeyGen{t, ""},
 				beClient: &MockClient{t, ""},
 			},
-			args: args{id, idKey},
+			args: args{id, nil, idKey},
 			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				ContentType: contentTypeStr, Labels: labels},
 		},
 		{
-			name: "should fail generating GCM cipher",
+			name: "should run named retrieve successfully",
 			fields: fields{
-				keygen:   &MockKeyGen{t, "GetGCMCipher"},
+				keygen:   &MockKeyGen{t, ""},
 				beClient: &MockClient{t, ""},
 			},
-			args:    args{id, idKey},
-			wantErr: errors.New(badGetGCMCipherMessage),
+			args: args{id, name, idKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				ContentType: contentTypeStr, Labels: labels},
 		},
 		{
-			name: "should fail calling back-end client",
+			name: "should run chunked retrieve successfully",
 			fields: fields{
 				keygen:   &MockKeyGen{t, ""},
-				beClient: &MockClient{t, "Retrieve"},
+				beClient: &MockClient{t, "Chunked"},
 			},
-			args:    args{id, idKey},
-			wantErr: errors.New(badBEClientMessage),
+			args:     args{id, nil, idKey},
+			want:     record,
+			wantInfo: utils.RecordInfo{Size: 64, Chunked: true},
+		},
+		{
+			name: "should run compressed retrieve successfully",
+			fields: fields{
+				keygen:     &MockKeyGen{t, ""},
+				compressor: noCompressor,
+				beClient:   &MockClient{t, "Compressed"},
+			},
+			args: args{id, nil, idKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				Compression: utils.CompressionGzip},
+		},
+		{
+			name: "should decompress beyond configured limit up to sealed size",
+			fields: fields{
+				keygen:     &MockKeyGen{t, ""},
+				compressor: limitedCompressor,
+				beClient:   &MockClient{t, "Compressed"},
+			},
+			args: args{id, nil, idKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				Compression: utils.CompressionGzip},
+		},
+		{
+			name: "should fail decompressing beyond sealed size",
+			fields: fields{
+				keygen:     &MockKeyGen{t, ""},
+				compressor: gzipCompressor,
+				beClient:   &MockClient{t, "CompressedUndersized"},
+			},
+			args: args{id, nil, idKey},
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 16,
+				Compression: utils.CompressionGzip},
+			wantErr: utils.ErrDecompressedTooLarge,
 		},
 		{
-			name: "should fail decrypting record",
+			name: "should run padded retrieve successfully",
 			fields: fields{
 				keygen:   &MockKeyGen{t, ""},
-				beClient: &MockClient{t, "RetrieveCorrupt"},
+				beClient: &MockClient{t, "Padded"},
 			},
-			args:    args{id, idKey},
-			wantErr: errors.New(badDecryptMessage),
+			args: args{id, nil, idKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				Padding: utils.PaddingBlock, Unpadded: 64},
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			s := &serverImpl{
-				keygen:   test.fields.keygen,
-				idNonce:  idNonce,
-				idCipher: idCipher,
-				beClient: test.fields.beClient,
-			}
-
-			got, err := s.retrieveRecord(test.args.id, test.args.key)
-			assert.Equal(t, test.want, got)
-			assert.Equal(t, test.wantErr, err)
-		})
-	}
-}
-
-// delete() - Test Methods
-func TestServer_delete(t *testing.T) {
-
-	type fields struct {
-		keygen   utils.KeyGen
-		beClient utils.ClientBE
-	}
-	type args struct {
-		id []byte
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr error
-	}{
 		{
-			name: "should run successfully",
+			name: "should fail unpadding record shorter than its contents",
 			fields: fields{
 				keygen:   &MockKeyGen{t, ""},
-				beClient: &MockClient{t, ""},
+				beClient: &MockClient{t, "PaddedShort"},
 			},
-			args: args{id},
+			args: args{id, nil, idKey},
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				Padding: utils.PaddingBlock, Unpadded: 200},
+			wantErr: utils.ErrPaddingInvalid,
 		},
 		{
-			name: "should fail calling back-end client",
+			name: "should run member retrieve successfully",
 			fields: fields{
 				keygen:   &MockKeyGen{t, ""},
-				beClient: &MockClient{t, "Delete"},
+				beClient: &MockClient{t, "Member"},
 			},
-			args:    args{id},
-			wantErr: errors.New(badBEClientMessage),
+			args: args{id, nil, memberKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				KeySlots: []utils.KeySlot{memberSlot}},
 		},
+		{
+			name: "should run one-time member retrieve successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "MemberOneTime"},
+			},
+			args: args{id, nil, memberKey},
+			want: record,
+			wantInfo: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				KeySlots: []utils.KeySlot{memberSlot}},
+		},
+		{
+			name: "should fail on one-time retrieve without a key slot",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "MemberOneTime"},
+			},
+			args:    args{id, nil, otherMemberKey},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should fail generating GCM cipher",
+			fields: fields{
+				keygen:   &MockKeyGen{t, "GetGCMCipher"},
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: errors.New(badGetGCMCipherMessage),
+		},
+		{
+			name: "should fail calling back-end client",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "Retrieve"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: errors.New(badBEClientMessage),
+		},
+		{
+			name: "should fail with key mismatch decrypting record",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "RetrieveCorrupt"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should fail on record shorter than its nonce",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "RetrieveShort"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: errors.New("Sealed record too short"),
+		},
+		{
+			name: "should fail decrypting metadata",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "MetadataCorrupt"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: errors.New(badDecryptMessage),
+		},
+		{
+			name: "should run signed retrieve successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   signingSigner,
+				beClient: &MockClient{t, "Signed"},
+			},
+			args: args{id, nil, idKey},
+			want: record,
+			wantInfo: func() utils.RecordInfo {
+				info, digest := signedInfo(nil, recordEnc), sha256.Sum256(recordEnc)
+				info.Metadata, info.Size, info.Digest, info.Signer = nil, 64, digest[:], clientKeyID
+				return info
+			}(),
+		},
+		{
+			name: "should fail on tampered signed metadata",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   signingSigner,
+				beClient: &MockClient{t, "SignedTampered"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: utils.ErrSignatureInvalid,
+		},
+		{
+			name: "should fail on record not matching its signed digest",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   signingSigner,
+				beClient: &MockClient{t, "SignedDigest"},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: utils.ErrSignatureInvalid,
+		},
+		{
+			name: "should fail on unsigned record when signatures are required",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				signer:   requireSigner,
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, idKey},
+			wantErr: utils.ErrSignatureMissing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compressor := test.fields.compressor
+			if compressor == nil {
+				compressor = noCompressor
+			}
+			padder := test.fields.padder
+			if padder == nil {
+				padder = noPadder
+			}
+			signer := test.fields.signer
+			if signer == nil {
+				signer = noSigner
+			}
+			s := &serverImpl{
+				keygen:     test.fields.keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+
+				compressor: compressor,
+				padder:     padder,
+				signer:     signer,
+
+				beClient: test.fields.beClient,
+			}
+
+			got, info, err := s.retrieveRecord(context.Background(), test.args.id, test.args.name, test.args.key)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantInfo, info)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// stat() - Test Methods
+func TestServer_stat(t *testing.T) {
+
+	type fields struct {
+		beClient utils.ClientBE
+	}
+	type args struct {
+		id   []byte
+		name []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    utils.RecordInfo
+		wantErr error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{id, name},
+			want: utils.RecordInfo{Version: 1, Created: created, Size: 64,
+				ContentType: contentTypeStr, Labels: labels},
+		},
+		{
+			name: "should fail calling back-end client",
+			fields: fields{
+				beClient: &MockClient{t, "Retrieve"},
+			},
+			args:    args{id, nil},
+			wantErr: errors.New(badBEClientMessage),
+		},
+		{
+			name: "should fail decrypting metadata",
+			fields: fields{
+				beClient: &MockClient{t, "MetadataCorrupt"},
+			},
+			args:    args{id, nil},
+			wantErr: errors.New(badDecryptMessage),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &serverImpl{
+				keygen:     &MockKeyGen{t, ""},
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+
+				beClient: test.fields.beClient,
+			}
+
+			got, err := s.statRecord(context.Background(), test.args.id, test.args.name)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// list() - Test Methods
+func TestServer_list(t *testing.T) {
+
+	type fields struct {
+		beClient utils.ClientBE
+	}
+	type args struct {
+		id   []byte
+		opts utils.ListOptions
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		want     []utils.RecordSummary
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{id, utils.ListOptions{Cursor: nameHexStr}},
+			want: []utils.RecordSummary{
+				{Name: name, Size: int64(len(record)), Created: created},
+				{Name: name, Size: int64(len(record)), ContentType: contentTypeStr, Created: created},
+			},
+			wantNext: nameHexStr,
+		},
+		{
+			name: "should fail calling back-end client",
+			fields: fields{
+				beClient: &MockClient{t, "List"},
+			},
+			args:    args{id, utils.ListOptions{}},
+			wantErr: errors.New(badBEClientMessage),
+		},
+		{
+			name: "should fail decrypting name",
+			fields: fields{
+				beClient: &MockClient{t, "ListCorrupt"},
+			},
+			args:    args{id, utils.ListOptions{}},
+			wantErr: errors.New(badDecryptMessage),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &serverImpl{
+				keygen:     &MockKeyGen{t, ""},
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+
+				beClient: test.fields.beClient,
+			}
+
+			got, next, err := s.listRecords(context.Background(), test.args.id, test.args.opts)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantNext, next)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// delete() - Test Methods
+func TestServer_delete(t *testing.T) {
+
+	type fields struct {
+		keygen   utils.KeyGen
+		beClient utils.ClientBE
+	}
+	type args struct {
+		id   []byte
+		name []byte
+		key  []byte
+		opts utils.DeleteOptions
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should run successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args: args{id, nil, idKey, utils.DeleteOptions{}},
+		},
+		{
+			name: "should run named delete successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args: args{id, name, idKey, utils.DeleteOptions{}},
+		},
+		{
+			name: "should run admin delete without key successfully",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "Retrieve"},
+			},
+			args: args{id, nil, nil, utils.DeleteOptions{}},
+		},
+		{
+			name: "should fail on member key delete",
+			fields: fields{
+				keygen:   keygen,
+				beClient: &MockClient{t, "Member"},
+			},
+			args:    args{id, nil, memberKey, utils.DeleteOptions{}},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should run delete of record without key commitment successfully",
+			fields: fields{
+				keygen:   keygen,
+				beClient: &MockClient{t, "Chunked"},
+			},
+			args: args{id, nil, idKey, utils.DeleteOptions{}},
+		},
+		{
+			name: "should fail on mismatched key",
+			fields: fields{
+				keygen:   keygen,
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, memberKey, utils.DeleteOptions{}},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should fail on mismatched key of record without key commitment",
+			fields: fields{
+				keygen:   keygen,
+				beClient: &MockClient{t, "Chunked"},
+			},
+			args:    args{id, nil, memberKey, utils.DeleteOptions{}},
+			wantErr: utils.ErrKeyMismatch,
+		},
+		{
+			name: "should fail on mismatched revision",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, ""},
+			},
+			args:    args{id, nil, idKey, utils.DeleteOptions{Match: utils.Precondition{IfMatch: 2}}},
+			wantErr: utils.ErrPreconditionFailed,
+		},
+		{
+			name: "should fail reading record attributes",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "Retrieve"},
+			},
+			args:    args{id, nil, idKey, utils.DeleteOptions{}},
+			wantErr: errors.New(badBEClientMessage),
+		},
+		{
+			name: "should fail calling back-end client",
+			fields: fields{
+				keygen:   &MockKeyGen{t, ""},
+				beClient: &MockClient{t, "Delete"},
+			},
+			args:    args{id, nil, idKey, utils.DeleteOptions{}},
+			wantErr: errors.New(badBEClientMessage),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &serverImpl{
+				keygen:     test.fields.keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+
+				beClient: test.fields.beClient,
+			}
+
+			err := s.deleteRecord(context.Background(), test.args.id, test.args.name, test.args.key, test.args.opts)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// Respond() - Test Methods
+func TestServer_Respond(t *testing.T) {
+
+	type fields struct {
+		beClient utils.ClientBE
 	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			s := &serverImpl{
-				keygen:   test.fields.keygen,
-				idNonce:  idNonce,
-				idCipher: idCipher,
-				beClient: test.fields.beClient,
-			}
-
-			err := s.deleteRecord(test.args.id)
-			assert.Equal(t, test.wantErr, err)
-		})
-	}
-}
-
-// Respond() - Test Methods
-func TestServer_Respond(t *testing.T) {
-
-	type fields struct {
-		beClient utils.ClientBE
-	}
 	type args struct {
 		message string
 	}
@@ -467,7 +1383,98 @@ func TestServer_Respond(t *testing.T) {
 				beClient: &MockClient{t, ""},
 			},
 			args: args{"STORE " + idHexStr + " " + recordHexStr},
-			want: []byte(idKeyHexStr + "\n"),
+			want: []byte(idKeyHexStr + " " + storedInfo + "\n"),
+		},
+		{
+			name: "should run named StoreRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " NAME " + nameHexStr},
+			want: []byte(idKeyHexStr + " " + storedInfo + "\n"),
+		},
+		{
+			name: "should run one-time StoreRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " ONETIME"},
+			want: []byte(idKeyHexStr + " " + storedInfo + "\n"),
+		},
+		{
+			name: "should run uncompressed StoreRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " NOCOMPRESS"},
+			want: []byte(idKeyHexStr + " " + storedInfo + "\n"),
+		},
+		{
+			name: "should run conditional StoreRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " ONETIME IFMATCH \"1\""},
+			want: []byte(idKeyHexStr + " " + storedInfo + "\n"),
+		},
+		{
+			name: "should fail on mismatched StoreRecord() revision",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " IFMATCH \"2\""},
+			want: []byte("ERROR " + utils.ErrPreconditionFailed.Error() + "\n"),
+		},
+		{
+			name: "should fail on unpaired StoreRecord() precondition",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " IFMATCH"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should run StoreRecord() with attributes successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " TYPE " + contentTypeHexStr +
+				" LABELS " + labelsHexStr},
+			want: []byte(idKeyHexStr + " 1 1700000000000 0 64 " + contentTypeHexStr + " " +
+				labelsHexStr + " - -\n"),
+		},
+		{
+			name: "should fail on malformed StoreRecord() labels",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " LABELS " + contentTypeHexStr},
+			want: []byte("ERROR invalid character 'e' in literal true (expecting 'r')\n"),
+		},
+		{
+			name: "should fail on untrusted StoreRecord() content signature",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " SIGNER " + hex.EncodeToString(clientKeyID) +
+				" SIGNATURE " + hex.EncodeToString(contentSignature.Signature)},
+			want: []byte("ERROR " + utils.ErrSignerUnknown.Error() + "\n"),
+		},
+		{
+			name: "should fail on StoreRecord() signer without signature",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " SIGNER " + hex.EncodeToString(clientKeyID)},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on unrecognized StoreRecord() option",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STORE " + idHexStr + " " + recordHexStr + " FOO"},
+			want: []byte("ERROR " + badRequest + "\n"),
 		},
 		{
 			name: "should fail on StoreRecord() token count",
@@ -499,7 +1506,23 @@ func TestServer_Respond(t *testing.T) {
 				beClient: &MockClient{t, ""},
 			},
 			args: args{"RETRIEVE " + idHexStr + " " + idKeyHexStr},
-			want: []byte(recordHexStr + "\n"),
+			want: []byte(recordHexStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should run named RetrieveRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexStr + " " + idKeyHexStr + " NAME " + nameHexStr},
+			want: []byte(recordHexStr + " " + retrievedInfo + "\n"),
+		},
+		{
+			name: "should fail on unpaired RetrieveRecord() name",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"RETRIEVE " + idHexStr + " " + idKeyHexStr + " NAME"},
+			want: []byte("ERROR " + badRequest + "\n"),
 		},
 		{
 			name: "should fail on RetrieveRecord() token count",
@@ -525,20 +1548,125 @@ func TestServer_Respond(t *testing.T) {
 			args: args{"RETRIEVE " + idHexStr + " " + idKeyHexStr},
 			want: []byte("ERROR " + badBEClientMessage + "\n"),
 		},
+		{
+			name: "should run StatRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STAT " + idHexStr + " NAME " + nameHexStr},
+			want: []byte(retrievedInfo + "\n"),
+		},
+		{
+			name: "should fail on StatRecord() token count",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STAT"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on STAT decode",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"STAT ggg"},
+			want: []byte("ERROR " + badDecode + "\n"),
+		},
+		{
+			name: "should fail on back-end client StatRecord()",
+			fields: fields{
+				beClient: &MockClient{t, "Retrieve"},
+			},
+			args: args{"STAT " + idHexStr},
+			want: []byte("ERROR " + badBEClientMessage + "\n"),
+		},
+		{
+			name: "should run ListRecords() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"LIST " + idHexStr + " LIMIT 10"},
+			want: []byte("- " + nameHexStr + ",64,1700000000000,0,-,- " +
+				nameHexStr + ",64,1700000000000,0," + contentTypeHexStr + ",-\n"),
+		},
+		{
+			name: "should fail on unpaired ListRecords() option",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"LIST " + idHexStr + " CURSOR"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
+		{
+			name: "should fail on LIST decode",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"LIST ggg"},
+			want: []byte("ERROR " + badDecode + "\n"),
+		},
+		{
+			name: "should fail on back-end client ListRecords()",
+			fields: fields{
+				beClient: &MockClient{t, "List"},
+			},
+			args: args{"LIST " + idHexStr},
+			want: []byte("ERROR " + badBEClientMessage + "\n"),
+		},
 		{
 			name: "should run DeleteRecord() successfully",
 			fields: fields{
 				beClient: &MockClient{t, ""},
 			},
-			args: args{"DELETE " + idHexStr},
+			args: args{"DELETE " + idHexStr + " " + idKeyHexStr},
+			want: []byte("\n"),
+		},
+		{
+			name: "should run named DeleteRecord() successfully",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " " + idKeyHexStr + " NAME " + nameHexStr},
 			want: []byte("\n"),
 		},
+		{
+			name: "should fail on mismatched DeleteRecord() revision",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " " + idKeyHexStr + " IFMATCH \"2\""},
+			want: []byte("ERROR " + utils.ErrPreconditionFailed.Error() + "\n"),
+		},
+		{
+			name: "should fail on mismatched DeleteRecord() key",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " " + hex.EncodeToString(memberKey)},
+			want: []byte("ERROR " + utils.ErrKeyMismatch.Error() + "\n"),
+		},
+		{
+			name: "should fail on DeleteRecord() without key",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " -"},
+			want: []byte("ERROR key not defined\n"),
+		},
+		{
+			name: "should fail on unrecognized DeleteRecord() option",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " " + idKeyHexStr + " ONETIME"},
+			want: []byte("ERROR " + badRequest + "\n"),
+		},
 		{
 			name: "should fail on DeleteRecord() token count",
 			fields: fields{
 				beClient: &MockClient{t, ""},
 			},
-			args: args{"DELETE "},
+			args: args{"DELETE " + idHexStr},
 			want: []byte("ERROR " + badRequest + "\n"),
 		},
 		{
@@ -546,7 +1674,15 @@ func TestServer_Respond(t *testing.T) {
 			fields: fields{
 				beClient: &MockClient{t, ""},
 			},
-			args: args{"DELETE ggg"},
+			args: args{"DELETE ggg " + idKeyHexStr},
+			want: []byte("ERROR " + badDecode + "\n"),
+		},
+		{
+			name: "should fail on DELETE key decode",
+			fields: fields{
+				beClient: &MockClient{t, ""},
+			},
+			args: args{"DELETE " + idHexStr + " ggg"},
 			want: []byte("ERROR " + badDecode + "\n"),
 		},
 		{
@@ -554,7 +1690,7 @@ func TestServer_Respond(t *testing.T) {
 			fields: fields{
 				beClient: &MockClient{t, "Delete"},
 			},
-			args: args{"DELETE " + idHexStr},
+			args: args{"DELETE " + idHexStr + " " + idKeyHexStr},
 			want: []byte("ERROR " + badBEClientMessage + "\n"),
 		},
 		{
@@ -569,9 +1705,19 @@ func TestServer_Respond(t *testing.T) {
 
 	for _, test := range tests {
 		s := &serverImpl{
-			keygen:   &MockKeyGen{t, ""},
-			idNonce:  idNonce,
-			idCipher: idCipher,
+			keygen:     &MockKeyGen{t, ""},
+			idNonce:    idNonce,
+			idCipher:   idCipher,
+			nameKey:    nameKey,
+			metaCipher: metaCipher,
+
+			compressor: noCompressor,
+			padder:     noPadder,
+			signer:     noSigner,
+			auth:       noAuth,
+			limiter:    noLimits,
+			auditor:    noAudit,
+
 			beClient: test.fields.beClient,
 		}
 
@@ -581,3 +1727,198 @@ func TestServer_Respond(t *testing.T) {
 		})
 	}
 }
+
+// Session() - Test Methods
+func TestServer_Session(t *testing.T) {
+
+	// Rate limits for deletes only, by endpoint.
+	rateLimitsFile := filepath.Join(t.TempDir(), "ratelimits.json")
+	_ = os.WriteFile(rateLimitsFile, []byte(`{"DELETE": "1/1"}`), 0o600)
+
+	type args struct {
+		messages []string
+	}
+	tests := []struct {
+		name       string
+		auth       utils.Authenticator
+		rateLimits map[string]string
+		args       args
+		want       [][]byte
+	}{
+		{
+			name: "should respond without authentication",
+			auth: noAuth,
+			args: args{[]string{"DELETE " + idHexStr + " " + idKeyHexStr}},
+			want: [][]byte{[]byte("\n")},
+		},
+		{
+			name: "should authorize requests within API key scopes",
+			auth: keyAuth,
+			args: args{[]string{"AUTH " + storeAPIKey, "STORE " + idHexStr + " " + recordHexStr}},
+			want: [][]byte{[]byte("\n"), []byte(idKeyHexStr + " " + storedInfo + "\n")},
+		},
+		{
+			name: "should authorize admin API key for any request",
+			auth: keyAuth,
+			args: args{[]string{"AUTH " + adminAPIKey, "DELETE " + idHexStr + " -"}},
+			want: [][]byte{[]byte("\n"), []byte("\n")},
+		},
+		{
+			name: "should fail requests outside API key scopes",
+			auth: keyAuth,
+			args: args{[]string{"AUTH " + storeAPIKey, "DELETE " + idHexStr + " " + idKeyHexStr}},
+			want: [][]byte{[]byte("\n"), []byte("ERROR " + utils.ErrForbidden.Error() + "\n")},
+		},
+		{
+			name: "should fail requests before authentication",
+			auth: keyAuth,
+			args: args{[]string{"STORE " + idHexStr + " " + recordHexStr}},
+			want: [][]byte{[]byte("ERROR " + utils.ErrUnauthenticated.Error() + "\n")},
+		},
+		{
+			name: "should fail authenticating unknown API key",
+			auth: keyAuth,
+			args: args{[]string{"AUTH unknown-api-key", "DELETE " + idHexStr + " " + idKeyHexStr}},
+			want: [][]byte{[]byte("ERROR " + utils.ErrUnauthenticated.Error() + "\n"),
+				[]byte("ERROR " + utils.ErrUnauthenticated.Error() + "\n")},
+		},
+		{
+			name: "should fail on malformed AUTH",
+			auth: keyAuth,
+			args: args{[]string{"AUTH"}},
+			want: [][]byte{[]byte("ERROR " + badRequest + "\n")},
+		},
+		{
+			name:       "should rate limit requests beyond the burst",
+			auth:       noAuth,
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			args: args{[]string{"DELETE " + idHexStr + " " + idKeyHexStr,
+				"DELETE " + idHexStr + " " + idKeyHexStr}},
+			want: [][]byte{[]byte("\n"), []byte(rateLimitedResponse)},
+		},
+		{
+			name:       "should rate limit AUTH attempts",
+			auth:       keyAuth,
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			args:       args{[]string{"AUTH unknown-api-key", "AUTH " + storeAPIKey}},
+			want: [][]byte{[]byte("ERROR " + utils.ErrUnauthenticated.Error() + "\n"),
+				[]byte(rateLimitedResponse)},
+		},
+		{
+			name:       "should rate limit requests failing authorization by client IP",
+			auth:       keyAuth,
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			args: args{[]string{"STORE " + idHexStr + " " + recordHexStr,
+				"STORE " + idHexStr + " " + recordHexStr}},
+			want: [][]byte{[]byte("ERROR " + utils.ErrUnauthenticated.Error() + "\n"),
+				[]byte(rateLimitedResponse)},
+		},
+		{
+			name:       "should rate limit configured endpoints only",
+			auth:       noAuth,
+			rateLimits: map[string]string{"rateLimitsFile": rateLimitsFile},
+			args: args{[]string{"STORE " + idHexStr + " " + recordHexStr, "STORE " + idHexStr + " " + recordHexStr,
+				"DELETE " + idHexStr + " " + idKeyHexStr, "DELETE " + idHexStr + " " + idKeyHexStr}},
+			want: [][]byte{[]byte(idKeyHexStr + " " + storedInfo + "\n"), []byte(idKeyHexStr + " " + storedInfo + "\n"),
+				[]byte("\n"), []byte(rateLimitedResponse)},
+		},
+	}
+
+	for _, test := range tests {
+		limiter, err := utils.MakeRateLimiter(test.rateLimits)
+		assert.NoError(t, err)
+
+		s := &serverImpl{
+			keygen:     &MockKeyGen{t, ""},
+			idNonce:    idNonce,
+			idCipher:   idCipher,
+			nameKey:    nameKey,
+			metaCipher: metaCipher,
+
+			compressor: noCompressor,
+			padder:     noPadder,
+			signer:     noSigner,
+			auth:       test.auth,
+			limiter:    limiter,
+			auditor:    noAudit,
+
+			beClient: &MockClient{t, ""},
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			session := s.Session(clientIP)
+			for i, message := range test.args.messages {
+				got := session.Respond(message)
+				assert.Equal(t, test.want[i], got)
+			}
+		})
+	}
+}
+
+// audit() - Test Method
+func TestServer_audit(t *testing.T) {
+	auditFile := filepath.Join(t.TempDir(), "audit.log")
+	auditor, err := utils.MakeAuditor(map[string]string{"auditFile": auditFile, "idKeyStr": idKeyStr}, nil)
+	assert.NoError(t, err)
+
+	s := &serverImpl{
+		keygen:     &MockKeyGen{t, ""},
+		idNonce:    idNonce,
+		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+
+		compressor: noCompressor,
+		padder:     noPadder,
+		signer:     noSigner,
+		auth:       keyAuth,
+		limiter:    noLimits,
+		auditor:    auditor,
+
+		beClient: &MockClient{t, ""},
+	}
+
+	// Record operations, including reads of attributes and record lists, are
+	// audited whether they succeed or not, along with the ID of their request,
+	// while other requests are not.
+	session := s.Session(clientIP).(utils.ContextResponder)
+	for i, message := range []string{
+		"AUTH " + storeAPIKey,
+		"STORE " + idHexStr + " " + recordHexStr,
+		"STAT " + idHexStr,
+		"LIST " + idHexStr,
+		"DELETE " + idHexStr + " " + idKeyHexStr,
+		"RETRIEVE zz " + idKeyHexStr,
+	} {
+		session.RespondContext(utils.ContextWithRequestID(context.Background(), "req-"+strconv.Itoa(i)), message)
+	}
+
+	file, err := os.Open(auditFile)
+	assert.NoError(t, err)
+	defer file.Close()
+	entries, err := utils.ReadAuditLog(file)
+	assert.NoError(t, err)
+
+	recordID := utils.AuditRecordID(idEnc, utils.SealName(idCipher, idKey, id, nil))
+	want := []utils.AuditEvent{
+		{Operation: utils.AuditStore, Principal: "ci", RecordID: recordID, Outcome: utils.AuditOK, RequestID: "req-1"},
+		{Operation: utils.AuditRetrieve, Principal: "ci", RecordID: recordID, Outcome: utils.AuditOK, RequestID: "req-2"},
+		{Operation: utils.AuditRetrieve, Principal: "ci", RecordID: recordID, Outcome: utils.AuditOK, RequestID: "req-3"},
+		{Operation: utils.AuditDelete, Principal: "ci", RecordID: recordID, Outcome: utils.ErrForbidden.Error(),
+			RequestID: "req-4"},
+		{Operation: utils.AuditRetrieve, Principal: "ci", Outcome: "encoding/hex: invalid byte: U+007A 'z'",
+			RequestID: "req-5"},
+	}
+	if assert.Len(t, entries, len(want)) {
+		for i, entry := range entries {
+			assert.Equal(t, want[i], entry.AuditEvent)
+		}
+	}
+
+	// Entries chain from the first, and an altered entry breaks the chain.
+	_, err = utils.VerifyAuditLog(entries, auditKey)
+	assert.NoError(t, err)
+	entries[3].Outcome = utils.AuditOK
+	_, err = utils.VerifyAuditLog(entries, auditKey)
+	assert.Equal(t, errors.New("Audit log entry 4 has been altered"), err)
+}
@@ -5,9 +5,12 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
-	"log"
+	"io"
+	"log/slog"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/service"
@@ -24,87 +27,460 @@ type Dialer interface {
 type clientImpl struct {
 	serverAddr string
 	dialer     Dialer
+
+	// Context of the request making calls, parenting their spans.
+	ctx context.Context
+}
+
+func precondition(match utils.Precondition) *service.Precondition {
+
+	// Omit preconditions that are always satisfied.
+	if match == (utils.Precondition{}) {
+		return nil
+	}
+	return &service.Precondition{
+		IfMatch:     match.IfMatch,
+		IfExists:    match.IfExists,
+		IfNotExists: match.IfNotExists,
+	}
+}
+
+func sendError(err error) error {
+
+	// Failed preconditions, mismatched keys and missing records or versions
+	// are reported with distinct GRPC status codes.
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		return utils.ErrPreconditionFailed
+	case codes.PermissionDenied:
+		return utils.ErrKeyMismatch
+	case codes.NotFound:
+		if status.Convert(err).Message() == utils.ErrVersionNotFound.Error() {
+			return utils.ErrVersionNotFound
+		}
+		return utils.ErrRecordNotFound
+	}
+	return errors.New("Could not send message: " + err.Error())
 }
 
-func (c *clientImpl) StoreRecord(id, data []byte) (err error) {
+func decodeMetadata(metadata string) ([]byte, error) {
+
+	// Records stored without metadata carry none.
+	if metadata == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(metadata)
+}
+
+// Dials the back-end service for a call, traced under the client context and
+// carrying its request ID.
+func (c *clientImpl) dial() (conn *grpc.ClientConn, s service.BackendServiceClient,
+	ctx context.Context, cancel context.CancelFunc, err error) {
+	conn, s, ctx, cancel, err = c.dialer.Dial(c.serverAddr)
+	if err == nil {
+		ctx = utils.ContextWithSpan(ctx, c.ctx)
+		ctx = utils.ContextWithRequestID(ctx, utils.RequestIDFromContext(c.ctx))
+	}
+	return conn, s, ctx, cancel, err
+}
+
+func (c *clientImpl) StoreRecord(id, name, data []byte, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
 	dataStr := hex.EncodeToString(data)
 
-	log.Println("BE client received a store request for", idStr)
+	slog.InfoContext(c.ctx, "BE client received a store request", "id", idStr)
 
 	// GRPC connection
-	conn, s, ctx, cancel, err := c.dialer.Dial(c.serverAddr)
+	conn, s, ctx, cancel, err := c.dial()
 	if err != nil {
-		return errors.New("Error connecting to backend server: " + err.Error())
+		return info, errors.New("Error connecting to backend server: " + err.Error())
 	}
 	defer c.dialer.Close(conn, cancel)
 
 	// Process store request
-	req := &service.StoreRequest{Id: idStr, Data: dataStr}
-	if _, err = s.StoreRecord(ctx, req); err != nil {
-		return errors.New("Could not send message: " + err.Error())
+	req := &service.StoreRequest{
+		Id:         idStr,
+		Name:       nameStr,
+		Data:       dataStr,
+		OneTime:    opts.OneTime,
+		KeyHash:    hex.EncodeToString(opts.KeyHash),
+		Match:      precondition(opts.Match),
+		Metadata:   hex.EncodeToString(opts.Metadata),
+		Signatures: hex.EncodeToString(opts.Signatures),
+	}
+	resp, err := s.StoreRecord(ctx, req)
+	if err != nil {
+		return info, sendError(err)
 	}
 
-	return nil
+	info.Version = resp.Version
+	info.Created, info.Updated = utils.FromUnixMilli(resp.Created), utils.FromUnixMilli(resp.Updated)
+	return info, nil
 }
 
-func (c *clientImpl) RetrieveRecord(id []byte) (data []byte, err error) {
+func (c *clientImpl) RetrieveRecord(id, name []byte, opts utils.RetrieveOptions) (data []byte, info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
 
-	log.Println("BE client received a get request for", idStr)
+	slog.InfoContext(c.ctx, "BE client received a get request", "id", idStr)
 
 	// GRPC connection
-	conn, s, ctx, cancel, err := c.dialer.Dial(c.serverAddr)
+	conn, s, ctx, cancel, err := c.dial()
 	if err != nil {
-		return nil, errors.New("Error connecting to backend server: " + err.Error())
+		return nil, info, errors.New("Error connecting to backend server: " + err.Error())
 	}
 	defer c.dialer.Close(conn, cancel)
 
 	// Process get request
-	req := &service.RetrieveRequest{Id: idStr}
+	req := &service.RetrieveRequest{
+		Id:           idStr,
+		Name:         nameStr,
+		KeyHash:      hex.EncodeToString(opts.KeyHash),
+		Version:      opts.Version,
+		MetadataOnly: opts.MetadataOnly,
+	}
 	resp, err := s.RetrieveRecord(ctx, req)
 	if err != nil {
-		return nil, errors.New("Could not send message: " + err.Error())
+		return nil, info, sendError(err)
 	}
 
-	// Decode record from hex.
+	// Decode record and metadata from hex.
 	if data, err = hex.DecodeString(resp.Data); err != nil {
-		return nil, err
+		return nil, info, err
 	}
+	if info.Metadata, err = decodeMetadata(resp.Metadata); err != nil {
+		return nil, info, err
+	}
+	if info.Signatures, err = decodeMetadata(resp.Signatures); err != nil {
+		return nil, info, err
+	}
+
+	info.Version = resp.Version
+	info.Created, info.Updated = utils.FromUnixMilli(resp.Created), utils.FromUnixMilli(resp.Updated)
+	return data, info, nil
+}
 
-	return data, nil
+func sendChunk(stream service.BackendService_StoreStreamClient, chunk *service.StoreChunk) error {
+
+	// Streams ended by the server report their status on close.
+	err := stream.Send(chunk)
+	if errors.Is(err, io.EOF) {
+		_, err = stream.CloseAndRecv()
+	}
+	if err != nil {
+		return sendError(err)
+	}
+	return nil
 }
 
-func (c *clientImpl) DeleteRecord(id []byte) (err error) {
+func (c *clientImpl) StoreStream(id, name []byte, record io.Reader, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings
 	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
 
-	log.Println("BE client received a delete request for", idStr)
+	slog.InfoContext(c.ctx, "BE client received a store stream request", "id", idStr)
 
 	// GRPC connection
-	conn, s, ctx, cancel, err := c.dialer.Dial(c.serverAddr)
+	conn, s, ctx, cancel, err := c.dial()
+	if err != nil {
+		return info, errors.New("Error connecting to backend server: " + err.Error())
+	}
+	defer c.dialer.Close(conn, cancel)
+
+	// Streams outlive the dial timeout and end with the stream itself.
+	streamCtx, streamCancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer streamCancel()
+
+	stream, err := s.StoreStream(streamCtx)
+	if err != nil {
+		return info, sendError(err)
+	}
+
+	// Send the store request, then record chunks as they are read.
+	req := &service.StoreRequest{
+		Id:         idStr,
+		Name:       nameStr,
+		OneTime:    opts.OneTime,
+		KeyHash:    hex.EncodeToString(opts.KeyHash),
+		Match:      precondition(opts.Match),
+		Metadata:   hex.EncodeToString(opts.Metadata),
+		Signatures: hex.EncodeToString(opts.Signatures),
+	}
+	if err = sendChunk(stream, &service.StoreChunk{Request: req}); err != nil {
+		return info, err
+	}
+	buf := make([]byte, utils.StreamSegmentSize)
+	for {
+		n, err := io.ReadFull(record, buf)
+		if n > 0 {
+			if err := sendChunk(stream, &service.StoreChunk{Data: hex.EncodeToString(buf[:n])}); err != nil {
+				return info, err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return info, errors.New("Error reading record: " + err.Error())
+		}
+	}
+
+	// Metadata sealed once the record is consumed accompanies the final message.
+	if opts.SealMetadata != nil {
+		metadata, err := opts.SealMetadata()
+		if err != nil {
+			return info, err
+		}
+		chunk := &service.StoreChunk{Metadata: hex.EncodeToString(metadata)}
+		if opts.SignMetadata != nil {
+			signatures, err := opts.SignMetadata(metadata)
+			if err != nil {
+				return info, err
+			}
+			chunk.Signatures = hex.EncodeToString(signatures)
+		}
+		if err = sendChunk(stream, chunk); err != nil {
+			return info, err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return info, sendError(err)
+	}
+
+	info.Version = resp.Version
+	info.Created, info.Updated = utils.FromUnixMilli(resp.Created), utils.FromUnixMilli(resp.Updated)
+	return info, nil
+}
+
+// Record chunks received from a retrieve stream.
+type chunkReader struct {
+	stream service.BackendService_RetrieveStreamClient
+	buf    []byte
+	close  func()
+}
+
+func (r *chunkReader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if r.buf, err = hex.DecodeString(chunk.Data); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *chunkReader) Close() error {
+	r.close()
+	return nil
+}
+
+func (c *clientImpl) RetrieveStream(id, name []byte, opts utils.RetrieveOptions) (record io.ReadCloser, info utils.RecordInfo, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
+
+	slog.InfoContext(c.ctx, "BE client received a get stream request", "id", idStr)
+
+	// GRPC connection
+	conn, s, ctx, cancel, err := c.dial()
+	if err != nil {
+		return nil, info, errors.New("Error connecting to backend server: " + err.Error())
+	}
+
+	// Streams outlive the dial timeout and are released when the record is closed.
+	streamCtx, streamCancel := context.WithCancel(context.WithoutCancel(ctx))
+	release := func() {
+		streamCancel()
+		c.dialer.Close(conn, cancel)
+	}
+
+	// Process get request
+	req := &service.RetrieveRequest{
+		Id:           idStr,
+		Name:         nameStr,
+		KeyHash:      hex.EncodeToString(opts.KeyHash),
+		Version:      opts.Version,
+		MetadataOnly: opts.MetadataOnly,
+	}
+	stream, err := s.RetrieveStream(streamCtx, req)
+	if err != nil {
+		release()
+		return nil, info, errors.New("Could not send message: " + err.Error())
+	}
+
+	// The first message carries the record attributes.
+	header, err := stream.Recv()
+	if err != nil {
+		release()
+		return nil, info, sendError(err)
+	}
+	resp := header.GetResponse()
+	if resp == nil {
+		release()
+		return nil, info, errors.New("Missing record attributes")
+	}
+	if info.Metadata, err = decodeMetadata(resp.Metadata); err != nil {
+		release()
+		return nil, info, err
+	}
+	if info.Signatures, err = decodeMetadata(resp.Signatures); err != nil {
+		release()
+		return nil, info, err
+	}
+
+	info.Version = resp.Version
+	info.Created, info.Updated = utils.FromUnixMilli(resp.Created), utils.FromUnixMilli(resp.Updated)
+	return &chunkReader{stream: stream, close: release}, info, nil
+}
+
+func (c *clientImpl) ListVersions(id, name []byte) (versions []int64, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
+
+	slog.InfoContext(c.ctx, "BE client received a list versions request", "id", idStr)
+
+	// GRPC connection
+	conn, s, ctx, cancel, err := c.dial()
+	if err != nil {
+		return nil, errors.New("Error connecting to backend server: " + err.Error())
+	}
+	defer c.dialer.Close(conn, cancel)
+
+	// Process list versions request
+	req := &service.ListVersionsRequest{Id: idStr, Name: nameStr}
+	resp, err := s.ListVersions(ctx, req)
+	if err != nil {
+		return nil, sendError(err)
+	}
+
+	return resp.Versions, nil
+}
+
+func (c *clientImpl) ListRecords(id []byte, opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+
+	slog.InfoContext(c.ctx, "BE client received a list records request", "id", idStr)
+
+	// GRPC connection
+	conn, s, ctx, cancel, err := c.dial()
+	if err != nil {
+		return nil, "", errors.New("Error connecting to backend server: " + err.Error())
+	}
+	defer c.dialer.Close(conn, cancel)
+
+	// Process list records request
+	req := &service.ListRecordsRequest{Id: idStr, Cursor: opts.Cursor, Limit: opts.Limit}
+	resp, err := s.ListRecords(ctx, req)
+	if err != nil {
+		return nil, "", errors.New("Could not send message: " + err.Error())
+	}
+
+	// Decode record names and metadata from hex.
+	for _, summary := range resp.Records {
+		name, err := hex.DecodeString(summary.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		metadata, err := decodeMetadata(summary.Metadata)
+		if err != nil {
+			return nil, "", err
+		}
+		records = append(records, utils.RecordSummary{
+			Name:     name,
+			Size:     summary.Size,
+			Created:  utils.FromUnixMilli(summary.Created),
+			Updated:  utils.FromUnixMilli(summary.Updated),
+			Metadata: metadata,
+		})
+	}
+
+	return records, resp.Next, nil
+}
+
+func (c *clientImpl) DeleteRecord(id, name []byte, opts utils.DeleteOptions) (err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
+
+	slog.InfoContext(c.ctx, "BE client received a delete request", "id", idStr)
+
+	// GRPC connection
+	conn, s, ctx, cancel, err := c.dial()
 	if err != nil {
 		return errors.New("Error connecting to backend server: " + err.Error())
 	}
 	defer c.dialer.Close(conn, cancel)
 
 	// Process delete request
-	req := &service.DeleteRequest{Id: idStr}
+	req := &service.DeleteRequest{Id: idStr, Name: nameStr, Match: precondition(opts.Match)}
 	if _, err = s.DeleteRecord(ctx, req); err != nil {
-		return errors.New("Could not send message: " + err.Error())
+		return sendError(err)
 	}
 
 	return nil
 }
 
+func (c *clientImpl) UpdateMetadata(id, name, prior, metadata, signatures []byte) (info utils.RecordInfo, err error) {
+
+	// Encode data as hex strings
+	idStr := hex.EncodeToString(id)
+	nameStr := hex.EncodeToString(name)
+
+	slog.InfoContext(c.ctx, "BE client received a metadata update request", "id", idStr)
+
+	// GRPC connection
+	conn, s, ctx, cancel, err := c.dial()
+	if err != nil {
+		return info, errors.New("Error connecting to backend server: " + err.Error())
+	}
+	defer c.dialer.Close(conn, cancel)
+
+	// Process metadata update request
+	req := &service.UpdateMetadataRequest{
+		Id:         idStr,
+		Name:       nameStr,
+		Prior:      hex.EncodeToString(prior),
+		Metadata:   hex.EncodeToString(metadata),
+		Signatures: hex.EncodeToString(signatures),
+	}
+	resp, err := s.UpdateMetadata(ctx, req)
+	if err != nil {
+		return info, sendError(err)
+	}
+
+	info.Version, info.Metadata, info.Signatures = resp.Version, metadata, signatures
+	info.Created, info.Updated = utils.FromUnixMilli(resp.Created), utils.FromUnixMilli(resp.Updated)
+	return info, nil
+}
+
+func (c *clientImpl) WithContext(ctx context.Context) utils.ClientBE {
+	traced := *c
+	traced.ctx = ctx
+	return &traced
+}
+
 func MakeClient(configs map[string]string) (c utils.ClientBE, err error) {
 
-	log.Println("BE client MakeClient with configs:", configs)
+	slog.Info("BE client MakeClient", "serverAddr", configs["serverAddr"])
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyConfigs(configs, []string{"serverAddr"}); !ok {
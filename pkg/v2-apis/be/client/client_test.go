@@ -1,13 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/service"
@@ -24,6 +31,11 @@ const badClientMessage = "MakeClient missing configuration serverAddr"
 // Test data for StoreRecord, RetrieveRecord, DeleteRecord tests
 const testID = "test-id"
 const testData = "test-data"
+const testKeyHash = "test-key-hash"
+const testName = "test-name"
+const testCursor = "test-cursor"
+const testMetadata = "test-metadata"
+const testSignatures = "test-signatures"
 
 // Error messages
 const errConnectionFailed = "connection failed"
@@ -33,6 +45,8 @@ const errInvalidHex = "invalid-hex"
 const errConnectingToBackend = "Error connecting to backend server"
 const errCouldNotSendMessage = "Could not send message"
 const errEncodingHex = "encoding/hex"
+const errReadingRecord = "Error reading record"
+const errMissingAttributes = "Missing record attributes"
 
 // Test Variables
 var (
@@ -53,6 +67,11 @@ type mockBackendServiceClient struct {
 	storeRecordFn    func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error)
 	retrieveRecordFn func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error)
 	deleteRecordFn   func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error)
+	listVersionsFn   func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error)
+	listRecordsFn    func(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error)
+	storeStreamFn    func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[service.StoreChunk, service.StoreResponse], error)
+	retrieveStreamFn func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[service.RetrieveChunk], error)
+	updateMetadataFn func(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error)
 }
 
 func (m *mockBackendServiceClient) StoreRecord(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
@@ -76,6 +95,88 @@ func (m *mockBackendServiceClient) DeleteRecord(ctx context.Context, in *service
 	return &service.DeleteResponse{}, nil
 }
 
+func (m *mockBackendServiceClient) ListVersions(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error) {
+	if m.listVersionsFn != nil {
+		return m.listVersionsFn(ctx, in, opts...)
+	}
+	return &service.ListVersionsResponse{}, nil
+}
+
+func (m *mockBackendServiceClient) ListRecords(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error) {
+	if m.listRecordsFn != nil {
+		return m.listRecordsFn(ctx, in, opts...)
+	}
+	return &service.ListRecordsResponse{}, nil
+}
+
+func (m *mockBackendServiceClient) StoreStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[service.StoreChunk, service.StoreResponse], error) {
+	if m.storeStreamFn != nil {
+		return m.storeStreamFn(ctx, opts...)
+	}
+	return &mockStoreStreamClient{reply: &service.StoreResponse{}}, nil
+}
+
+func (m *mockBackendServiceClient) RetrieveStream(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[service.RetrieveChunk], error) {
+	if m.retrieveStreamFn != nil {
+		return m.retrieveStreamFn(ctx, in, opts...)
+	}
+	return &mockRetrieveStreamClient{}, nil
+}
+
+func (m *mockBackendServiceClient) UpdateMetadata(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+	if m.updateMetadataFn != nil {
+		return m.updateMetadataFn(ctx, in, opts...)
+	}
+	return &service.StoreResponse{}, nil
+}
+
+// Mock store stream
+type mockStoreStreamClient struct {
+	grpc.ClientStream
+	sent     []*service.StoreChunk
+	sendErr  error
+	reply    *service.StoreResponse
+	closeErr error
+}
+
+func (m *mockStoreStreamClient) Send(chunk *service.StoreChunk) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.sent = append(m.sent, chunk)
+	return nil
+}
+
+func (m *mockStoreStreamClient) CloseAndRecv() (*service.StoreResponse, error) {
+	return m.reply, m.closeErr
+}
+
+// Mock retrieve stream
+type mockRetrieveStreamClient struct {
+	grpc.ClientStream
+	chunks []*service.RetrieveChunk
+	err    error
+}
+
+func (m *mockRetrieveStreamClient) Recv() (*service.RetrieveChunk, error) {
+	if len(m.chunks) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, io.EOF
+	}
+	chunk := m.chunks[0]
+	m.chunks = m.chunks[1:]
+	return chunk, nil
+}
+
+// Reader failing after any preceding data
+type failingReader struct{}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New(errServiceError)
+}
+
 // Mock Dialer
 type mockDialer struct {
 	dialFn  func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
@@ -136,9 +237,12 @@ func TestClient_StoreRecord(t *testing.T) {
 	tests := []struct {
 		name          string
 		id            []byte
+		recName       []byte
 		data          []byte
+		opts          utils.StoreOptions
 		mockServiceFn func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error)
 		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
+		wantInfo      utils.RecordInfo
 		wantErr       bool
 		errContains   string
 	}{
@@ -150,14 +254,75 @@ func TestClient_StoreRecord(t *testing.T) {
 				// Verify request details
 				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
 				assert.Equal(t, hex.EncodeToString([]byte(testData)), in.Data)
-				return &service.StoreResponse{}, nil
+				assert.False(t, in.OneTime)
+				assert.Empty(t, in.Name)
+				return &service.StoreResponse{Version: 2}, nil
 			},
 			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
 				ctx, cancel := context.WithCancel(context.Background())
 				return nil, mockService, ctx, cancel, nil
 			},
+			wantInfo: utils.RecordInfo{Version: 2},
+			wantErr:  false,
+		},
+		{
+			name:    "should store named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			data:    []byte(testData),
+			mockServiceFn: func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				// Verify name is forwarded
+				assert.Equal(t, hex.EncodeToString([]byte(testName)), in.Name)
+				return &service.StoreResponse{Version: 1}, nil
+			},
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store one-time record successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{OneTime: true, KeyHash: []byte(testKeyHash)},
+			mockServiceFn: func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				// Verify one-time options are forwarded
+				assert.True(t, in.OneTime)
+				assert.Equal(t, hex.EncodeToString([]byte(testKeyHash)), in.KeyHash)
+				return &service.StoreResponse{Version: 1}, nil
+			},
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store record metadata successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{Metadata: []byte(testMetadata), Signatures: []byte(testSignatures)},
+			mockServiceFn: func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				// Verify sealed metadata and signatures are forwarded
+				assert.Equal(t, hex.EncodeToString([]byte(testMetadata)), in.Metadata)
+				assert.Equal(t, hex.EncodeToString([]byte(testSignatures)), in.Signatures)
+				return &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000}, nil
+			},
+			wantInfo: utils.RecordInfo{
+				Version: 1,
+				Created: time.UnixMilli(1700000000000),
+				Updated: time.UnixMilli(1700000600000),
+			},
 			wantErr: false,
 		},
+		{
+			name: "should fail on mismatched revision",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{Match: utils.Precondition{IfMatch: 2}},
+			mockServiceFn: func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				// Verify preconditions are forwarded
+				assert.Equal(t, int64(2), in.Match.GetIfMatch())
+				return nil, status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
+		},
 		{
 			name: "should fail on dialer error",
 			id:   []byte(testID),
@@ -210,13 +375,14 @@ func TestClient_StoreRecord(t *testing.T) {
 				dialer:     mockDialerObj,
 			}
 
-			err := client.StoreRecord(test.id, test.data)
+			got, err := client.StoreRecord(test.id, test.recName, test.data, test.opts)
 
 			if test.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), test.errContains)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, test.wantInfo, got)
 			}
 		})
 	}
@@ -227,11 +393,15 @@ func TestClient_RetrieveRecord(t *testing.T) {
 	tests := []struct {
 		name          string
 		id            []byte
+		recName       []byte
+		opts          utils.RetrieveOptions
 		mockServiceFn func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error)
 		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
 		wantData      []byte
+		wantInfo      utils.RecordInfo
 		wantErr       bool
 		errContains   string
+		wantErrIs     error
 	}{
 		{
 			name: "should retrieve record successfully",
@@ -250,6 +420,105 @@ func TestClient_RetrieveRecord(t *testing.T) {
 			wantData: []byte(testData),
 			wantErr:  false,
 		},
+		{
+			name:    "should retrieve named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				// Verify name is forwarded
+				assert.Equal(t, hex.EncodeToString([]byte(testName)), in.Name)
+				return &service.RetrieveResponse{
+					Data:    hex.EncodeToString([]byte(testData)),
+					Version: 1,
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should forward key commitment",
+			id:   []byte(testID),
+			opts: utils.RetrieveOptions{KeyHash: []byte(testKeyHash), Version: 3},
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				assert.Equal(t, hex.EncodeToString([]byte(testKeyHash)), in.KeyHash)
+				assert.Equal(t, int64(3), in.Version)
+				return &service.RetrieveResponse{
+					Data:    hex.EncodeToString([]byte(testData)),
+					Version: 3,
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 3},
+			wantErr:  false,
+		},
+		{
+			name: "should fail on mismatched key commitment",
+			id:   []byte(testID),
+			opts: utils.RetrieveOptions{KeyHash: []byte(testKeyHash)},
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				return nil, status.Error(codes.PermissionDenied, utils.ErrKeyMismatch.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name: "should fail on missing record",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				return nil, status.Error(codes.NotFound, utils.ErrRecordNotFound.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrRecordNotFound.Error(),
+			wantErrIs:   utils.ErrRecordNotFound,
+		},
+		{
+			name: "should fail on unretained version",
+			id:   []byte(testID),
+			opts: utils.RetrieveOptions{Version: 2},
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				return nil, status.Error(codes.NotFound, utils.ErrVersionNotFound.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrVersionNotFound.Error(),
+			wantErrIs:   utils.ErrVersionNotFound,
+		},
+		{
+			name: "should retrieve record metadata only",
+			id:   []byte(testID),
+			opts: utils.RetrieveOptions{MetadataOnly: true},
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				assert.True(t, in.MetadataOnly)
+				return &service.RetrieveResponse{
+					Version:    1,
+					Metadata:   hex.EncodeToString([]byte(testMetadata)),
+					Signatures: hex.EncodeToString([]byte(testSignatures)),
+					Created:    1700000000000,
+					Updated:    1700000600000,
+				}, nil
+			},
+			wantData: []byte{},
+			wantInfo: utils.RecordInfo{
+				Version:    1,
+				Created:    time.UnixMilli(1700000000000),
+				Updated:    time.UnixMilli(1700000600000),
+				Metadata:   []byte(testMetadata),
+				Signatures: []byte(testSignatures),
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail on invalid hex metadata",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (*service.RetrieveResponse, error) {
+				return &service.RetrieveResponse{
+					Data:     hex.EncodeToString([]byte(testData)),
+					Metadata: errInvalidHex,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: errEncodingHex,
+		},
 		{
 			name: "should fail on dialer error",
 			id:   []byte(testID),
@@ -315,62 +584,117 @@ func TestClient_RetrieveRecord(t *testing.T) {
 				dialer:     mockDialerObj,
 			}
 
-			got, err := client.RetrieveRecord(test.id)
+			got, info, err := client.RetrieveRecord(test.id, test.recName, test.opts)
 
 			if test.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), test.errContains)
+				if test.wantErrIs != nil {
+					assert.ErrorIs(t, err, test.wantErrIs)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, test.wantData, got)
+				assert.Equal(t, test.wantInfo, info)
 			}
 		})
 	}
 }
 
-// DeleteRecord() - Test Method
-func TestClient_DeleteRecord(t *testing.T) {
+// StoreStream() - Test Method
+func TestClient_StoreStream(t *testing.T) {
+
+	large := bytes.Repeat([]byte{'a'}, utils.StreamSegmentSize+3)
+	request := &service.StoreRequest{Id: hex.EncodeToString([]byte(testID))}
+	reply := &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000}
+	sealMetadata := func() ([]byte, error) { return []byte(testMetadata), nil }
+	signMetadata := func(metadata []byte) ([]byte, error) {
+		assert.Equal(t, []byte(testMetadata), metadata)
+		return []byte(testSignatures), nil
+	}
+
 	tests := []struct {
-		name          string
-		id            []byte
-		mockServiceFn func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error)
-		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
-		wantErr       bool
-		errContains   string
+		name        string
+		record      io.Reader
+		opts        utils.StoreOptions
+		stream      *mockStoreStreamClient
+		streamErr   error
+		dialErr     error
+		wantSent    []*service.StoreChunk
+		wantInfo    utils.RecordInfo
+		wantErr     bool
+		errContains string
 	}{
 		{
-			name: "should delete record successfully",
-			id:   []byte(testID),
-			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
-				// Verify request details
-				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
-				return &service.DeleteResponse{}, nil
+			name:   "should store record stream successfully",
+			record: strings.NewReader(testData),
+			opts:   utils.StoreOptions{SealMetadata: sealMetadata, SignMetadata: signMetadata},
+			stream: &mockStoreStreamClient{reply: reply},
+			wantSent: []*service.StoreChunk{
+				{Request: request},
+				{Data: hex.EncodeToString([]byte(testData))},
+				{Metadata: hex.EncodeToString([]byte(testMetadata)), Signatures: hex.EncodeToString([]byte(testSignatures))},
 			},
-			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
-				ctx, cancel := context.WithCancel(context.Background())
-				return nil, mockService, ctx, cancel, nil
+			wantInfo: utils.RecordInfo{
+				Version: 1,
+				Created: time.UnixMilli(1700000000000),
+				Updated: time.UnixMilli(1700000600000),
 			},
-			wantErr: false,
 		},
 		{
-			name: "should fail on dialer error",
-			id:   []byte(testID),
-			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
-				return nil, nil, nil, nil, errors.New(errConnectionFailed)
+			name:   "should split records into segments",
+			record: bytes.NewReader(large),
+			stream: &mockStoreStreamClient{reply: &service.StoreResponse{Version: 2}},
+			wantSent: []*service.StoreChunk{
+				{Request: request},
+				{Data: hex.EncodeToString(large[:utils.StreamSegmentSize])},
+				{Data: hex.EncodeToString(large[utils.StreamSegmentSize:])},
 			},
+			wantInfo: utils.RecordInfo{Version: 2},
+		},
+		{
+			name:        "should fail on dialer error",
+			record:      strings.NewReader(testData),
+			dialErr:     errors.New(errConnectionFailed),
 			wantErr:     true,
 			errContains: errConnectingToBackend,
 		},
 		{
-			name: "should fail on service error",
-			id:   []byte(testID),
-			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
+			name:        "should fail on stream error",
+			record:      strings.NewReader(testData),
+			streamErr:   errors.New(errServiceError),
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+		{
+			name:   "should fail on precondition reported by ended stream",
+			record: strings.NewReader(testData),
+			stream: &mockStoreStreamClient{sendErr: io.EOF,
+				closeErr: status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error())},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name:        "should fail on record read error",
+			record:      io.MultiReader(strings.NewReader(testData), &failingReader{}),
+			stream:      &mockStoreStreamClient{reply: reply},
+			wantErr:     true,
+			errContains: errReadingRecord,
+		},
+		{
+			name:   "should fail on metadata sealing error",
+			record: strings.NewReader(testData),
+			opts: utils.StoreOptions{SealMetadata: func() ([]byte, error) {
 				return nil, errors.New(errServiceError)
-			},
-			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
-				ctx, cancel := context.WithCancel(context.Background())
-				return nil, mockService, ctx, cancel, nil
-			},
+			}},
+			stream:      &mockStoreStreamClient{reply: reply},
+			wantErr:     true,
+			errContains: errServiceError,
+		},
+		{
+			name:        "should fail on close error",
+			record:      strings.NewReader(testData),
+			stream:      &mockStoreStreamClient{closeErr: errors.New(errServiceError)},
 			wantErr:     true,
 			errContains: errCouldNotSendMessage,
 		},
@@ -379,18 +703,171 @@ func TestClient_DeleteRecord(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			mockService := &mockBackendServiceClient{
-				deleteRecordFn: test.mockServiceFn,
+				storeStreamFn: func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[service.StoreChunk, service.StoreResponse], error) {
+					if test.streamErr != nil {
+						return nil, test.streamErr
+					}
+					return test.stream, nil
+				},
 			}
 
 			mockDialerObj := &mockDialer{
 				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
-					if test.mockDialerFn != nil {
-						return test.mockDialerFn(serverAddr, mockService)
+					if test.dialErr != nil {
+						return nil, nil, nil, nil, test.dialErr
+					}
+					ctx, cancel := context.WithCancel(context.Background())
+					return nil, mockService, ctx, cancel, nil
+				},
+				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					if cancel != nil {
+						cancel()
+					}
+				},
+			}
+
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer:     mockDialerObj,
+			}
+
+			info, err := client.StoreStream([]byte(testID), nil, test.record, test.opts)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantSent, test.stream.sent)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// RetrieveStream() - Test Method
+func TestClient_RetrieveStream(t *testing.T) {
+
+	header := &service.RetrieveChunk{Response: &service.RetrieveResponse{
+		Version:    1,
+		Metadata:   hex.EncodeToString([]byte(testMetadata)),
+		Signatures: hex.EncodeToString([]byte(testSignatures)),
+		Created:    1700000000000,
+		Updated:    1700000600000,
+	}}
+	info := utils.RecordInfo{
+		Version:    1,
+		Created:    time.UnixMilli(1700000000000),
+		Updated:    time.UnixMilli(1700000600000),
+		Metadata:   []byte(testMetadata),
+		Signatures: []byte(testSignatures),
+	}
+
+	tests := []struct {
+		name        string
+		opts        utils.RetrieveOptions
+		stream      *mockRetrieveStreamClient
+		streamErr   error
+		dialErr     error
+		wantData    []byte
+		wantInfo    utils.RecordInfo
+		wantErr     bool
+		errContains string
+		readErr     string
+	}{
+		{
+			name: "should retrieve record stream successfully",
+			opts: utils.RetrieveOptions{KeyHash: []byte(testKeyHash)},
+			stream: &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{header,
+				{Data: hex.EncodeToString([]byte(testData[:4]))},
+				{Data: hex.EncodeToString([]byte(testData[4:]))},
+			}},
+			wantData: []byte(testData),
+			wantInfo: info,
+		},
+		{
+			name:     "should retrieve record stream attributes only",
+			opts:     utils.RetrieveOptions{MetadataOnly: true},
+			stream:   &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{header}},
+			wantData: []byte{},
+			wantInfo: info,
+		},
+		{
+			name:        "should fail on dialer error",
+			dialErr:     errors.New(errConnectionFailed),
+			wantErr:     true,
+			errContains: errConnectingToBackend,
+		},
+		{
+			name:        "should fail on stream error",
+			streamErr:   errors.New(errServiceError),
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+		{
+			name:        "should fail on retrieve error",
+			stream:      &mockRetrieveStreamClient{err: errors.New(errServiceError)},
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+		{
+			name: "should fail on missing attributes",
+			stream: &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{
+				{Data: hex.EncodeToString([]byte(testData))},
+			}},
+			wantErr:     true,
+			errContains: errMissingAttributes,
+		},
+		{
+			name: "should fail on invalid hex metadata",
+			stream: &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{
+				{Response: &service.RetrieveResponse{Metadata: errInvalidHex}},
+			}},
+			wantErr:     true,
+			errContains: errEncodingHex,
+		},
+		{
+			name: "should fail reading invalid hex chunks",
+			stream: &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{header,
+				{Data: errInvalidHex},
+			}},
+			wantInfo: info,
+			readErr:  errEncodingHex,
+		},
+		{
+			name: "should fail reading interrupted stream",
+			stream: &mockRetrieveStreamClient{chunks: []*service.RetrieveChunk{header},
+				err: errors.New(errServiceError)},
+			wantInfo: info,
+			readErr:  errServiceError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := &mockBackendServiceClient{
+				retrieveStreamFn: func(ctx context.Context, in *service.RetrieveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[service.RetrieveChunk], error) {
+					assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
+					assert.Equal(t, hex.EncodeToString(test.opts.KeyHash), in.KeyHash)
+					assert.Equal(t, test.opts.MetadataOnly, in.MetadataOnly)
+					if test.streamErr != nil {
+						return nil, test.streamErr
+					}
+					return test.stream, nil
+				},
+			}
+
+			closed := false
+			mockDialerObj := &mockDialer{
+				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+					if test.dialErr != nil {
+						return nil, nil, nil, nil, test.dialErr
 					}
 					ctx, cancel := context.WithCancel(context.Background())
 					return nil, mockService, ctx, cancel, nil
 				},
 				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					closed = true
 					if cancel != nil {
 						cancel()
 					}
@@ -402,14 +879,515 @@ func TestClient_DeleteRecord(t *testing.T) {
 				dialer:     mockDialerObj,
 			}
 
-			err := client.DeleteRecord(test.id)
+			record, info, err := client.RetrieveStream([]byte(testID), nil, test.opts)
 
 			if test.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), test.errContains)
+				assert.Nil(t, record)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantInfo, info)
+
+			got, err := io.ReadAll(record)
+			if test.readErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.readErr)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, test.wantData, got)
 			}
+
+			// Closing the record releases the connection.
+			assert.False(t, closed)
+			assert.NoError(t, record.Close())
+			assert.True(t, closed)
+		})
+	}
+}
+
+// ListVersions() - Test Method
+func TestClient_ListVersions(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            []byte
+		recName       []byte
+		mockServiceFn func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error)
+		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
+		wantVersions  []int64
+		wantErr       bool
+		errContains   string
+		wantErrIs     error
+	}{
+		{
+			name: "should list versions successfully",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error) {
+				// Verify request details
+				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
+				return &service.ListVersionsResponse{Versions: []int64{3, 2, 1}}, nil
+			},
+			wantVersions: []int64{3, 2, 1},
+			wantErr:      false,
+		},
+		{
+			name:    "should list named record versions successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockServiceFn: func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error) {
+				// Verify name is forwarded
+				assert.Equal(t, hex.EncodeToString([]byte(testName)), in.Name)
+				return &service.ListVersionsResponse{Versions: []int64{1}}, nil
+			},
+			wantVersions: []int64{1},
+			wantErr:      false,
+		},
+		{
+			name: "should fail on dialer error",
+			id:   []byte(testID),
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				return nil, nil, nil, nil, errors.New(errConnectionFailed)
+			},
+			wantErr:     true,
+			errContains: errConnectingToBackend,
+		},
+		{
+			name: "should fail on service error",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error) {
+				return nil, errors.New(errServiceError)
+			},
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+		{
+			name: "should fail on missing record",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.ListVersionsRequest, opts ...grpc.CallOption) (*service.ListVersionsResponse, error) {
+				return nil, status.Error(codes.NotFound, utils.ErrRecordNotFound.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrRecordNotFound.Error(),
+			wantErrIs:   utils.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := &mockBackendServiceClient{
+				listVersionsFn: test.mockServiceFn,
+			}
+
+			mockDialerObj := &mockDialer{
+				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+					if test.mockDialerFn != nil {
+						return test.mockDialerFn(serverAddr, mockService)
+					}
+					ctx, cancel := context.WithCancel(context.Background())
+					return nil, mockService, ctx, cancel, nil
+				},
+				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					if cancel != nil {
+						cancel()
+					}
+				},
+			}
+
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer:     mockDialerObj,
+			}
+
+			got, err := client.ListVersions(test.id, test.recName)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				if test.wantErrIs != nil {
+					assert.ErrorIs(t, err, test.wantErrIs)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantVersions, got)
+			}
+		})
+	}
+}
+
+// ListRecords() - Test Method
+func TestClient_ListRecords(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            []byte
+		opts          utils.ListOptions
+		mockServiceFn func(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error)
+		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
+		wantRecords   []utils.RecordSummary
+		wantNext      string
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name: "should list records successfully",
+			id:   []byte(testID),
+			opts: utils.ListOptions{Cursor: testCursor, Limit: 1},
+			mockServiceFn: func(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error) {
+				// Verify request details
+				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
+				assert.Equal(t, testCursor, in.Cursor)
+				assert.Equal(t, int64(1), in.Limit)
+				return &service.ListRecordsResponse{
+					Records: []*service.RecordSummary{{
+						Name:     hex.EncodeToString([]byte(testName)),
+						Size:     44,
+						Created:  1700000000000,
+						Metadata: hex.EncodeToString([]byte(testMetadata)),
+					}},
+					Next: testCursor,
+				}, nil
+			},
+			wantRecords: []utils.RecordSummary{{
+				Name:     []byte(testName),
+				Size:     44,
+				Created:  time.UnixMilli(1700000000000),
+				Metadata: []byte(testMetadata),
+			}},
+			wantNext: testCursor,
+			wantErr:  false,
+		},
+		{
+			name: "should fail on dialer error",
+			id:   []byte(testID),
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				return nil, nil, nil, nil, errors.New(errConnectionFailed)
+			},
+			wantErr:     true,
+			errContains: errConnectingToBackend,
+		},
+		{
+			name: "should fail on service error",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error) {
+				return nil, errors.New(errServiceError)
+			},
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+		{
+			name: "should fail on invalid hex name",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.ListRecordsRequest, opts ...grpc.CallOption) (*service.ListRecordsResponse, error) {
+				return &service.ListRecordsResponse{
+					Records: []*service.RecordSummary{{Name: errInvalidHex}},
+				}, nil
+			},
+			wantErr:     true,
+			errContains: errEncodingHex,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := &mockBackendServiceClient{
+				listRecordsFn: test.mockServiceFn,
+			}
+
+			mockDialerObj := &mockDialer{
+				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+					if test.mockDialerFn != nil {
+						return test.mockDialerFn(serverAddr, mockService)
+					}
+					ctx, cancel := context.WithCancel(context.Background())
+					return nil, mockService, ctx, cancel, nil
+				},
+				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					if cancel != nil {
+						cancel()
+					}
+				},
+			}
+
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer:     mockDialerObj,
+			}
+
+			got, next, err := client.ListRecords(test.id, test.opts)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantRecords, got)
+				assert.Equal(t, test.wantNext, next)
+			}
+		})
+	}
+}
+
+// DeleteRecord() - Test Method
+func TestClient_DeleteRecord(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            []byte
+		recName       []byte
+		opts          utils.DeleteOptions
+		mockServiceFn func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error)
+		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name: "should delete record successfully",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
+				// Verify request details
+				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
+				assert.Nil(t, in.Match)
+				return &service.DeleteResponse{}, nil
+			},
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				return nil, mockService, ctx, cancel, nil
+			},
+			wantErr: false,
+		},
+		{
+			name:    "should delete named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
+				// Verify name is forwarded
+				assert.Equal(t, hex.EncodeToString([]byte(testName)), in.Name)
+				return &service.DeleteResponse{}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail on mismatched revision",
+			id:   []byte(testID),
+			opts: utils.DeleteOptions{Match: utils.Precondition{IfExists: true}},
+			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
+				// Verify preconditions are forwarded
+				assert.True(t, in.Match.GetIfExists())
+				return nil, status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error())
+			},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name: "should fail on dialer error",
+			id:   []byte(testID),
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				return nil, nil, nil, nil, errors.New(errConnectionFailed)
+			},
+			wantErr:     true,
+			errContains: errConnectingToBackend,
+		},
+		{
+			name: "should fail on service error",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.DeleteRequest, opts ...grpc.CallOption) (*service.DeleteResponse, error) {
+				return nil, errors.New(errServiceError)
+			},
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				return nil, mockService, ctx, cancel, nil
+			},
+			wantErr:     true,
+			errContains: errCouldNotSendMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := &mockBackendServiceClient{
+				deleteRecordFn: test.mockServiceFn,
+			}
+
+			mockDialerObj := &mockDialer{
+				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+					if test.mockDialerFn != nil {
+						return test.mockDialerFn(serverAddr, mockService)
+					}
+					ctx, cancel := context.WithCancel(context.Background())
+					return nil, mockService, ctx, cancel, nil
+				},
+				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					if cancel != nil {
+						cancel()
+					}
+				},
+			}
+
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer:     mockDialerObj,
+			}
+
+			err := client.DeleteRecord(test.id, test.recName, test.opts)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// UpdateMetadata() - Test Method
+func TestClient_UpdateMetadata(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            []byte
+		recName       []byte
+		mockServiceFn func(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error)
+		mockDialerFn  func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error)
+		wantInfo      utils.RecordInfo
+		wantErr       error
+		errContains   string
+	}{
+		{
+			name:    "should update record metadata successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockServiceFn: func(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				// Verify request details
+				assert.Equal(t, hex.EncodeToString([]byte(testID)), in.Id)
+				assert.Equal(t, hex.EncodeToString([]byte(testName)), in.Name)
+				assert.Equal(t, hex.EncodeToString([]byte(testKeyHash)), in.Prior)
+				assert.Equal(t, hex.EncodeToString([]byte(testMetadata)), in.Metadata)
+				assert.Equal(t, hex.EncodeToString([]byte(testSignatures)), in.Signatures)
+				return &service.StoreResponse{Version: 2}, nil
+			},
+			wantInfo: utils.RecordInfo{Version: 2, Metadata: []byte(testMetadata), Signatures: []byte(testSignatures)},
+		},
+		{
+			name: "should fail on changed prior metadata",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				return nil, status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error())
+			},
+			wantErr: utils.ErrPreconditionFailed,
+		},
+		{
+			name: "should fail on dialer error",
+			id:   []byte(testID),
+			mockDialerFn: func(serverAddr string, mockService service.BackendServiceClient) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+				return nil, nil, nil, nil, errors.New(errConnectionFailed)
+			},
+			errContains: errConnectingToBackend,
+		},
+		{
+			name: "should fail on service error",
+			id:   []byte(testID),
+			mockServiceFn: func(ctx context.Context, in *service.UpdateMetadataRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+				return nil, errors.New(errServiceError)
+			},
+			errContains: errCouldNotSendMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockService := &mockBackendServiceClient{
+				updateMetadataFn: test.mockServiceFn,
+			}
+
+			mockDialerObj := &mockDialer{
+				dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+					if test.mockDialerFn != nil {
+						return test.mockDialerFn(serverAddr, mockService)
+					}
+					ctx, cancel := context.WithCancel(context.Background())
+					return nil, mockService, ctx, cancel, nil
+				},
+				closeFn: func(conn *grpc.ClientConn, cancel context.CancelFunc) {
+					if cancel != nil {
+						cancel()
+					}
+				},
+			}
+
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer:     mockDialerObj,
+			}
+
+			info, err := client.UpdateMetadata(test.id, test.recName, []byte(testKeyHash), []byte(testMetadata),
+				[]byte(testSignatures))
+
+			if test.errContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				return
+			}
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.wantInfo, info)
+		})
+	}
+}
+
+// WithContext() - Test Method
+func TestClient_WithContext(t *testing.T) {
+
+	// Span of the request making calls
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	requestCtx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantSpan trace.SpanContext
+	}{
+		{
+			name:     "should trace calls under the request span",
+			ctx:      requestCtx,
+			wantSpan: spanContext,
+		},
+		{
+			name: "should trace calls without a request span as new traces",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var callCtx context.Context
+			mockService := &mockBackendServiceClient{
+				storeRecordFn: func(ctx context.Context, in *service.StoreRequest, opts ...grpc.CallOption) (*service.StoreResponse, error) {
+					callCtx = ctx
+					return &service.StoreResponse{Version: 1}, nil
+				},
+			}
+
+			// Calls keep the deadline of the dialed context.
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				dialer: &mockDialer{
+					dialFn: func(serverAddr string) (*grpc.ClientConn, service.BackendServiceClient, context.Context, context.CancelFunc, error) {
+						ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+						return nil, mockService, ctx, cancel, nil
+					},
+				},
+			}
+
+			_, err := client.WithContext(test.ctx).StoreRecord([]byte(testID), nil, []byte(testData), utils.StoreOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantSpan, trace.SpanContextFromContext(callCtx))
+			_, hasDeadline := callCtx.Deadline()
+			assert.True(t, hasDeadline)
+
+			// The client itself is left untraced.
+			assert.Nil(t, client.ctx)
 		})
 	}
 }
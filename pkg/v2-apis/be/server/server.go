@@ -3,10 +3,13 @@ package server
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"net"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/service"
@@ -22,47 +25,313 @@ type serverImpl struct {
 	service.UnimplementedBackendServiceServer
 	db         utils.DB
 	serverAddr string
+
+	// Port serving metrics, if any.
+	metricsPort string
+}
+
+func precondition(match *service.Precondition) utils.Precondition {
+
+	// Unset preconditions are always satisfied.
+	return utils.Precondition{
+		IfMatch:     match.GetIfMatch(),
+		IfExists:    match.GetIfExists(),
+		IfNotExists: match.GetIfNotExists(),
+	}
+}
+
+func statusError(err error) error {
+
+	// Surface failed preconditions, mismatched keys and missing records or
+	// versions with distinct GRPC status codes.
+	if errors.Is(err, utils.ErrPreconditionFailed) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	} else if errors.Is(err, utils.ErrKeyMismatch) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	} else if errors.Is(err, utils.ErrVersionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	} else if errors.Is(err, mongo.ErrNoDocuments) {
+		return status.Error(codes.NotFound, utils.ErrRecordNotFound.Error())
+	}
+	return err
 }
 
 func (s *serverImpl) StoreRecord(ctx context.Context, req *service.StoreRequest) (*service.StoreResponse, error) {
-	log.Println("BE server received a store request for", req.Id)
+	slog.InfoContext(ctx, "BE server received a store request", "id", req.Id)
 
-	if err := s.db.StoreRecord(req.Id, req.Data); err != nil {
-		log.Println("BE server StoreRecord error:", err)
-		return nil, err
+	entry := utils.Entry{
+		Id:         req.Id,
+		Name:       req.Name,
+		Record:     req.Data,
+		OneTime:    req.OneTime,
+		KeyHash:    req.KeyHash,
+		Metadata:   req.Metadata,
+		Signatures: req.Signatures,
+	}
+	stored, err := s.db.WithContext(ctx).StoreRecord(entry, precondition(req.Match))
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server StoreRecord error", "error", err)
+		return nil, statusError(err)
 	}
 
-	return &service.StoreResponse{}, nil
+	reply := &service.StoreResponse{
+		Version: stored.Version,
+		Created: utils.UnixMilli(stored.Created),
+		Updated: utils.UnixMilli(stored.Updated),
+	}
+	return reply, nil
 }
 
 func (s *serverImpl) RetrieveRecord(ctx context.Context, req *service.RetrieveRequest) (*service.RetrieveResponse, error) {
 
-	log.Println("BE server received a get request for", req.Id)
+	slog.InfoContext(ctx, "BE server received a get request", "id", req.Id)
 
-	record, err := s.db.RetrieveRecord(req.Id)
+	// Attribute requests never read record contents.
+	db := s.db.WithContext(ctx)
+	var entry utils.Entry
+	var err error
+	if req.MetadataOnly {
+		entry, err = db.StatRecord(req.Id, req.Name, req.Version)
+	} else {
+		entry, err = db.RetrieveRecord(req.Id, req.Name, req.KeyHash, req.Version)
+	}
 	if err != nil {
-		log.Println("BE server RetrieveRecord error:", err)
-		return nil, err
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server RetrieveRecord error", "error", err)
+		return nil, statusError(err)
+	}
+
+	// Streamed records are read back from their chunks, unless only their
+	// attributes are requested, so one-time records are not consumed.
+	var data string
+	if !req.MetadataOnly {
+		if data, err = utils.LoadRecord(db, entry); err != nil {
+			slog.Log(ctx, utils.ErrorLevel(err), "BE server RetrieveRecord error", "error", err)
+			return nil, err
+		}
 	}
 
 	reply := &service.RetrieveResponse{
-		Data: record,
+		Data:       data,
+		Version:    entry.Version,
+		Metadata:   entry.Metadata,
+		Signatures: entry.Signatures,
+		Created:    utils.UnixMilli(entry.Created),
+		Updated:    utils.UnixMilli(entry.Updated),
 	}
 	return reply, nil
 }
 
-func (s *serverImpl) DeleteRecord(ctx context.Context, req *service.DeleteRequest) (*service.DeleteResponse, error) {
+func (s *serverImpl) StoreStream(stream service.BackendService_StoreStreamServer) error {
+
+	// Data store calls are traced under the stream.
+	ctx := stream.Context()
+	db := s.db.WithContext(ctx)
+
+	// The first message carries the store request.
+	first, err := stream.Recv()
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server StoreStream error", "error", err)
+		return err
+	}
+	req := first.GetRequest()
+	if req == nil {
+		slog.WarnContext(ctx, "BE server StoreStream error", "error", "missing store request")
+		return status.Error(codes.InvalidArgument, "missing store request")
+	}
+
+	slog.InfoContext(ctx, "BE server received a store stream request", "id", req.Id)
+
+	streamID, err := utils.NewStreamID()
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server StoreStream error", "error", err)
+		return err
+	}
+
+	// Store chunks as they arrive. Metadata and signatures may accompany
+	// any chunk.
+	var size int64
+	metadata, signatures := req.Metadata, req.Signatures
+	chunks, err := db.StoreChunks(streamID, func() (string, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return "", err
+		}
+		if msg.Metadata != "" {
+			metadata = msg.Metadata
+		}
+		if msg.Signatures != "" {
+			signatures = msg.Signatures
+		}
+		size += int64(len(msg.Data) / 2)
+		return msg.Data, nil
+	})
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server StoreStream error", "error", err)
+		s.releaseStream(ctx, streamID)
+		return err
+	}
 
-	log.Println("BE server received a delete request for", req.Id)
+	entry := utils.Entry{
+		Id:         req.Id,
+		Name:       req.Name,
+		OneTime:    req.OneTime,
+		KeyHash:    req.KeyHash,
+		Metadata:   metadata,
+		Signatures: signatures,
+		Stream:     streamID,
+		Chunks:     chunks,
+		Size:       size,
+	}
+	stored, err := db.StoreRecord(entry, precondition(req.Match))
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server StoreStream error", "error", err)
+		s.releaseStream(ctx, streamID)
+		return statusError(err)
+	}
 
-	if err := s.db.DeleteRecord(req.Id); err != nil {
-		log.Println("BE server DeleteRecord error:", err)
+	reply := &service.StoreResponse{
+		Version: stored.Version,
+		Created: utils.UnixMilli(stored.Created),
+		Updated: utils.UnixMilli(stored.Updated),
+	}
+	return stream.SendAndClose(reply)
+}
+
+func (s *serverImpl) releaseStream(ctx context.Context, streamID string) {
+
+	// Chunks of streams that were never stored are released.
+	if err := s.db.WithContext(ctx).DeleteChunks([]string{streamID}); err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server chunk cleanup error", "error", err)
+	}
+}
+
+func (s *serverImpl) RetrieveStream(req *service.RetrieveRequest, stream service.BackendService_RetrieveStreamServer) error {
+
+	// Data store calls are traced under the stream.
+	ctx := stream.Context()
+	db := s.db.WithContext(ctx)
+
+	slog.InfoContext(ctx, "BE server received a get stream request", "id", req.Id)
+
+	// Attribute requests never read record contents.
+	var entry utils.Entry
+	var err error
+	if req.MetadataOnly {
+		entry, err = db.StatRecord(req.Id, req.Name, req.Version)
+	} else {
+		entry, err = db.RetrieveRecord(req.Id, req.Name, req.KeyHash, req.Version)
+	}
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server RetrieveStream error", "error", err)
+		return statusError(err)
+	}
+
+	// The first message carries the record attributes.
+	header := &service.RetrieveChunk{Response: &service.RetrieveResponse{
+		Version:    entry.Version,
+		Metadata:   entry.Metadata,
+		Signatures: entry.Signatures,
+		Created:    utils.UnixMilli(entry.Created),
+		Updated:    utils.UnixMilli(entry.Updated),
+	}}
+	if err = stream.Send(header); err != nil || req.MetadataOnly {
+		return err
+	}
+
+	// Records stored whole are split into chunks.
+	if entry.Stream == "" {
+		for data := entry.Record; len(data) > 0; {
+			chunk := data[:min(len(data), 2*utils.StreamSegmentSize)]
+			data = data[len(chunk):]
+			if err = stream.Send(&service.RetrieveChunk{Data: chunk}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Chunks of consumed one-time records are released once read.
+	if entry.OneTime {
+		defer s.releaseStream(ctx, entry.Stream)
+	}
+	err = db.RetrieveChunks(entry.Stream, entry.Chunks, func(data string) error {
+		return stream.Send(&service.RetrieveChunk{Data: data})
+	})
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server RetrieveStream error", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *serverImpl) ListVersions(ctx context.Context, req *service.ListVersionsRequest) (*service.ListVersionsResponse, error) {
+
+	slog.InfoContext(ctx, "BE server received a list versions request", "id", req.Id)
+
+	versions, err := s.db.WithContext(ctx).ListVersions(req.Id, req.Name)
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server ListVersions error", "error", err)
+		return nil, statusError(err)
+	}
+
+	return &service.ListVersionsResponse{Versions: versions}, nil
+}
+
+func (s *serverImpl) ListRecords(ctx context.Context, req *service.ListRecordsRequest) (*service.ListRecordsResponse, error) {
+
+	slog.InfoContext(ctx, "BE server received a list records request", "id", req.Id)
+
+	entries, next, err := s.db.WithContext(ctx).ListRecords(req.Id, req.Cursor, req.Limit)
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server ListRecords error", "error", err)
 		return nil, err
 	}
 
+	reply := &service.ListRecordsResponse{Next: next}
+	for _, entry := range entries {
+		reply.Records = append(reply.Records, &service.RecordSummary{
+			Name:     entry.Name,
+			Size:     entry.Size,
+			Created:  utils.UnixMilli(entry.Created),
+			Updated:  utils.UnixMilli(entry.Updated),
+			Metadata: entry.Metadata,
+		})
+	}
+
+	return reply, nil
+}
+
+func (s *serverImpl) DeleteRecord(ctx context.Context, req *service.DeleteRequest) (*service.DeleteResponse, error) {
+
+	slog.InfoContext(ctx, "BE server received a delete request", "id", req.Id)
+
+	if err := s.db.WithContext(ctx).DeleteRecord(req.Id, req.Name, precondition(req.Match)); err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server DeleteRecord error", "error", err)
+		return nil, statusError(err)
+	}
+
 	return &service.DeleteResponse{}, nil
 }
 
+func (s *serverImpl) UpdateMetadata(ctx context.Context, req *service.UpdateMetadataRequest) (*service.StoreResponse, error) {
+
+	slog.InfoContext(ctx, "BE server received a metadata update request", "id", req.Id)
+
+	updated, err := s.db.WithContext(ctx).UpdateMetadata(req.Id, req.Name, req.Prior, req.Metadata, req.Signatures)
+	if err != nil {
+		slog.Log(ctx, utils.ErrorLevel(err), "BE server UpdateMetadata error", "error", err)
+		return nil, statusError(err)
+	}
+
+	reply := &service.StoreResponse{
+		Version: updated.Version,
+		Created: utils.UnixMilli(updated.Created),
+		Updated: utils.UnixMilli(updated.Updated),
+	}
+	return reply, nil
+}
+
 func (s *serverImpl) Start() (err error) {
 
 	// Listen on TCP port
@@ -71,11 +340,21 @@ func (s *serverImpl) Start() (err error) {
 		return errors.New("Failed to listen: " + err.Error())
 	}
 
-	// Create and register server
-	g := grpc.NewServer()
+	// Serve metrics on their own port when configured.
+	if err = utils.StartMetricsListener(s.metricsPort); err != nil {
+		return errors.New("Failed to serve metrics: " + err.Error())
+	}
+
+	// Create and register server, carrying the request ID, recording metrics
+	// and tracing each request
+	g := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(utils.RequestIDUnaryServerInterceptor,
+			utils.MetricsUnaryServerInterceptor, utils.TracingUnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(utils.RequestIDStreamServerInterceptor,
+			utils.MetricsStreamServerInterceptor, utils.TracingStreamServerInterceptor))
 	service.RegisterBackendServiceServer(g, s)
 
-	log.Println("Listening and serving GRPC on", s.serverAddr)
+	slog.Info("Listening and serving GRPC", "serverAddr", s.serverAddr)
 
 	if err := g.Serve(lis); err != nil {
 		return errors.New("Failed to serve: " + err.Error())
@@ -86,7 +365,7 @@ func (s *serverImpl) Start() (err error) {
 
 func MakeServer(configs map[string]string) (s Server, err error) {
 
-	log.Println("BE server MakeServer with configs:", configs)
+	slog.Info("BE server MakeServer", "port", configs["port"], "maxVersions", configs["maxVersions"])
 
 	// Build data store wrapper.
 	db, err := utils.MakeDB(configs)
@@ -105,6 +384,8 @@ func MakeServer(configs map[string]string) (s Server, err error) {
 	si := &serverImpl{
 		db:         db,
 		serverAddr: ":" + configs["port"],
+
+		metricsPort: configs["metricsPort"],
 	}
 
 	return si, nil
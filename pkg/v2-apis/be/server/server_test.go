@@ -3,12 +3,19 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/service"
@@ -19,6 +26,11 @@ const port = "8888"
 const mongoURI = "mongodb://localhost:27017"
 
 const idHexEncStr = "396263343233393039616335acc30dd405c51d37675d4e0002a526ae113d56"
+const nameHexEncStr = "6c9a1f3e5b7d20c4a8e6f1d3b5c7e9a0"
+const keyHashHexStr = "2f8ad1bd3e2be0a2c7e6bb4a4e0b5e6e2e7d2b8d5e0c1f3a7b9c4d6e8f0a1b2c"
+const metadataHexStr = "3962633432333930396163353a4c0e1f2d3b5a69788796a5b4c3d2e1"
+const signaturesHexStr = "7b22656e76656c6f7065223a7b7d7d"
+const streamID = "5f3c8e2a9b1d4f60a7c2e8d1b3f5a9c0"
 
 const recordHexEncStr = "396263343233393039616335b6d61c6839a0dda2524d19b4e5d" +
 	"ac5a1fda8902ad2701ced5c31c89088c3151d039ee27d003b75c3a140141c05da496572142eb" +
@@ -39,10 +51,23 @@ const failedToListenMessage = "Failed to listen"
 // MockDB failure modes
 const mockDBFailStore = "Store"
 const mockDBFailRetrieve = "Retrieve"
+const mockDBFailStat = "Stat"
 const mockDBFailDelete = "Delete"
+const mockDBFailVersions = "Versions"
+const mockDBFailList = "List"
+const mockDBFailStreamed = "Streamed"
+const mockDBFailChunks = "Chunks"
+const mockDBFailStoreChunks = "StoreChunks"
+const mockDBFailMismatch = "Mismatch"
+const mockDBFailMetadata = "Metadata"
+const mockDBFailOneTimeStreamed = "OneTimeStreamed"
+const mockDBFailMissing = "Missing"
 
 // Test Variables
 var (
+	created = time.UnixMilli(1700000000000)
+	updated = time.UnixMilli(1700000600000)
+
 	goodDBConfig = map[string]string{
 		"port":     port,
 		"mongoURI": mongoURI,
@@ -79,31 +104,224 @@ type MockDB struct {
 	fail string
 }
 
-func (db *MockDB) StoreRecord(id, record string) (err error) {
+func (db *MockDB) StoreRecord(entry utils.Entry, match utils.Precondition) (stored utils.Entry, err error) {
 	if db.fail == mockDBFailStore {
-		return errors.New(badDBClientMessage)
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	} else if match.IfMatch > 1 {
+		return utils.Entry{}, utils.ErrPreconditionFailed
 	}
-	assert.Equal(db.t, idHexEncStr, id)
-	assert.Equal(db.t, recordHexEncStr, record)
-	return nil
+	assert.Equal(db.t, idHexEncStr, entry.Id)
+	if entry.Stream != "" {
+		assert.Equal(db.t, int64(2), entry.Chunks)
+		assert.Equal(db.t, int64(len(recordHexEncStr)/2), entry.Size)
+	} else {
+		assert.Equal(db.t, recordHexEncStr, entry.Record)
+	}
+	if entry.Name != "" {
+		assert.Equal(db.t, nameHexEncStr, entry.Name)
+	}
+	if entry.OneTime {
+		assert.Equal(db.t, keyHashHexStr, entry.KeyHash)
+	}
+	if entry.Metadata != "" {
+		assert.Equal(db.t, metadataHexStr, entry.Metadata)
+	}
+	if entry.Signatures != "" {
+		assert.Equal(db.t, signaturesHexStr, entry.Signatures)
+	}
+	return utils.Entry{Version: 1, Created: created, Updated: updated}, nil
 }
 
-func (db *MockDB) RetrieveRecord(id string) (record string, err error) {
+func (db *MockDB) RetrieveRecord(id, name, keyHash string, version int64) (entry utils.Entry, err error) {
 	if db.fail == mockDBFailRetrieve {
-		return "", errors.New(badDBClientMessage)
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	} else if db.fail == mockDBFailMismatch {
+		return utils.Entry{}, utils.ErrKeyMismatch
+	} else if db.fail == mockDBFailMissing {
+		return utils.Entry{}, mongo.ErrNoDocuments
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	if keyHash != "" {
+		assert.Equal(db.t, keyHashHexStr, keyHash)
+	}
+	if version > 1 {
+		return utils.Entry{}, utils.ErrVersionNotFound
+	}
+	if db.fail == mockDBFailStreamed || db.fail == mockDBFailChunks {
+		return utils.Entry{Id: id, Name: name, Stream: streamID, Chunks: 2, Version: 1,
+			Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+	}
+	return utils.Entry{Id: id, Name: name, Record: recordHexEncStr, Version: 1,
+		Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+}
+
+func (db *MockDB) StatRecord(id, name string, version int64) (entry utils.Entry, err error) {
+	if db.fail == mockDBFailStat {
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	if version > 1 {
+		return utils.Entry{}, utils.ErrVersionNotFound
+	}
+	if db.fail == mockDBFailOneTimeStreamed {
+		return utils.Entry{Id: id, Name: name, Stream: streamID, Chunks: 2, OneTime: true, Version: 1,
+			Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+	}
+	return utils.Entry{Id: id, Name: name, Version: 1,
+		Metadata: metadataHexStr, Signatures: signaturesHexStr, Created: created, Updated: updated}, nil
+}
+
+func (db *MockDB) ListVersions(id, name string) (versions []int64, err error) {
+	if db.fail == mockDBFailVersions {
+		return nil, errors.New(badDBClientMessage)
+	} else if db.fail == mockDBFailMissing {
+		return nil, mongo.ErrNoDocuments
 	}
 	assert.Equal(db.t, idHexEncStr, id)
-	return recordHexEncStr, nil
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	return []int64{3, 2, 1}, nil
 }
 
-func (db *MockDB) DeleteRecord(id string) (err error) {
+func (db *MockDB) DeleteRecord(id, name string, match utils.Precondition) (err error) {
 	if db.fail == mockDBFailDelete {
 		return errors.New(badDBClientMessage)
+	} else if match.IfMatch > 1 {
+		return utils.ErrPreconditionFailed
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	return nil
+}
+
+func (db *MockDB) ListRecords(id, cursor string, limit int64) (entries []utils.Entry, next string, err error) {
+	if db.fail == mockDBFailList {
+		return nil, "", errors.New(badDBClientMessage)
 	}
 	assert.Equal(db.t, idHexEncStr, id)
+	entries = []utils.Entry{{Name: nameHexEncStr, Size: 44, Metadata: metadataHexStr,
+		Created: created, Updated: updated}}
+	if limit == 1 && cursor == "" {
+		return entries, nameHexEncStr, nil
+	}
+	return entries, "", nil
+}
+
+func (db *MockDB) UpdateMetadata(id, name, prior, metadata, signatures string) (entry utils.Entry, err error) {
+	if db.fail == mockDBFailMetadata {
+		return utils.Entry{}, errors.New(badDBClientMessage)
+	} else if prior != metadataHexStr {
+		return utils.Entry{}, utils.ErrPreconditionFailed
+	}
+	assert.Equal(db.t, idHexEncStr, id)
+	if name != "" {
+		assert.Equal(db.t, nameHexEncStr, name)
+	}
+	assert.Equal(db.t, metadataHexStr, metadata)
+	assert.Equal(db.t, signaturesHexStr, signatures)
+	return utils.Entry{Version: 1, Metadata: metadata, Signatures: signatures, Created: created, Updated: updated}, nil
+}
+
+func (db *MockDB) StoreChunks(stream string, next func() (data string, err error)) (chunks int64, err error) {
+	if db.fail == mockDBFailStoreChunks {
+		return 0, errors.New(badDBClientMessage)
+	}
+	var record string
+	for {
+		data, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return chunks, err
+		}
+		if data != "" {
+			record += data
+			chunks++
+		}
+	}
+	assert.Equal(db.t, recordHexEncStr, record)
+	return chunks, nil
+}
+
+func (db *MockDB) RetrieveChunks(stream string, chunks int64, fn func(data string) error) (err error) {
+	if db.fail == mockDBFailOneTimeStreamed {
+		assert.Fail(db.t, "one-time record chunks read")
+	}
+	if db.fail == mockDBFailChunks {
+		return utils.ErrChunkMissing
+	}
+	assert.Equal(db.t, streamID, stream)
+	half := len(recordHexEncStr) / 2
+	if err = fn(recordHexEncStr[:half]); err != nil {
+		return err
+	}
+	return fn(recordHexEncStr[half:])
+}
+
+func (db *MockDB) DeleteChunks(streams []string) (err error) {
+	if db.fail == mockDBFailOneTimeStreamed {
+		assert.Fail(db.t, "one-time record chunks deleted")
+	}
+	return errors.New(badDBClientMessage)
+}
+
+func (db *MockDB) WithContext(ctx context.Context) utils.DB {
+	return db
+}
+
+// Mock store stream
+type mockStoreStream struct {
+	grpc.ServerStream
+	chunks []*service.StoreChunk
+	reply  *service.StoreResponse
+}
+
+func (m *mockStoreStream) Recv() (*service.StoreChunk, error) {
+	if len(m.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := m.chunks[0]
+	m.chunks = m.chunks[1:]
+	return chunk, nil
+}
+
+func (m *mockStoreStream) SendAndClose(reply *service.StoreResponse) error {
+	m.reply = reply
+	return nil
+}
+
+func (m *mockStoreStream) Context() context.Context {
+	return context.Background()
+}
+
+// Mock retrieve stream
+type mockRetrieveStream struct {
+	grpc.ServerStream
+	sent []*service.RetrieveChunk
+	ctx  context.Context
+}
+
+func (m *mockRetrieveStream) Send(chunk *service.RetrieveChunk) error {
+	m.sent = append(m.sent, chunk)
 	return nil
 }
 
+func (m *mockRetrieveStream) Context() context.Context {
+	if m.ctx != nil {
+		return m.ctx
+	}
+	return context.Background()
+}
+
 // MakeServer() - Test Method
 func TestServer_MakeServer(t *testing.T) {
 
@@ -171,7 +389,61 @@ func TestServer_StoreRecord(t *testing.T) {
 					Data: recordHexEncStr,
 				},
 			},
-			want: &service.StoreResponse{},
+			want: &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000},
+		}, {
+			name: "should run named StoreRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.StoreRequest{
+					Id:   idHexEncStr,
+					Name: nameHexEncStr,
+					Data: recordHexEncStr,
+				},
+			},
+			want: &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000},
+		}, {
+			name: "should run StoreRecord() with metadata successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.StoreRequest{
+					Id:         idHexEncStr,
+					Data:       recordHexEncStr,
+					Metadata:   metadataHexStr,
+					Signatures: signaturesHexStr,
+				},
+			},
+			want: &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000},
+		}, {
+			name: "should run one-time StoreRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.StoreRequest{
+					Id:      idHexEncStr,
+					Data:    recordHexEncStr,
+					OneTime: true,
+					KeyHash: keyHashHexStr,
+				},
+			},
+			want: &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000},
+		}, {
+			name: "should fail on mismatched StoreRecord() revision",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.StoreRequest{
+					Id:    idHexEncStr,
+					Data:  recordHexEncStr,
+					Match: &service.Precondition{IfMatch: 2},
+				},
+			},
+			wantErr: status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error()),
 		}, {
 			name: "should fail on database client StoreRecord()",
 			fields: fields{
@@ -227,8 +499,152 @@ func TestServer_RetrieveRecord(t *testing.T) {
 				},
 			},
 			want: &service.RetrieveResponse{
-				Data: recordHexEncStr,
+				Data:       recordHexEncStr,
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should run RetrieveRecord() with key commitment successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:      idHexEncStr,
+					KeyHash: keyHashHexStr,
+				},
+			},
+			want: &service.RetrieveResponse{
+				Data:       recordHexEncStr,
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should run named RetrieveRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:   idHexEncStr,
+					Name: nameHexEncStr,
+				},
+			},
+			want: &service.RetrieveResponse{
+				Data:       recordHexEncStr,
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should run metadata-only RetrieveRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, mockDBFailRetrieve},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:           idHexEncStr,
+					Name:         nameHexEncStr,
+					MetadataOnly: true,
+				},
+			},
+			want: &service.RetrieveResponse{
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should not read one-time streamed records on metadata-only RetrieveRecord()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailOneTimeStreamed},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:           idHexEncStr,
+					MetadataOnly: true,
+				},
+			},
+			want: &service.RetrieveResponse{
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should fail on database client metadata-only RetrieveRecord()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailStat},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:           idHexEncStr,
+					MetadataOnly: true,
+				},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		}, {
+			name: "should fail on unretained RetrieveRecord() version",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:      idHexEncStr,
+					Version: 2,
+				},
+			},
+			wantErr: status.Error(codes.NotFound, utils.ErrVersionNotFound.Error()),
+		}, {
+			name: "should fail on missing RetrieveRecord() record",
+			fields: fields{
+				db: &MockDB{t, mockDBFailMissing},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id: idHexEncStr,
+				},
+			},
+			wantErr: status.Error(codes.NotFound, utils.ErrRecordNotFound.Error()),
+		}, {
+			name: "should run streamed RetrieveRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, mockDBFailStreamed},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id: idHexEncStr,
+				},
+			},
+			want: &service.RetrieveResponse{
+				Data:       recordHexEncStr,
+				Version:    1,
+				Metadata:   metadataHexStr,
+				Signatures: signaturesHexStr,
+				Created:    1700000000000,
+				Updated:    1700000600000,
+			},
+		}, {
+			name: "should fail on missing RetrieveRecord() chunks",
+			fields: fields{
+				db: &MockDB{t, mockDBFailChunks},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id: idHexEncStr,
+				},
 			},
+			wantErr: utils.ErrChunkMissing,
 		}, {
 			name: "should fail on database client RetrieveRecord()",
 			fields: fields{
@@ -240,6 +656,18 @@ func TestServer_RetrieveRecord(t *testing.T) {
 				},
 			},
 			wantErr: errors.New(badDBClientMessage),
+		}, {
+			name: "should fail on mismatched RetrieveRecord() key commitment",
+			fields: fields{
+				db: &MockDB{t, mockDBFailMismatch},
+			},
+			args: args{
+				req: &service.RetrieveRequest{
+					Id:      idHexEncStr,
+					KeyHash: keyHashHexStr,
+				},
+			},
+			wantErr: status.Error(codes.PermissionDenied, utils.ErrKeyMismatch.Error()),
 		},
 	}
 
@@ -256,42 +684,80 @@ func TestServer_RetrieveRecord(t *testing.T) {
 	}
 }
 
-// DeleteRecord() - Test Method
-func TestServer_DeleteRecord(t *testing.T) {
+// StoreStream() - Test Method
+func TestServer_StoreStream(t *testing.T) {
+
+	half := len(recordHexEncStr) / 2
+	request := func(match *service.Precondition) []*service.StoreChunk {
+		return []*service.StoreChunk{
+			{Request: &service.StoreRequest{Id: idHexEncStr, Name: nameHexEncStr, Match: match}},
+			{Data: recordHexEncStr[:half]},
+			{Data: recordHexEncStr[half:], Metadata: metadataHexStr, Signatures: signaturesHexStr},
+		}
+	}
 
 	type fields struct {
 		db utils.DB
 	}
 	type args struct {
-		req *service.DeleteRequest
+		chunks []*service.StoreChunk
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    *service.DeleteResponse
+		want    *service.StoreResponse
 		wantErr error
 	}{
 		{
-			name: "should run DeleteRecord() successfully",
+			name: "should run StoreStream() successfully",
 			fields: fields{
 				db: &MockDB{t, ""},
 			},
 			args: args{
-				req: &service.DeleteRequest{
-					Id: idHexEncStr,
-				},
+				chunks: request(nil),
 			},
-			want: &service.DeleteResponse{},
+			want: &service.StoreResponse{Version: 1, Created: 1700000000000, Updated: 1700000600000},
 		}, {
-			name: "should fail on database client DeleteRecord()",
+			name: "should fail on empty StoreStream()",
 			fields: fields{
-				db: &MockDB{t, mockDBFailDelete},
+				db: &MockDB{t, ""},
+			},
+			wantErr: io.EOF,
+		}, {
+			name: "should fail on missing StoreStream() request",
+			fields: fields{
+				db: &MockDB{t, ""},
 			},
 			args: args{
-				req: &service.DeleteRequest{
-					Id: idHexEncStr,
-				},
+				chunks: request(nil)[1:],
+			},
+			wantErr: status.Error(codes.InvalidArgument, "missing store request"),
+		}, {
+			name: "should fail on database client StoreStream() chunks",
+			fields: fields{
+				db: &MockDB{t, mockDBFailStoreChunks},
+			},
+			args: args{
+				chunks: request(nil),
+			},
+			wantErr: errors.New(badDBClientMessage),
+		}, {
+			name: "should fail on mismatched StoreStream() revision",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				chunks: request(&service.Precondition{IfMatch: 2}),
+			},
+			wantErr: status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error()),
+		}, {
+			name: "should fail on database client StoreStream()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailStore},
+			},
+			args: args{
+				chunks: request(nil),
 			},
 			wantErr: errors.New(badDBClientMessage),
 		},
@@ -303,32 +769,443 @@ func TestServer_DeleteRecord(t *testing.T) {
 		}
 
 		t.Run(test.name, func(t *testing.T) {
-			got, err := s.DeleteRecord(context.TODO(), test.args.req)
-			assert.Equal(t, test.want, got)
+			stream := &mockStoreStream{chunks: test.args.chunks}
+			err := s.StoreStream(stream)
+			assert.Equal(t, test.want, stream.reply)
 			assert.Equal(t, test.wantErr, err)
 		})
 	}
 }
 
-// Mock Listener for testing Start()
-type mockListener struct {
-	acceptFn func() (net.Conn, error)
-	closeFn  func() error
-	addrFn   func() net.Addr
-}
+// RetrieveStream() - Test Method
+func TestServer_RetrieveStream(t *testing.T) {
 
-func (ml *mockListener) Accept() (net.Conn, error) {
-	if ml.acceptFn != nil {
-		return ml.acceptFn()
-	}
-	return nil, errors.New("")
-}
+	half := len(recordHexEncStr) / 2
+	header := &service.RetrieveChunk{Response: &service.RetrieveResponse{
+		Version:    1,
+		Metadata:   metadataHexStr,
+		Signatures: signaturesHexStr,
+		Created:    1700000000000,
+		Updated:    1700000600000,
+	}}
 
-func (ml *mockListener) Close() error {
-	if ml.closeFn != nil {
-		return ml.closeFn()
+	type fields struct {
+		db utils.DB
 	}
-	return nil
+	type args struct {
+		req *service.RetrieveRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*service.RetrieveChunk
+		wantErr error
+	}{
+		{
+			name: "should run RetrieveStream() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.RetrieveRequest{Id: idHexEncStr},
+			},
+			want: []*service.RetrieveChunk{header, {Data: recordHexEncStr}},
+		}, {
+			name: "should run streamed RetrieveStream() successfully",
+			fields: fields{
+				db: &MockDB{t, mockDBFailStreamed},
+			},
+			args: args{
+				req: &service.RetrieveRequest{Id: idHexEncStr, Name: nameHexEncStr},
+			},
+			want: []*service.RetrieveChunk{header,
+				{Data: recordHexEncStr[:half]}, {Data: recordHexEncStr[half:]}},
+		}, {
+			name: "should run metadata-only RetrieveStream() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.RetrieveRequest{Id: idHexEncStr, MetadataOnly: true},
+			},
+			want: []*service.RetrieveChunk{header},
+		}, {
+			name: "should fail on missing RetrieveStream() chunks",
+			fields: fields{
+				db: &MockDB{t, mockDBFailChunks},
+			},
+			args: args{
+				req: &service.RetrieveRequest{Id: idHexEncStr},
+			},
+			want:    []*service.RetrieveChunk{header},
+			wantErr: utils.ErrChunkMissing,
+		}, {
+			name: "should fail on database client RetrieveStream()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailRetrieve},
+			},
+			args: args{
+				req: &service.RetrieveRequest{Id: idHexEncStr},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		},
+	}
+
+	for _, test := range tests {
+		s := &serverImpl{
+			db: test.fields.db,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			stream := &mockRetrieveStream{}
+			err := s.RetrieveStream(test.args.req, stream)
+			assert.Equal(t, test.want, stream.sent)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// ListVersions() - Test Method
+func TestServer_ListVersions(t *testing.T) {
+
+	type fields struct {
+		db utils.DB
+	}
+	type args struct {
+		req *service.ListVersionsRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *service.ListVersionsResponse
+		wantErr error
+	}{
+		{
+			name: "should run ListVersions() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.ListVersionsRequest{
+					Id: idHexEncStr,
+				},
+			},
+			want: &service.ListVersionsResponse{
+				Versions: []int64{3, 2, 1},
+			},
+		}, {
+			name: "should run named ListVersions() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.ListVersionsRequest{
+					Id:   idHexEncStr,
+					Name: nameHexEncStr,
+				},
+			},
+			want: &service.ListVersionsResponse{
+				Versions: []int64{3, 2, 1},
+			},
+		}, {
+			name: "should fail on database client ListVersions()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailVersions},
+			},
+			args: args{
+				req: &service.ListVersionsRequest{
+					Id: idHexEncStr,
+				},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		}, {
+			name: "should fail on missing ListVersions() record",
+			fields: fields{
+				db: &MockDB{t, mockDBFailMissing},
+			},
+			args: args{
+				req: &service.ListVersionsRequest{
+					Id: idHexEncStr,
+				},
+			},
+			wantErr: status.Error(codes.NotFound, utils.ErrRecordNotFound.Error()),
+		},
+	}
+
+	for _, test := range tests {
+		s := &serverImpl{
+			db: test.fields.db,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.ListVersions(context.TODO(), test.args.req)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// ListRecords() - Test Method
+func TestServer_ListRecords(t *testing.T) {
+
+	type fields struct {
+		db utils.DB
+	}
+	type args struct {
+		req *service.ListRecordsRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *service.ListRecordsResponse
+		wantErr error
+	}{
+		{
+			name: "should run ListRecords() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.ListRecordsRequest{
+					Id: idHexEncStr,
+				},
+			},
+			want: &service.ListRecordsResponse{
+				Records: []*service.RecordSummary{{
+					Name:     nameHexEncStr,
+					Size:     44,
+					Created:  1700000000000,
+					Updated:  1700000600000,
+					Metadata: metadataHexStr,
+				}},
+			},
+		}, {
+			name: "should return next cursor",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.ListRecordsRequest{
+					Id:    idHexEncStr,
+					Limit: 1,
+				},
+			},
+			want: &service.ListRecordsResponse{
+				Records: []*service.RecordSummary{{
+					Name:     nameHexEncStr,
+					Size:     44,
+					Created:  1700000000000,
+					Updated:  1700000600000,
+					Metadata: metadataHexStr,
+				}},
+				Next: nameHexEncStr,
+			},
+		}, {
+			name: "should fail on database client ListRecords()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailList},
+			},
+			args: args{
+				req: &service.ListRecordsRequest{
+					Id: idHexEncStr,
+				},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		},
+	}
+
+	for _, test := range tests {
+		s := &serverImpl{
+			db: test.fields.db,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.ListRecords(context.TODO(), test.args.req)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// DeleteRecord() - Test Method
+func TestServer_DeleteRecord(t *testing.T) {
+
+	type fields struct {
+		db utils.DB
+	}
+	type args struct {
+		req *service.DeleteRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *service.DeleteResponse
+		wantErr error
+	}{
+		{
+			name: "should run DeleteRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.DeleteRequest{
+					Id: idHexEncStr,
+				},
+			},
+			want: &service.DeleteResponse{},
+		}, {
+			name: "should run named DeleteRecord() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.DeleteRequest{
+					Id:   idHexEncStr,
+					Name: nameHexEncStr,
+				},
+			},
+			want: &service.DeleteResponse{},
+		}, {
+			name: "should fail on mismatched DeleteRecord() revision",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.DeleteRequest{
+					Id:    idHexEncStr,
+					Match: &service.Precondition{IfMatch: 2},
+				},
+			},
+			wantErr: status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error()),
+		}, {
+			name: "should fail on database client DeleteRecord()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailDelete},
+			},
+			args: args{
+				req: &service.DeleteRequest{
+					Id: idHexEncStr,
+				},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		},
+	}
+
+	for _, test := range tests {
+		s := &serverImpl{
+			db: test.fields.db,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.DeleteRecord(context.TODO(), test.args.req)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// UpdateMetadata() - Test Method
+func TestServer_UpdateMetadata(t *testing.T) {
+
+	type fields struct {
+		db utils.DB
+	}
+	type args struct {
+		req *service.UpdateMetadataRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *service.StoreResponse
+		wantErr error
+	}{
+		{
+			name: "should run UpdateMetadata() successfully",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.UpdateMetadataRequest{
+					Id:         idHexEncStr,
+					Name:       nameHexEncStr,
+					Prior:      metadataHexStr,
+					Metadata:   metadataHexStr,
+					Signatures: signaturesHexStr,
+				},
+			},
+			want: &service.StoreResponse{
+				Version: 1,
+				Created: 1700000000000,
+				Updated: 1700000600000,
+			},
+		}, {
+			name: "should fail on changed UpdateMetadata() prior metadata",
+			fields: fields{
+				db: &MockDB{t, ""},
+			},
+			args: args{
+				req: &service.UpdateMetadataRequest{
+					Id:         idHexEncStr,
+					Prior:      keyHashHexStr,
+					Metadata:   metadataHexStr,
+					Signatures: signaturesHexStr,
+				},
+			},
+			wantErr: status.Error(codes.FailedPrecondition, utils.ErrPreconditionFailed.Error()),
+		}, {
+			name: "should fail on database client UpdateMetadata()",
+			fields: fields{
+				db: &MockDB{t, mockDBFailMetadata},
+			},
+			args: args{
+				req: &service.UpdateMetadataRequest{
+					Id:         idHexEncStr,
+					Prior:      metadataHexStr,
+					Metadata:   metadataHexStr,
+					Signatures: signaturesHexStr,
+				},
+			},
+			wantErr: errors.New(badDBClientMessage),
+		},
+	}
+
+	for _, test := range tests {
+		s := &serverImpl{
+			db: test.fields.db,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.UpdateMetadata(context.TODO(), test.args.req)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+// Mock Listener for testing Start()
+type mockListener struct {
+	acceptFn func() (net.Conn, error)
+	closeFn  func() error
+	addrFn   func() net.Addr
+}
+
+func (ml *mockListener) Accept() (net.Conn, error) {
+	if ml.acceptFn != nil {
+		return ml.acceptFn()
+	}
+	return nil, errors.New("")
+}
+
+func (ml *mockListener) Close() error {
+	if ml.closeFn != nil {
+		return ml.closeFn()
+	}
+	return nil
 }
 
 func (ml *mockListener) Addr() net.Addr {
@@ -338,6 +1215,72 @@ func (ml *mockListener) Addr() net.Addr {
 	return nil
 }
 
+// Mock DB recording the context its calls are traced under
+type tracedDB struct {
+	*MockDB
+	ctx context.Context
+}
+
+func (db *tracedDB) WithContext(ctx context.Context) utils.DB {
+	db.ctx = ctx
+	return db
+}
+
+// Tracing interceptors - Test Method
+func TestServer_tracing(t *testing.T) {
+
+	// Trace context passed by the front-end service
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	incoming := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01"))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantTraceID string
+	}{
+		{
+			name:        "should trace data store calls under the caller trace",
+			ctx:         incoming,
+			wantTraceID: traceID,
+		},
+		{
+			name:        "should leave requests without trace context untraced",
+			ctx:         context.Background(),
+			wantTraceID: "00000000000000000000000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := &tracedDB{MockDB: &MockDB{t, ""}}
+			server := &serverImpl{db: db}
+
+			// Unary requests
+			info := &grpc.UnaryServerInfo{FullMethod: "/service.BackendService/StoreRecord"}
+			req := &service.StoreRequest{Id: idHexEncStr, Data: recordHexEncStr}
+			_, err := utils.TracingUnaryServerInterceptor(test.ctx, req, info,
+				func(ctx context.Context, req any) (any, error) {
+					return server.StoreRecord(ctx, req.(*service.StoreRequest))
+				})
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantTraceID, trace.SpanContextFromContext(db.ctx).TraceID().String())
+
+			// Streamed requests
+			db.ctx = nil
+			stream := &mockRetrieveStream{ctx: test.ctx}
+			streamInfo := &grpc.StreamServerInfo{FullMethod: "/service.BackendService/RetrieveStream"}
+			err = utils.TracingStreamServerInterceptor(server, stream, streamInfo,
+				func(srv any, ss grpc.ServerStream) error {
+					stream.ctx = ss.Context()
+					return server.RetrieveStream(&service.RetrieveRequest{Id: idHexEncStr}, stream)
+				})
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantTraceID, trace.SpanContextFromContext(db.ctx).TraceID().String())
+		})
+	}
+}
+
 // Start() - Test Method
 func TestServer_Start(t *testing.T) {
 	tests := []struct {
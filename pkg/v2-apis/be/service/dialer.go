@@ -7,6 +7,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"enc-server-go/pkg/utils"
 )
 
 // Dialer interface for dependency injection
@@ -23,7 +25,11 @@ func (d *RealDialer) Dial(serverAddr string) (conn *grpc.ClientConn, s BackendSe
 	ctx context.Context, cancel context.CancelFunc, err error) {
 
 	// GRPC connection
-	conn, err = grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err = grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(utils.RequestIDUnaryClientInterceptor,
+			utils.MetricsUnaryClientInterceptor, utils.TracingUnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(utils.RequestIDStreamClientInterceptor,
+			utils.MetricsStreamClientInterceptor, utils.TracingStreamClientInterceptor))
 	if err != nil {
 		return nil, nil, nil, nil, errors.New("Error connecting to backend server: " + err.Error())
 	}
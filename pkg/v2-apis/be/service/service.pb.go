@@ -21,17 +21,85 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type StoreRequest struct {
+type Precondition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	IfMatch       int64                  `protobuf:"varint,1,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	IfExists      bool                   `protobuf:"varint,2,opt,name=if_exists,json=ifExists,proto3" json:"if_exists,omitempty"`
+	IfNotExists   bool                   `protobuf:"varint,3,opt,name=if_not_exists,json=ifNotExists,proto3" json:"if_not_exists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Precondition) Reset() {
+	*x = Precondition{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Precondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Precondition) ProtoMessage() {}
+
+func (x *Precondition) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Precondition.ProtoReflect.Descriptor instead.
+func (*Precondition) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Precondition) GetIfMatch() int64 {
+	if x != nil {
+		return x.IfMatch
+	}
+	return 0
+}
+
+func (x *Precondition) GetIfExists() bool {
+	if x != nil {
+		return x.IfExists
+	}
+	return false
+}
+
+func (x *Precondition) GetIfNotExists() bool {
+	if x != nil {
+		return x.IfNotExists
+	}
+	return false
+}
+
+type StoreRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data    string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	OneTime bool                   `protobuf:"varint,3,opt,name=one_time,json=oneTime,proto3" json:"one_time,omitempty"`
+	KeyHash string                 `protobuf:"bytes,4,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	Match   *Precondition          `protobuf:"bytes,5,opt,name=match,proto3" json:"match,omitempty"`
+	Name    string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Record metadata sealed by the front-end service.
+	Metadata string `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Record signatures encoded by the front-end service.
+	Signatures    string `protobuf:"bytes,8,opt,name=signatures,proto3" json:"signatures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StoreRequest) Reset() {
 	*x = StoreRequest{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[0]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -43,7 +111,7 @@ func (x *StoreRequest) String() string {
 func (*StoreRequest) ProtoMessage() {}
 
 func (x *StoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[0]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,7 +124,7 @@ func (x *StoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoreRequest.ProtoReflect.Descriptor instead.
 func (*StoreRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{0}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *StoreRequest) GetId() string {
@@ -73,16 +141,62 @@ func (x *StoreRequest) GetData() string {
 	return ""
 }
 
+func (x *StoreRequest) GetOneTime() bool {
+	if x != nil {
+		return x.OneTime
+	}
+	return false
+}
+
+func (x *StoreRequest) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetMatch() *Precondition {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *StoreRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetSignatures() string {
+	if x != nil {
+		return x.Signatures
+	}
+	return ""
+}
+
 type StoreResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Version int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Unix times in milliseconds.
+	Created       int64 `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int64 `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StoreResponse) Reset() {
 	*x = StoreResponse{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[1]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +208,7 @@ func (x *StoreResponse) String() string {
 func (*StoreResponse) ProtoMessage() {}
 
 func (x *StoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[1]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +221,7 @@ func (x *StoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StoreResponse.ProtoReflect.Descriptor instead.
 func (*StoreResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{1}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *StoreResponse) GetMessage() string {
@@ -117,16 +231,42 @@ func (x *StoreResponse) GetMessage() string {
 	return ""
 }
 
+func (x *StoreResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *StoreResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *StoreResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
 type RetrieveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyHash string                 `protobuf:"bytes,2,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	Version int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Name    string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// Omit record data. One-time records are not consumed.
+	MetadataOnly  bool `protobuf:"varint,5,opt,name=metadata_only,json=metadataOnly,proto3" json:"metadata_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RetrieveRequest) Reset() {
 	*x = RetrieveRequest{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[2]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -138,7 +278,7 @@ func (x *RetrieveRequest) String() string {
 func (*RetrieveRequest) ProtoMessage() {}
 
 func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[2]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,7 +291,7 @@ func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveRequest.ProtoReflect.Descriptor instead.
 func (*RetrieveRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{2}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RetrieveRequest) GetId() string {
@@ -161,17 +301,51 @@ func (x *RetrieveRequest) GetId() string {
 	return ""
 }
 
+func (x *RetrieveRequest) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *RetrieveRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RetrieveRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RetrieveRequest) GetMetadataOnly() bool {
+	if x != nil {
+		return x.MetadataOnly
+	}
+	return false
+}
+
 type RetrieveResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Message  string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Data     string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Version  int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Metadata string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Unix times in milliseconds.
+	Created       int64  `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int64  `protobuf:"varint,6,opt,name=updated,proto3" json:"updated,omitempty"`
+	Signatures    string `protobuf:"bytes,7,opt,name=signatures,proto3" json:"signatures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RetrieveResponse) Reset() {
 	*x = RetrieveResponse{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[3]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -183,7 +357,7 @@ func (x *RetrieveResponse) String() string {
 func (*RetrieveResponse) ProtoMessage() {}
 
 func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[3]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,7 +370,7 @@ func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveResponse.ProtoReflect.Descriptor instead.
 func (*RetrieveResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{3}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RetrieveResponse) GetMessage() string {
@@ -213,16 +387,53 @@ func (x *RetrieveResponse) GetData() string {
 	return ""
 }
 
+func (x *RetrieveResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RetrieveResponse) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *RetrieveResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *RetrieveResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *RetrieveResponse) GetSignatures() string {
+	if x != nil {
+		return x.Signatures
+	}
+	return ""
+}
+
 type DeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Match         *Precondition          `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteRequest) Reset() {
 	*x = DeleteRequest{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[4]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +445,7 @@ func (x *DeleteRequest) String() string {
 func (*DeleteRequest) ProtoMessage() {}
 
 func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[4]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +458,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{4}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeleteRequest) GetId() string {
@@ -257,6 +468,20 @@ func (x *DeleteRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteRequest) GetMatch() *Precondition {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *DeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type DeleteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -266,7 +491,7 @@ type DeleteResponse struct {
 
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[5]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -278,7 +503,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[5]
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,7 +516,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{5}
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteResponse) GetMessage() string {
@@ -301,29 +526,605 @@ func (x *DeleteResponse) GetMessage() string {
 	return ""
 }
 
-var File_pkg_v2_apis_be_service_service_proto protoreflect.FileDescriptor
+type ListVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_pkg_v2_apis_be_service_service_proto_rawDesc = "" +
-	"\n" +
-	"$pkg/v2-apis/be/service/service.proto\x12\aservice\"2\n" +
-	"\fStoreRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\tR\x04data\")\n" +
-	"\rStoreResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"!\n" +
-	"\x0fRetrieveRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
-	"\x10RetrieveResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\tR\x04data\"\x1f\n" +
-	"\rDeleteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
-	"\x0eDeleteResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xdc\x01\n" +
+func (x *ListVersionsRequest) Reset() {
+	*x = ListVersionsRequest{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsRequest) ProtoMessage() {}
+
+func (x *ListVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListVersionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListVersionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Versions      []int64                `protobuf:"varint,2,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVersionsResponse) Reset() {
+	*x = ListVersionsResponse{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsResponse) ProtoMessage() {}
+
+func (x *ListVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListVersionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListVersionsResponse) GetVersions() []int64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type RecordSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size  int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Unix times in milliseconds.
+	Created       int64  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int64  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	Metadata      string `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSummary) Reset() {
+	*x = RecordSummary{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSummary) ProtoMessage() {}
+
+func (x *RecordSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSummary.ProtoReflect.Descriptor instead.
+func (*RecordSummary) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordSummary) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *RecordSummary) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *RecordSummary) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *RecordSummary) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type ListRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordsRequest) Reset() {
+	*x = ListRecordsRequest{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsRequest) ProtoMessage() {}
+
+func (x *ListRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRecordsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Records       []*RecordSummary       `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	Next          string                 `protobuf:"bytes,3,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordsResponse) Reset() {
+	*x = ListRecordsResponse{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsResponse) ProtoMessage() {}
+
+func (x *ListRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRecordsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListRecordsResponse) GetRecords() []*RecordSummary {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListRecordsResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+// Replaces the sealed metadata of a record still holding the prior metadata.
+type UpdateMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prior         string                 `protobuf:"bytes,3,opt,name=prior,proto3" json:"prior,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Signatures    string                 `protobuf:"bytes,5,opt,name=signatures,proto3" json:"signatures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMetadataRequest) Reset() {
+	*x = UpdateMetadataRequest{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateMetadataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMetadataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMetadataRequest) GetPrior() string {
+	if x != nil {
+		return x.Prior
+	}
+	return ""
+}
+
+func (x *UpdateMetadataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *UpdateMetadataRequest) GetSignatures() string {
+	if x != nil {
+		return x.Signatures
+	}
+	return ""
+}
+
+// Chunked record upload. The first message carries the store request without
+// data; following messages carry record chunks. Metadata sealed once the
+// record has been streamed, and its signatures, may accompany the final
+// message.
+type StoreChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *StoreRequest          `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Signatures    string                 `protobuf:"bytes,4,opt,name=signatures,proto3" json:"signatures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreChunk) Reset() {
+	*x = StoreChunk{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreChunk) ProtoMessage() {}
+
+func (x *StoreChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreChunk.ProtoReflect.Descriptor instead.
+func (*StoreChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StoreChunk) GetRequest() *StoreRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *StoreChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *StoreChunk) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *StoreChunk) GetSignatures() string {
+	if x != nil {
+		return x.Signatures
+	}
+	return ""
+}
+
+// Chunked record download. The first message carries the record attributes
+// without data; following messages carry record chunks.
+type RetrieveChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *RetrieveResponse      `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveChunk) Reset() {
+	*x = RetrieveChunk{}
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveChunk) ProtoMessage() {}
+
+func (x *RetrieveChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_v2_apis_be_service_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveChunk.ProtoReflect.Descriptor instead.
+func (*RetrieveChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_v2_apis_be_service_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RetrieveChunk) GetResponse() *RetrieveResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *RetrieveChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+var File_pkg_v2_apis_be_service_service_proto protoreflect.FileDescriptor
+
+const file_pkg_v2_apis_be_service_service_proto_rawDesc = "" +
+	"\n" +
+	"$pkg/v2-apis/be/service/service.proto\x12\aservice\"j\n" +
+	"\fPrecondition\x12\x19\n" +
+	"\bif_match\x18\x01 \x01(\x03R\aifMatch\x12\x1b\n" +
+	"\tif_exists\x18\x02 \x01(\bR\bifExists\x12\"\n" +
+	"\rif_not_exists\x18\x03 \x01(\bR\vifNotExists\"\xe5\x01\n" +
+	"\fStoreRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x19\n" +
+	"\bone_time\x18\x03 \x01(\bR\aoneTime\x12\x19\n" +
+	"\bkey_hash\x18\x04 \x01(\tR\akeyHash\x12+\n" +
+	"\x05match\x18\x05 \x01(\v2\x15.service.PreconditionR\x05match\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x1a\n" +
+	"\bmetadata\x18\a \x01(\tR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"signatures\x18\b \x01(\tR\n" +
+	"signatures\"w\n" +
+	"\rStoreResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\x03R\aupdated\"\x8f\x01\n" +
+	"\x0fRetrieveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bkey_hash\x18\x02 \x01(\tR\akeyHash\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12#\n" +
+	"\rmetadata_only\x18\x05 \x01(\bR\fmetadataOnly\"\xca\x01\n" +
+	"\x10RetrieveResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x06 \x01(\x03R\aupdated\x12\x1e\n" +
+	"\n" +
+	"signatures\x18\a \x01(\tR\n" +
+	"signatures\"`\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x05match\x18\x02 \x01(\v2\x15.service.PreconditionR\x05match\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"*\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
+	"\x13ListVersionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"L\n" +
+	"\x14ListVersionsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1a\n" +
+	"\bversions\x18\x02 \x03(\x03R\bversions\"\x87\x01\n" +
+	"\rRecordSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\x03R\aupdated\x12\x1a\n" +
+	"\bmetadata\x18\x05 \x01(\tR\bmetadata\"R\n" +
+	"\x12ListRecordsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"u\n" +
+	"\x13ListRecordsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\arecords\x18\x02 \x03(\v2\x16.service.RecordSummaryR\arecords\x12\x12\n" +
+	"\x04next\x18\x03 \x01(\tR\x04next\"\x8d\x01\n" +
+	"\x15UpdateMetadataRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05prior\x18\x03 \x01(\tR\x05prior\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"signatures\x18\x05 \x01(\tR\n" +
+	"signatures\"\x8d\x01\n" +
+	"\n" +
+	"StoreChunk\x12/\n" +
+	"\arequest\x18\x01 \x01(\v2\x15.service.StoreRequestR\arequest\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x1a\n" +
+	"\bmetadata\x18\x03 \x01(\tR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"signatures\x18\x04 \x01(\tR\n" +
+	"signatures\"Z\n" +
+	"\rRetrieveChunk\x125\n" +
+	"\bresponse\x18\x01 \x01(\v2\x19.service.RetrieveResponseR\bresponse\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data2\xcb\x04\n" +
 	"\x0eBackendService\x12>\n" +
 	"\vStoreRecord\x12\x15.service.StoreRequest\x1a\x16.service.StoreResponse\"\x00\x12G\n" +
 	"\x0eRetrieveRecord\x12\x18.service.RetrieveRequest\x1a\x19.service.RetrieveResponse\"\x00\x12A\n" +
-	"\fDeleteRecord\x12\x16.service.DeleteRequest\x1a\x17.service.DeleteResponse\"\x00B\vZ\t./serviceb\x06proto3"
+	"\fDeleteRecord\x12\x16.service.DeleteRequest\x1a\x17.service.DeleteResponse\"\x00\x12M\n" +
+	"\fListVersions\x12\x1c.service.ListVersionsRequest\x1a\x1d.service.ListVersionsResponse\"\x00\x12J\n" +
+	"\vListRecords\x12\x1b.service.ListRecordsRequest\x1a\x1c.service.ListRecordsResponse\"\x00\x12>\n" +
+	"\vStoreStream\x12\x13.service.StoreChunk\x1a\x16.service.StoreResponse\"\x00(\x01\x12F\n" +
+	"\x0eRetrieveStream\x12\x18.service.RetrieveRequest\x1a\x16.service.RetrieveChunk\"\x000\x01\x12J\n" +
+	"\x0eUpdateMetadata\x12\x1e.service.UpdateMetadataRequest\x1a\x16.service.StoreResponse\"\x00B\vZ\t./serviceb\x06proto3"
 
 var (
 	file_pkg_v2_apis_be_service_service_proto_rawDescOnce sync.Once
@@ -337,27 +1138,51 @@ func file_pkg_v2_apis_be_service_service_proto_rawDescGZIP() []byte {
 	return file_pkg_v2_apis_be_service_service_proto_rawDescData
 }
 
-var file_pkg_v2_apis_be_service_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pkg_v2_apis_be_service_service_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_pkg_v2_apis_be_service_service_proto_goTypes = []any{
-	(*StoreRequest)(nil),     // 0: service.StoreRequest
-	(*StoreResponse)(nil),    // 1: service.StoreResponse
-	(*RetrieveRequest)(nil),  // 2: service.RetrieveRequest
-	(*RetrieveResponse)(nil), // 3: service.RetrieveResponse
-	(*DeleteRequest)(nil),    // 4: service.DeleteRequest
-	(*DeleteResponse)(nil),   // 5: service.DeleteResponse
+	(*Precondition)(nil),          // 0: service.Precondition
+	(*StoreRequest)(nil),          // 1: service.StoreRequest
+	(*StoreResponse)(nil),         // 2: service.StoreResponse
+	(*RetrieveRequest)(nil),       // 3: service.RetrieveRequest
+	(*RetrieveResponse)(nil),      // 4: service.RetrieveResponse
+	(*DeleteRequest)(nil),         // 5: service.DeleteRequest
+	(*DeleteResponse)(nil),        // 6: service.DeleteResponse
+	(*ListVersionsRequest)(nil),   // 7: service.ListVersionsRequest
+	(*ListVersionsResponse)(nil),  // 8: service.ListVersionsResponse
+	(*RecordSummary)(nil),         // 9: service.RecordSummary
+	(*ListRecordsRequest)(nil),    // 10: service.ListRecordsRequest
+	(*ListRecordsResponse)(nil),   // 11: service.ListRecordsResponse
+	(*UpdateMetadataRequest)(nil), // 12: service.UpdateMetadataRequest
+	(*StoreChunk)(nil),            // 13: service.StoreChunk
+	(*RetrieveChunk)(nil),         // 14: service.RetrieveChunk
 }
 var file_pkg_v2_apis_be_service_service_proto_depIdxs = []int32{
-	0, // 0: service.BackendService.StoreRecord:input_type -> service.StoreRequest
-	2, // 1: service.BackendService.RetrieveRecord:input_type -> service.RetrieveRequest
-	4, // 2: service.BackendService.DeleteRecord:input_type -> service.DeleteRequest
-	1, // 3: service.BackendService.StoreRecord:output_type -> service.StoreResponse
-	3, // 4: service.BackendService.RetrieveRecord:output_type -> service.RetrieveResponse
-	5, // 5: service.BackendService.DeleteRecord:output_type -> service.DeleteResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: service.StoreRequest.match:type_name -> service.Precondition
+	0,  // 1: service.DeleteRequest.match:type_name -> service.Precondition
+	9,  // 2: service.ListRecordsResponse.records:type_name -> service.RecordSummary
+	1,  // 3: service.StoreChunk.request:type_name -> service.StoreRequest
+	4,  // 4: service.RetrieveChunk.response:type_name -> service.RetrieveResponse
+	1,  // 5: service.BackendService.StoreRecord:input_type -> service.StoreRequest
+	3,  // 6: service.BackendService.RetrieveRecord:input_type -> service.RetrieveRequest
+	5,  // 7: service.BackendService.DeleteRecord:input_type -> service.DeleteRequest
+	7,  // 8: service.BackendService.ListVersions:input_type -> service.ListVersionsRequest
+	10, // 9: service.BackendService.ListRecords:input_type -> service.ListRecordsRequest
+	13, // 10: service.BackendService.StoreStream:input_type -> service.StoreChunk
+	3,  // 11: service.BackendService.RetrieveStream:input_type -> service.RetrieveRequest
+	12, // 12: service.BackendService.UpdateMetadata:input_type -> service.UpdateMetadataRequest
+	2,  // 13: service.BackendService.StoreRecord:output_type -> service.StoreResponse
+	4,  // 14: service.BackendService.RetrieveRecord:output_type -> service.RetrieveResponse
+	6,  // 15: service.BackendService.DeleteRecord:output_type -> service.DeleteResponse
+	8,  // 16: service.BackendService.ListVersions:output_type -> service.ListVersionsResponse
+	11, // 17: service.BackendService.ListRecords:output_type -> service.ListRecordsResponse
+	2,  // 18: service.BackendService.StoreStream:output_type -> service.StoreResponse
+	14, // 19: service.BackendService.RetrieveStream:output_type -> service.RetrieveChunk
+	2,  // 20: service.BackendService.UpdateMetadata:output_type -> service.StoreResponse
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_pkg_v2_apis_be_service_service_proto_init() }
@@ -371,7 +1196,7 @@ func file_pkg_v2_apis_be_service_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_v2_apis_be_service_service_proto_rawDesc), len(file_pkg_v2_apis_be_service_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,11 @@ const (
 	BackendService_StoreRecord_FullMethodName    = "/service.BackendService/StoreRecord"
 	BackendService_RetrieveRecord_FullMethodName = "/service.BackendService/RetrieveRecord"
 	BackendService_DeleteRecord_FullMethodName   = "/service.BackendService/DeleteRecord"
+	BackendService_ListVersions_FullMethodName   = "/service.BackendService/ListVersions"
+	BackendService_ListRecords_FullMethodName    = "/service.BackendService/ListRecords"
+	BackendService_StoreStream_FullMethodName    = "/service.BackendService/StoreStream"
+	BackendService_RetrieveStream_FullMethodName = "/service.BackendService/RetrieveStream"
+	BackendService_UpdateMetadata_FullMethodName = "/service.BackendService/UpdateMetadata"
 )
 
 // BackendServiceClient is the client API for BackendService service.
@@ -33,6 +38,11 @@ type BackendServiceClient interface {
 	StoreRecord(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
 	RetrieveRecord(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (*RetrieveResponse, error)
 	DeleteRecord(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	StoreStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreChunk, StoreResponse], error)
+	RetrieveStream(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RetrieveChunk], error)
+	UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*StoreResponse, error)
 }
 
 type backendServiceClient struct {
@@ -73,6 +83,68 @@ func (c *backendServiceClient) DeleteRecord(ctx context.Context, in *DeleteReque
 	return out, nil
 }
 
+func (c *backendServiceClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, BackendService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecordsResponse)
+	err := c.cc.Invoke(ctx, BackendService_ListRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) StoreStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreChunk, StoreResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackendService_ServiceDesc.Streams[0], BackendService_StoreStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StoreChunk, StoreResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackendService_StoreStreamClient = grpc.ClientStreamingClient[StoreChunk, StoreResponse]
+
+func (c *backendServiceClient) RetrieveStream(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RetrieveChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackendService_ServiceDesc.Streams[1], BackendService_RetrieveStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RetrieveRequest, RetrieveChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackendService_RetrieveStreamClient = grpc.ServerStreamingClient[RetrieveChunk]
+
+func (c *backendServiceClient) UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*StoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreResponse)
+	err := c.cc.Invoke(ctx, BackendService_UpdateMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackendServiceServer is the server API for BackendService service.
 // All implementations must embed UnimplementedBackendServiceServer
 // for forward compatibility.
@@ -82,6 +154,11 @@ type BackendServiceServer interface {
 	StoreRecord(context.Context, *StoreRequest) (*StoreResponse, error)
 	RetrieveRecord(context.Context, *RetrieveRequest) (*RetrieveResponse, error)
 	DeleteRecord(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	StoreStream(grpc.ClientStreamingServer[StoreChunk, StoreResponse]) error
+	RetrieveStream(*RetrieveRequest, grpc.ServerStreamingServer[RetrieveChunk]) error
+	UpdateMetadata(context.Context, *UpdateMetadataRequest) (*StoreResponse, error)
 	mustEmbedUnimplementedBackendServiceServer()
 }
 
@@ -101,6 +178,21 @@ func (UnimplementedBackendServiceServer) RetrieveRecord(context.Context, *Retrie
 func (UnimplementedBackendServiceServer) DeleteRecord(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteRecord not implemented")
 }
+func (UnimplementedBackendServiceServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedBackendServiceServer) ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRecords not implemented")
+}
+func (UnimplementedBackendServiceServer) StoreStream(grpc.ClientStreamingServer[StoreChunk, StoreResponse]) error {
+	return status.Error(codes.Unimplemented, "method StoreStream not implemented")
+}
+func (UnimplementedBackendServiceServer) RetrieveStream(*RetrieveRequest, grpc.ServerStreamingServer[RetrieveChunk]) error {
+	return status.Error(codes.Unimplemented, "method RetrieveStream not implemented")
+}
+func (UnimplementedBackendServiceServer) UpdateMetadata(context.Context, *UpdateMetadataRequest) (*StoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateMetadata not implemented")
+}
 func (UnimplementedBackendServiceServer) mustEmbedUnimplementedBackendServiceServer() {}
 func (UnimplementedBackendServiceServer) testEmbeddedByValue()                        {}
 
@@ -176,6 +268,78 @@ func _BackendService_DeleteRecord_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackendService_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_ListRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ListRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackendService_ListRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ListRecords(ctx, req.(*ListRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_StoreStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackendServiceServer).StoreStream(&grpc.GenericServerStream[StoreChunk, StoreResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackendService_StoreStreamServer = grpc.ClientStreamingServer[StoreChunk, StoreResponse]
+
+func _BackendService_RetrieveStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RetrieveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackendServiceServer).RetrieveStream(m, &grpc.GenericServerStream[RetrieveRequest, RetrieveChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackendService_RetrieveStreamServer = grpc.ServerStreamingServer[RetrieveChunk]
+
+func _BackendService_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).UpdateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackendService_UpdateMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).UpdateMetadata(ctx, req.(*UpdateMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BackendService_ServiceDesc is the grpc.ServiceDesc for BackendService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -195,7 +359,30 @@ var BackendService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRecord",
 			Handler:    _BackendService_DeleteRecord_Handler,
 		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _BackendService_ListVersions_Handler,
+		},
+		{
+			MethodName: "ListRecords",
+			Handler:    _BackendService_ListRecords_Handler,
+		},
+		{
+			MethodName: "UpdateMetadata",
+			Handler:    _BackendService_UpdateMetadata_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StoreStream",
+			Handler:       _BackendService_StoreStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RetrieveStream",
+			Handler:       _BackendService_RetrieveStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/v2-apis/be/service/service.proto",
 }
@@ -7,135 +7,626 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"enc-server-go/pkg/shamir"
 	"enc-server-go/pkg/utils"
 )
 
 type record struct {
-	ID   string `json:"id"`
-	Key  string `json:"key"`
-	Data string `json:"data"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Key         string            `json:"key"`
+	Data        string            `json:"data"`
+	OneTime     bool              `json:"oneTime,omitempty"`
+	KeyShares   []string          `json:"keyShares,omitempty"`
+	Version     int64             `json:"version,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Size        int64             `json:"size,omitempty"`
+	Created     time.Time         `json:"created,omitzero"`
+	Updated     time.Time         `json:"updated,omitzero"`
+}
+
+type keySlot struct {
+	Recipient string `json:"recipient"`
+	Ephemeral string `json:"ephemeral"`
+	Wrapped   string `json:"wrapped"`
+}
+
+type recipients struct {
+	Key        string   `json:"key"`
+	Recipients []string `json:"recipients,omitempty"`
+	Members    []string `json:"members,omitempty"`
+}
+
+type keySlots struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Version int64    `json:"version,omitempty"`
+	Slots   []string `json:"slots"`
+}
+
+type recordSummary struct {
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType,omitempty"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+type recordList struct {
+	ID      string          `json:"id"`
+	Records []recordSummary `json:"records"`
+	Next    string          `json:"next,omitempty"`
 }
 
 // Client implementation.
 type clientImpl struct {
 	serverAddr string
 	httpClient *http.Client
+
+	// Generates record keys in end-to-end mode, where records are sealed
+	// and opened by the client. Nil otherwise.
+	keygen utils.KeyGen
+}
+
+// Transport presenting the client API key or bearer token on every request.
+type authTransport struct {
+	apiKey      string
+	bearerToken string
+	base        http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.apiKey != "" {
+		req.Header.Set("X-API-Key", t.apiKey)
+	}
+	if t.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+t.bearerToken)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func setPrecondition(req *http.Request, match utils.Precondition) {
+
+	// Revision preconditions are carried in conditional request headers.
+	ifMatch, ifNoneMatch := match.Headers()
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+}
+
+func recordInfo(rec record) (info utils.RecordInfo) {
+
+	// Records report their revision and attributes alongside their contents.
+	return utils.RecordInfo{
+		Version:     rec.Version,
+		Created:     rec.Created,
+		Updated:     rec.Updated,
+		Size:        rec.Size,
+		ContentType: rec.ContentType,
+		Labels:      rec.Labels,
+	}
+}
+
+func parseRecordHeaders(header http.Header) (info utils.RecordInfo, err error) {
+
+	// Record attributes accompany HEAD and raw GET responses as headers.
+	if info.Version, err = utils.ParseETag(header.Get("ETag")); err != nil {
+		return info, errors.New("Error decoding ETag: " + err.Error())
+	}
+	if info.Size, err = strconv.ParseInt(header.Get("X-Record-Size"), 10, 64); err != nil {
+		return info, errors.New("Error decoding size: " + err.Error())
+	}
+	if updated := header.Get("Last-Modified"); updated != "" {
+		if info.Updated, err = http.ParseTime(updated); err != nil {
+			return info, errors.New("Error decoding update time: " + err.Error())
+		}
+	}
+	if created := header.Get("X-Record-Created"); created != "" {
+		if info.Created, err = http.ParseTime(created); err != nil {
+			return info, errors.New("Error decoding creation time: " + err.Error())
+		}
+	}
+	info.ContentType = header.Get("X-Record-Content-Type")
+	if signer := header.Get("X-Record-Signer"); signer != "" {
+		if info.Signer, err = hex.DecodeString(signer); err != nil {
+			return info, errors.New("Error decoding signer: " + err.Error())
+		}
+	}
+	if labels := header.Get("X-Record-Labels"); labels != "" {
+		values, err := url.ParseQuery(labels)
+		if err != nil {
+			return info, errors.New("Error decoding labels: " + err.Error())
+		}
+		info.Labels = make(map[string]string, len(values))
+		for label := range values {
+			info.Labels[label] = values.Get(label)
+		}
+	}
+
+	return info, nil
+}
+
+func (c *clientImpl) recordURL(idStr string, name []byte) string {
+
+	// Named records are addressed under their user ID.
+	if len(name) == 0 {
+		return "http://" + c.serverAddr + "/records/" + idStr
+	}
+	return "http://" + c.serverAddr + "/users/" + idStr + "/records/" + hex.EncodeToString(name)
+}
+
+func (c *clientImpl) sealedURL(idStr string, name []byte) string {
+
+	// Records sealed by the client pass through the sealed endpoints.
+	if len(name) == 0 {
+		return "http://" + c.serverAddr + "/sealed/records/" + idStr
+	}
+	return "http://" + c.serverAddr + "/sealed/users/" + idStr + "/records/" + hex.EncodeToString(name)
+}
+
+func (c *clientImpl) StoreRecord(id, name, data []byte, opts utils.StoreOptions) (key []byte, info utils.RecordInfo, err error) {
+
+	slog.Info("FE client received a store request", "id", hex.EncodeToString(id))
+
+	// In end-to-end mode the record key never leaves the client.
+	if c.keygen != nil {
+		if key, err = c.keygen.RandomKey(); err != nil {
+			return nil, info, err
+		}
+		if info, err = c.storeSealed(id, name, key, data, opts); err != nil {
+			return nil, info, err
+		}
+		return key, info, nil
+	}
+
+	newRecord, err := c.postRecord(id, name, data, opts, url.Values{})
+	if err != nil {
+		return nil, info, err
+	}
+
+	// Decode and return record key and attributes. Records stored for
+	// recipients return no key.
+	if newRecord.Key != "" {
+		if key, err = hex.DecodeString(newRecord.Key); err != nil {
+			return nil, info, errors.New("Error decoding key: " + err.Error())
+		}
+	}
+	return key, recordInfo(newRecord), nil
+}
+
+func (c *clientImpl) StoreRecordShares(id, name, data []byte, shares, threshold int,
+	opts utils.StoreOptions) (keyShares [][]byte, info utils.RecordInfo, err error) {
+
+	slog.Info("FE client received a split store request", "id", hex.EncodeToString(id))
+
+	// In end-to-end mode the record key is split by the client.
+	if c.keygen != nil {
+		key, err := c.keygen.RandomKey()
+		if err != nil {
+			return nil, info, err
+		}
+		if keyShares, err = shamir.Split(key, shares, threshold); err != nil {
+			return nil, info, err
+		}
+		if info, err = c.storeSealed(id, name, key, data, opts); err != nil {
+			return nil, info, err
+		}
+		return keyShares, info, nil
+	}
+
+	// Request the record key split into shares
+	query := url.Values{}
+	query.Set("shares", strconv.Itoa(shares))
+	query.Set("threshold", strconv.Itoa(threshold))
+	newRecord, err := c.postRecord(id, name, data, opts, query)
+	if err != nil {
+		return nil, info, err
+	}
+
+	// Decode and return key shares and record attributes
+	for _, shareStr := range newRecord.KeyShares {
+		share, err := hex.DecodeString(shareStr)
+		if err != nil {
+			return nil, info, errors.New("Error decoding key share: " + err.Error())
+		}
+		keyShares = append(keyShares, share)
+	}
+	if len(keyShares) != shares {
+		return nil, info, errors.New("Error storing record: expected " + strconv.Itoa(shares) +
+			" key shares, received " + strconv.Itoa(len(keyShares)))
+	}
+	return keyShares, recordInfo(newRecord), nil
 }
 
-func (c *clientImpl) StoreRecord(id, data []byte) (key []byte, err error) {
+func (c *clientImpl) postRecord(id, name, data []byte, opts utils.StoreOptions,
+	query url.Values) (newRecord record, err error) {
 
 	// Encode data as hex strings.
 	idStr := hex.EncodeToString(id)
-	dataStr := hex.EncodeToString(data)
 
-	log.Println("FE client received a store request for", idStr)
+	// Compose request query. Named records take their ID from the path.
+	postURL := "http://" + c.serverAddr + "/records"
+	if len(name) != 0 {
+		postURL = c.recordURL(idStr, name)
+	} else {
+		query.Set("id", idStr)
+	}
+	if opts.OneTime {
+		query.Set("oneTime", "true")
+	}
+	if opts.NoCompress {
+		query.Set("noCompress", "true")
+	}
+	for _, recipient := range opts.Recipients {
+		query.Add("recipient", hex.EncodeToString(recipient))
+	}
+	if opts.ContentSignature != nil {
+		query.Set("signer", hex.EncodeToString(opts.ContentSignature.KeyID))
+		query.Set("signature", hex.EncodeToString(opts.ContentSignature.Signature))
+	}
+	if len(query) > 0 {
+		postURL += "?" + query.Encode()
+	}
+	return c.post(postURL, data, opts)
+}
 
-	newRecord := record{
-		ID:   idStr,
-		Data: dataStr,
+func (c *clientImpl) storeSealed(id, name, key, data []byte, opts utils.StoreOptions) (info utils.RecordInfo, err error) {
+
+	// The server never sees the record contents, so cannot wrap its key for
+	// recipients or verify content signatures.
+	if len(opts.Recipients) > 0 {
+		return info, errors.New("Recipients are not supported in end-to-end mode")
+	}
+	if opts.ContentSignature != nil {
+		return info, errors.New("Content signatures are not supported in end-to-end mode")
 	}
 
-	// Compose request body
-	postURL := "http://" + c.serverAddr + "/records"
-	jsonData, err := json.Marshal(newRecord)
+	// Seal the record whole as the server does: a random nonce followed by
+	// the AES-GCM ciphertext under the record key.
+	cipher, err := c.keygen.GetGCMCipher(key)
+	if err != nil {
+		return info, err
+	}
+	nonce, err := c.keygen.RandomNonce(cipher.NonceSize())
+	if err != nil {
+		return info, err
+	}
+	sealed := cipher.Seal(nonce, nonce, data, nil)
+
+	// Commit to the record key so retrievals may be authorized without it.
+	query := url.Values{}
+	query.Set("keyHash", hex.EncodeToString(utils.KeyHash(key)))
+	if opts.OneTime {
+		query.Set("oneTime", "true")
+	}
+	newRecord, err := c.post(c.sealedURL(hex.EncodeToString(id), name)+"?"+query.Encode(), sealed, opts)
 	if err != nil {
-		return nil, errors.New("Error marshaling JSON: " + err.Error())
+		return info, err
 	}
-	req, err := http.NewRequest("POST", postURL, bytes.NewBuffer(jsonData))
+	return recordInfo(newRecord), nil
+}
+
+func (c *clientImpl) post(postURL string, data []byte, opts utils.StoreOptions) (newRecord record, err error) {
+
+	// Compose request with raw record bytes as the body
+	req, err := http.NewRequest("POST", postURL, bytes.NewReader(data))
 	if err != nil {
-		return nil, errors.New("Error composing POST request: " + err.Error())
+		return newRecord, errors.New("Error composing POST request: " + err.Error())
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if opts.ContentType != "" {
+		req.Header.Set("X-Record-Content-Type", opts.ContentType)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if len(opts.Labels) > 0 {
+		labels := url.Values{}
+		for label, value := range opts.Labels {
+			labels.Set(label, value)
+		}
+		req.Header.Set("X-Record-Labels", labels.Encode())
+	}
+	setPrecondition(req, opts.Match)
 
 	// Post request to FE server
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, errors.New("Error making POST request: " + err.Error())
+		return newRecord, errors.New("Error making POST request: " + err.Error())
 	}
 	defer resp.Body.Close()
 
 	// Read response body
-	data, err = io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.New("Error reading response: " + err.Error())
+		return newRecord, errors.New("Error reading response: " + err.Error())
 	}
 
 	// Verify HTTP status code
-	if resp.StatusCode != http.StatusCreated {
-		return nil, errors.New("Bad status making POST request: " + resp.Status + string(data))
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return newRecord, utils.ErrPreconditionFailed
+	} else if resp.StatusCode != http.StatusCreated {
+		return newRecord, errors.New("Bad status making POST request: " + resp.Status + string(body))
 	}
 
 	// Unmarshall record fields
-	if err = json.Unmarshal(data, &newRecord); err != nil {
-		return nil, errors.New("Error unmarshalling record: " + err.Error())
+	if err = json.Unmarshal(body, &newRecord); err != nil {
+		return newRecord, errors.New("Error unmarshalling record: " + err.Error())
 	}
+	return newRecord, nil
+}
+
+func (c *clientImpl) RetrieveRecord(id, name, key []byte) (data []byte, info utils.RecordInfo, err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+	keyStr := hex.EncodeToString(key)
 
-	// Decode and return record key
-	if key, err = hex.DecodeString(newRecord.Key); err != nil {
-		return nil, errors.New("Error decoding key: " + err.Error())
+	slog.Info("FE client received a get request", "id", idStr)
+
+	if c.keygen != nil {
+		return c.retrieveSealed(idStr, name, key)
+	}
+	return c.getRecord(c.recordURL(idStr, name) + "?key=" + keyStr)
+}
+
+func (c *clientImpl) retrieveSealed(idStr string, name, key []byte) (data []byte, info utils.RecordInfo, err error) {
+
+	// Retrieve the cipher entry against the key commitment, then open it
+	// locally.
+	sealed, info, err := c.getRecord(c.sealedURL(idStr, name) + "?keyHash=" + hex.EncodeToString(utils.KeyHash(key)))
+	if err != nil {
+		return nil, info, err
+	}
+	cipher, err := c.keygen.GetGCMCipher(key)
+	if err != nil {
+		return nil, info, err
 	}
-	return key, nil
+	if len(sealed) < cipher.NonceSize() {
+		return nil, info, errors.New("Error decrypting record: sealed record too short")
+	}
+	nonce, remainder := sealed[:cipher.NonceSize()], sealed[cipher.NonceSize():]
+	if data, err = cipher.Open(nil, nonce, remainder, nil); err != nil {
+		return nil, info, errors.New("Error decrypting record: " + err.Error())
+	}
+	return data, info, nil
 }
 
-func (c *clientImpl) RetrieveRecord(id, key []byte) (data []byte, err error) {
+func (c *clientImpl) RetrieveRecordShares(id, name []byte, shares [][]byte) (data []byte, info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings.
 	idStr := hex.EncodeToString(id)
-	keyStr := hex.EncodeToString(key)
+	query := url.Values{}
+	for _, share := range shares {
+		query.Add("share", hex.EncodeToString(share))
+	}
 
-	log.Println("FE client received a get request for", idStr)
+	slog.Info("FE client received a split get request", "id", idStr)
 
-	// Compose request body
-	getURL := "http://" + c.serverAddr + "/records/" + idStr + "?key=" + keyStr
+	// In end-to-end mode the record key is recovered by the client.
+	if c.keygen != nil {
+		key, err := shamir.Combine(shares)
+		if err != nil {
+			return nil, info, err
+		}
+		return c.retrieveSealed(idStr, name, key)
+	}
+
+	// The record key is recovered from the shares by the FE server.
+	return c.getRecord(c.recordURL(idStr, name) + "?" + query.Encode())
+}
+
+func (c *clientImpl) getRecord(getURL string) (data []byte, info utils.RecordInfo, err error) {
+
+	// Compose request for raw record bytes
 	req, err := http.NewRequest("GET", getURL, nil)
 	if err != nil {
-		return nil, errors.New("Error composing GET request: " + err.Error())
+		return nil, info, errors.New("Error composing GET request: " + err.Error())
 	}
+	req.Header.Set("Accept", "application/octet-stream")
 
 	// Get request to FE server
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, errors.New("Error making GET request: " + err.Error())
+		return nil, info, errors.New("Error making GET request: " + err.Error())
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	data, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.New("Error reading response: " + err.Error())
+		return nil, info, errors.New("Error reading response: " + err.Error())
 	}
 
 	// Verify HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("Bad status making GET request: " + resp.Status + string(data))
+		return nil, info, errors.New("Bad status making GET request: " + resp.Status + string(data))
 	}
 
-	// Unmarshall record fields
-	var newRecord record
-	if err = json.Unmarshal(data, &newRecord); err != nil {
-		return nil, errors.New("Error unmarshalling record: " + err.Error())
+	// Decode record attributes from headers
+	if info, err = parseRecordHeaders(resp.Header); err != nil {
+		return nil, info, err
 	}
+	return data, info, nil
+}
+
+func (c *clientImpl) RetrieveRecordFor(id, name, private []byte) (data []byte, info utils.RecordInfo, err error) {
 
-	// TODO make sure this is consistent
-	return []byte(newRecord.Data), nil
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+	public, err := utils.RecipientPublicKey(private)
+	if err != nil {
+		return nil, info, err
+	}
+
+	slog.Info("FE client received a recipient get request", "id", idStr)
+
+	// Get the record key wrapped for the recipient
+	slotURL := c.recordURL(idStr, name) + "/keyslot?recipient=" + hex.EncodeToString(public)
+	resp, err := c.httpClient.Get(slotURL)
+	if err != nil {
+		return nil, info, errors.New("Error making GET request: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, info, errors.New("Error reading response: " + err.Error())
+	}
+
+	// Verify HTTP status code
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, info, utils.ErrNoKeySlot
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, info, errors.New("Bad status making GET request: " + resp.Status + string(body))
+	}
+
+	// Unmarshall and decode key slot fields
+	var slotFields keySlot
+	if err = json.Unmarshal(body, &slotFields); err != nil {
+		return nil, info, errors.New("Error unmarshalling key slot: " + err.Error())
+	}
+	slot := utils.KeySlot{}
+	if slot.Recipient, err = hex.DecodeString(slotFields.Recipient); err != nil {
+		return nil, info, errors.New("Error decoding key slot: " + err.Error())
+	}
+	if slot.Ephemeral, err = hex.DecodeString(slotFields.Ephemeral); err != nil {
+		return nil, info, errors.New("Error decoding key slot: " + err.Error())
+	}
+	if slot.Wrapped, err = hex.DecodeString(slotFields.Wrapped); err != nil {
+		return nil, info, errors.New("Error decoding key slot: " + err.Error())
+	}
+
+	// Unwrap the record key locally, proving possession of the private key
+	// by retrieving with it. The private key never leaves the client.
+	key, err := utils.UnwrapKey(private, slot)
+	if err != nil {
+		return nil, info, err
+	}
+	return c.RetrieveRecord(id, name, key)
 }
 
-func (c *clientImpl) DeleteRecord(id []byte) (err error) {
+func (c *clientImpl) StatRecord(id, name []byte) (info utils.RecordInfo, err error) {
 
 	// Encode data as hex strings.
 	idStr := hex.EncodeToString(id)
 
-	log.Println("FE client received a delete request for", idStr)
+	slog.Info("FE client received a head request", "id", idStr)
 
-	// Compose request body
-	deleteURL := "http://" + c.serverAddr + "/records/" + idStr
+	// Compose request
+	req, err := http.NewRequest("HEAD", c.recordURL(idStr, name), nil)
+	if err != nil {
+		return info, errors.New("Error composing HEAD request: " + err.Error())
+	}
+
+	// Head request to FE server
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return info, errors.New("Error making HEAD request: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Verify HTTP status code
+	if resp.StatusCode != http.StatusOK {
+		return info, errors.New("Bad status making HEAD request: " + resp.Status)
+	}
+
+	// Decode record attributes from headers
+	return parseRecordHeaders(resp.Header)
+}
+
+func (c *clientImpl) ListRecords(id []byte, opts utils.ListOptions) (records []utils.RecordSummary, next string, err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+
+	slog.Info("FE client received a list request", "id", idStr)
+
+	// Compose request query
+	query := url.Values{}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	listURL := "http://" + c.serverAddr + "/users/" + idStr + "/records"
+	if len(query) > 0 {
+		listURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequest("GET", listURL, nil)
+	if err != nil {
+		return nil, "", errors.New("Error composing GET request: " + err.Error())
+	}
+
+	// Get request to FE server
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", errors.New("Error making GET request: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", errors.New("Error reading response: " + err.Error())
+	}
+
+	// Verify HTTP status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errors.New("Bad status making GET request: " + resp.Status + string(data))
+	}
+
+	// Unmarshall record list fields
+	var list recordList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, "", errors.New("Error unmarshalling record list: " + err.Error())
+	}
+
+	// Decode record names
+	for _, summary := range list.Records {
+		name, err := hex.DecodeString(summary.Name)
+		if err != nil {
+			return nil, "", errors.New("Error decoding name: " + err.Error())
+		}
+		records = append(records, utils.RecordSummary{
+			Name:        name,
+			Size:        summary.Size,
+			ContentType: summary.ContentType,
+			Created:     summary.Created,
+			Updated:     summary.Updated,
+		})
+	}
+
+	return records, list.Next, nil
+}
+
+func (c *clientImpl) DeleteRecord(id, name, key []byte, opts utils.DeleteOptions) (err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+
+	slog.Info("FE client received a delete request", "id", idStr)
+
+	// Compose request body. Admins may omit the record key.
+	deleteURL := c.recordURL(idStr, name)
+	if key != nil {
+		deleteURL += "?key=" + hex.EncodeToString(key)
+	}
 	req, err := http.NewRequest("DELETE", deleteURL, nil)
 	if err != nil {
 		return errors.New("Error composing DELETE request: " + err.Error())
 	}
+	setPrecondition(req, opts.Match)
 
 	// Delete request to FE server
 	resp, err := c.httpClient.Do(req)
@@ -145,16 +636,119 @@ func (c *clientImpl) DeleteRecord(id []byte) (err error) {
 	defer resp.Body.Close()
 
 	// Verify HTTP status code
-	if resp.StatusCode != http.StatusAccepted {
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+	case http.StatusForbidden:
+		return utils.ErrKeyMismatch
+	case http.StatusPreconditionFailed:
+		return utils.ErrPreconditionFailed
+	default:
 		return errors.New("Bad status making DELETE request: " + resp.Status)
 	}
 
 	return nil
 }
 
+func (c *clientImpl) AddRecipients(id, name, key []byte, recipientKeys, members [][]byte) (slots [][]byte, err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+	body := recipients{Key: hex.EncodeToString(key)}
+	for _, recipient := range recipientKeys {
+		body.Recipients = append(body.Recipients, hex.EncodeToString(recipient))
+	}
+	for _, member := range members {
+		body.Members = append(body.Members, hex.EncodeToString(member))
+	}
+
+	slog.Info("FE client received an add recipients request", "id", idStr)
+
+	// Compose request body
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.New("Error marshalling JSON: " + err.Error())
+	}
+
+	// Post request to FE server
+	postURL := c.recordURL(idStr, name) + "/recipients"
+	resp, err := c.httpClient.Post(postURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, errors.New("Error making POST request: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	return keySlotsResponse(resp, "POST")
+}
+
+func (c *clientImpl) RevokeRecipient(id, name, key, slot []byte) (err error) {
+
+	// Encode data as hex strings.
+	idStr := hex.EncodeToString(id)
+	keyStr := hex.EncodeToString(key)
+	slotStr := hex.EncodeToString(slot)
+
+	slog.Info("FE client received a revoke recipient request", "id", idStr)
+
+	// Compose request body
+	deleteURL := c.recordURL(idStr, name) + "/recipients/" + slotStr + "?key=" + keyStr
+	req, err := http.NewRequest("DELETE", deleteURL, nil)
+	if err != nil {
+		return errors.New("Error composing DELETE request: " + err.Error())
+	}
+
+	// Delete request to FE server
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.New("Error making DELETE request: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	_, err = keySlotsResponse(resp, "DELETE")
+	return err
+}
+
+// keySlotsResponse decodes the key slot IDs left on a record after a
+// recipients request, mapping refusals to their utils errors.
+func keySlotsResponse(resp *http.Response, method string) (slots [][]byte, err error) {
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New("Error reading response: " + err.Error())
+	}
+
+	// Verify HTTP status code
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return nil, utils.ErrKeyMismatch
+	case http.StatusNotFound:
+		return nil, utils.ErrNoKeySlot
+	case http.StatusPreconditionFailed:
+		return nil, utils.ErrPreconditionFailed
+	default:
+		return nil, errors.New("Bad status making " + method + " request: " + resp.Status + string(body))
+	}
+
+	// Unmarshall and decode key slot IDs
+	var slotFields keySlots
+	if err = json.Unmarshal(body, &slotFields); err != nil {
+		return nil, errors.New("Error unmarshalling key slots: " + err.Error())
+	}
+	for _, slotStr := range slotFields.Slots {
+		slot, err := hex.DecodeString(slotStr)
+		if err != nil {
+			return nil, errors.New("Error decoding key slot: " + err.Error())
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
 func MakeClient(configs map[string]string) (c utils.ClientFE, err error) {
 
-	log.Println("FE client MakeClient with configs:", configs)
+	slog.Info("FE client MakeClient", "serverAddr", configs["serverAddr"])
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyConfigs(configs, []string{"serverAddr"}); !ok {
@@ -163,10 +757,24 @@ func MakeClient(configs map[string]string) (c utils.ClientFE, err error) {
 	}
 
 	// Build client implementation.
-	c = &clientImpl{
+	impl := &clientImpl{
 		serverAddr: configs["serverAddr"],
 		httpClient: &http.Client{},
 	}
 
-	return c, nil
+	// Servers requiring authentication are presented the API key or bearer
+	// token.
+	if configs["apiKey"] != "" || configs["bearerToken"] != "" {
+		impl.httpClient.Transport = &authTransport{apiKey: configs["apiKey"],
+			bearerToken: configs["bearerToken"], base: http.DefaultTransport}
+	}
+
+	// End-to-end mode generates record keys of keySize bytes.
+	if configs["e2e"] == "true" {
+		if impl.keygen, err = utils.MakeKeyGen(configs); err != nil {
+			return nil, err
+		}
+	}
+
+	return impl, nil
 }
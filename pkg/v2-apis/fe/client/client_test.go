@@ -1,16 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"enc-server-go/pkg/shamir"
 	"enc-server-go/pkg/utils"
 )
 
@@ -21,32 +25,70 @@ const idHexStr = "4a5448"
 const testID = "test-id"
 const testKey = "test-key"
 const testData = "test-data"
+const testName = "test-name"
+const testAPIKey = "test-api-key"
+const testBearerToken = "test-bearer-token"
 
 // Content types
-const contentTypeJSON = "application/json"
 const contentTypeHeader = "Content-Type"
+const contentTypeOctetStream = "application/octet-stream"
+const acceptHeader = "Accept"
+
+// Precondition headers
+const ifMatchHeader = "If-Match"
+const ifNoneMatchHeader = "If-None-Match"
+
+// Attribute headers
+const etagHeader = "ETag"
+const lastModifiedHeader = "Last-Modified"
+const createdHeader = "X-Record-Created"
+const sizeHeader = "X-Record-Size"
+const recordContentTypeHeader = "X-Record-Content-Type"
+const labelsHeader = "X-Record-Labels"
+const signerHeader = "X-Record-Signer"
+const apiKeyHeader = "X-API-Key"
+const authorizationHeader = "Authorization"
+
+// Record attributes
+const testContentType = "text/plain"
 
 // Server paths
 const serverAddr = "localhost:7777"
 const serverRecordsAddr = "http://localhost:7777/records"
 const serverRecordsEndpoint = "/records/"
 const serverRecordsQuery = "key="
+const oneTimeQueryParam = "oneTime"
+const noCompressQueryParam = "noCompress"
+const recipientQueryParam = "recipient"
+const signerQueryParam = "signer"
+const signatureQueryParam = "signature"
+const keySlotEndpoint = "/keyslot"
+const recipientsEndpoint = "/recipients"
+const serverUserListAddr = "http://localhost:7777/users/746573742d6964/records"
+const serverUserRecordsAddr = "http://localhost:7777/users/746573742d6964/records/746573742d6e616d65"
+const serverSealedRecordAddr = "http://localhost:7777/sealed/records/746573742d6964"
+const serverSealedUserRecordAddr = "http://localhost:7777/sealed/users/746573742d6964/records/746573742d6e616d65"
+const keyHashQueryParam = "keyHash"
 
 // HTTP methods
 const httpMethodPOST = "POST"
 const httpMethodGET = "GET"
 const httpMethodDELETE = "DELETE"
+const httpMethodHEAD = "HEAD"
 
 // Bad status
 const bad404Status = "404 Not Found"
 const bad500Status = "500 Internal Server Error"
+const bad412Status = "412 Precondition Failed"
 
 // Error messages
 const errClientMessage = "MakeClient missing configuration serverAddr"
+const errKeyGenMessage = "MakeKeyGen missing configuration keySize"
 const errConnectionRefused = "connection refused"
 const errServerError = "Server error"
 const errNotFound = "Not found"
 const errInvalidJSON = "invalid json"
+const errDecryptMessage = "cipher: message authentication failed"
 
 // Test Variables
 var (
@@ -56,12 +98,82 @@ var (
 	badClientConfig = map[string]string{
 		"foo": "bar"}
 
+	e2eClientConfig = map[string]string{
+		"serverAddr": serverAddr,
+		"e2e":        "true",
+		"keySize":    "32"}
+
+	apiKeyClientConfig = map[string]string{
+		"serverAddr": serverAddr,
+		"apiKey":     testAPIKey}
+
+	bearerClientConfig = map[string]string{
+		"serverAddr":  serverAddr,
+		"bearerToken": testBearerToken}
+
+	badE2EClientConfig = map[string]string{
+		"serverAddr": serverAddr,
+		"e2e":        "true"}
+
 	goodClient = &clientImpl{
 		serverAddr: serverAddr,
 		httpClient: &http.Client{},
 	}
+
+	testLabels = map[string]string{"env": "prod"}
+
+	binaryData = []byte{0x00, 0xff, 0xfe, 0x80, 0x0a}
+
+	created = time.Date(2023, time.November, 14, 22, 13, 20, 0, time.UTC)
+	updated = time.Date(2023, time.November, 14, 22, 23, 20, 0, time.UTC)
+
+	attributedInfo = utils.RecordInfo{
+		Version:     2,
+		Created:     created,
+		Updated:     updated,
+		Size:        int64(len(testData)),
+		ContentType: testContentType,
+		Labels:      testLabels,
+	}
+
+	// Content signature by a client key
+	contentSignature = &utils.Signature{KeyID: []byte("signerid"), Signature: []byte("signature")}
+
+	// End-to-end key generator returning a fixed record key, the test data
+	// sealed under it, and its key commitment and shares
+	keygen, _     = utils.MakeKeyGen(map[string]string{"keySize": "32"})
+	e2eKey        = []byte("mQ3kT8vZ2pL6nR1wX9cY4bH7jF5sD0aE")
+	e2eKeyGen     = &mockKeyGen{KeyGen: keygen, key: e2eKey}
+	e2eKeyHashStr = hex.EncodeToString(utils.KeyHash(e2eKey))
+	e2eSealed     = func() []byte {
+		cipher, _ := keygen.GetGCMCipher(e2eKey)
+		nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+		return cipher.Seal(nonce, nonce, []byte(testData), nil)
+	}()
+	e2eKeyShares, _ = shamir.Split(e2eKey, 3, 2)
+
+	// Recipient key pair and the test key wrapped for it
+	recipientPrivate, recipientPublic, _ = utils.GenerateRecipientKey()
+	recipientSlot, _                     = utils.WrapKey(recipientPublic, []byte(testKey))
 )
 
+// Mock KeyGen returning a fixed record key
+type mockKeyGen struct {
+	utils.KeyGen
+	key []byte
+}
+
+func (m *mockKeyGen) RandomKey() ([]byte, error) {
+	return m.key, nil
+}
+
+// Helper function to open data sealed under the end-to-end key
+func openE2E(sealed []byte) []byte {
+	cipher, _ := keygen.GetGCMCipher(e2eKey)
+	data, _ := cipher.Open(nil, sealed[:cipher.NonceSize()], sealed[cipher.NonceSize():], nil)
+	return data
+}
+
 // Mock RoundTripper for HTTP mocking
 type mockRoundTripper struct {
 	fn func(req *http.Request) (*http.Response, error)
@@ -78,6 +190,15 @@ func createMockClient(fn func(req *http.Request) (*http.Response, error)) *http.
 	}
 }
 
+// Helper function to build response headers from name/value pairs
+func recordHeaders(pairs ...string) http.Header {
+	header := make(http.Header)
+	for i := 0; i < len(pairs); i += 2 {
+		header.Set(pairs[i], pairs[i+1])
+	}
+	return header
+}
+
 // MakeClient() - Test Method
 func TestClient_MakeClient(t *testing.T) {
 
@@ -97,11 +218,33 @@ func TestClient_MakeClient(t *testing.T) {
 			args: args{goodClientConfig},
 			want: goodClient,
 		},
+		{
+			name: "should run in end-to-end mode successfully",
+			args: args{e2eClientConfig},
+			want: &clientImpl{serverAddr: serverAddr, httpClient: &http.Client{}, keygen: keygen},
+		},
+		{
+			name: "should run with an API key successfully",
+			args: args{apiKeyClientConfig},
+			want: &clientImpl{serverAddr: serverAddr, httpClient: &http.Client{
+				Transport: &authTransport{apiKey: testAPIKey, base: http.DefaultTransport}}},
+		},
+		{
+			name: "should run with a bearer token successfully",
+			args: args{bearerClientConfig},
+			want: &clientImpl{serverAddr: serverAddr, httpClient: &http.Client{
+				Transport: &authTransport{bearerToken: testBearerToken, base: http.DefaultTransport}}},
+		},
 		{
 			name:    "should fail loading configuration",
 			args:    args{badClientConfig},
 			wantErr: errors.New(errClientMessage),
 		},
+		{
+			name:    "should fail building end-to-end key generator",
+			args:    args{badE2EClientConfig},
+			wantErr: errors.New(errKeyGenMessage),
+		},
 	}
 
 	for _, test := range tests {
@@ -113,14 +256,54 @@ func TestClient_MakeClient(t *testing.T) {
 	}
 }
 
+// authTransport - Test Method
+func TestClient_authTransport(t *testing.T) {
+	tests := []struct {
+		name           string
+		transport      *authTransport
+		expectedAPIKey string
+		expectedAuth   string
+	}{
+		{
+			name:           "should present API key",
+			transport:      &authTransport{apiKey: testAPIKey},
+			expectedAPIKey: testAPIKey,
+		},
+		{
+			name:         "should present bearer token",
+			transport:    &authTransport{bearerToken: testBearerToken},
+			expectedAuth: "Bearer " + testBearerToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.transport.base = &mockRoundTripper{fn: func(req *http.Request) (*http.Response, error) {
+				// Verify only the configured credential is presented
+				assert.Equal(t, test.expectedAPIKey, req.Header.Get(apiKeyHeader))
+				assert.Equal(t, test.expectedAuth, req.Header.Get(authorizationHeader))
+				return &http.Response{StatusCode: http.StatusAccepted, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}}
+			c := &clientImpl{serverAddr: serverAddr, httpClient: &http.Client{Transport: test.transport}}
+
+			err := c.DeleteRecord([]byte(testID), nil, []byte(testKey), utils.DeleteOptions{})
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // StoreRecord() - Test Method
 func TestClient_StoreRecord(t *testing.T) {
 	tests := []struct {
 		name        string
 		id          []byte
+		recName     []byte
 		data        []byte
+		opts        utils.StoreOptions
+		keygen      utils.KeyGen
 		mockFn      func(req *http.Request) (*http.Response, error)
 		wantKey     []byte
+		wantInfo    utils.RecordInfo
 		wantErr     bool
 		errContains string
 	}{
@@ -131,14 +314,21 @@ func TestClient_StoreRecord(t *testing.T) {
 			mockFn: func(req *http.Request) (*http.Response, error) {
 				// Verify request details
 				assert.Equal(t, httpMethodPOST, req.Method)
-				assert.True(t, strings.HasPrefix(req.URL.String(), serverRecordsAddr))
-				assert.Equal(t, contentTypeJSON, req.Header.Get(contentTypeHeader))
+				assert.Equal(t, serverRecordsAddr+"?id=746573742d6964", req.URL.String())
+				assert.Equal(t, contentTypeOctetStream, req.Header.Get(contentTypeHeader))
+				assert.Empty(t, req.Header.Get(ifMatchHeader))
+				assert.Empty(t, req.Header.Get(recordContentTypeHeader))
+				assert.Empty(t, req.Header.Get(labelsHeader))
+
+				// Verify record bytes are sent as the body
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, []byte(testData), body)
 
 				// Return successful response
 				responseRecord := record{
-					ID:   hex.EncodeToString([]byte(testID)),
-					Key:  hex.EncodeToString([]byte(testKey)),
-					Data: hex.EncodeToString([]byte(testData)),
+					ID:      hex.EncodeToString([]byte(testID)),
+					Key:     hex.EncodeToString([]byte(testKey)),
+					Version: 1,
 				}
 				respBody, _ := json.Marshal(responseRecord)
 
@@ -148,8 +338,276 @@ func TestClient_StoreRecord(t *testing.T) {
 					Header:     make(http.Header),
 				}, nil
 			},
-			wantKey: []byte(testKey),
-			wantErr: false,
+			wantKey:  []byte(testKey),
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store signed record successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{ContentSignature: contentSignature},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify the content signature is sent as query parameters
+				assert.Equal(t, hex.EncodeToString(contentSignature.KeyID), req.URL.Query().Get(signerQueryParam))
+				assert.Equal(t, hex.EncodeToString(contentSignature.Signature), req.URL.Query().Get(signatureQueryParam))
+
+				responseRecord := record{
+					Key:     hex.EncodeToString([]byte(testKey)),
+					Version: 1,
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  []byte(testKey),
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name:   "should store end-to-end record successfully",
+			id:     []byte(testID),
+			data:   []byte(testData),
+			opts:   utils.StoreOptions{ContentType: testContentType},
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify the record is posted sealed with a key commitment
+				assert.Equal(t, httpMethodPOST, req.Method)
+				assert.Equal(t, serverSealedRecordAddr+"?"+keyHashQueryParam+"="+e2eKeyHashStr, req.URL.String())
+				assert.Equal(t, contentTypeOctetStream, req.Header.Get(contentTypeHeader))
+				assert.Equal(t, testContentType, req.Header.Get(recordContentTypeHeader))
+
+				// Verify only the cipher entry leaves the client
+				body, _ := io.ReadAll(req.Body)
+				assert.False(t, bytes.Contains(body, []byte(testData)))
+				assert.Equal(t, []byte(testData), openE2E(body))
+
+				responseRecord := record{
+					ID:          hex.EncodeToString([]byte(testID)),
+					Version:     1,
+					ContentType: testContentType,
+					Size:        int64(len(testData)),
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  e2eKey,
+			wantInfo: utils.RecordInfo{Version: 1, ContentType: testContentType, Size: int64(len(testData))},
+			wantErr:  false,
+		},
+		{
+			name:    "should store named one-time end-to-end record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			data:    []byte(testData),
+			opts:    utils.StoreOptions{OneTime: true},
+			keygen:  e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, serverSealedUserRecordAddr+"?"+keyHashQueryParam+"="+e2eKeyHashStr+
+					"&"+oneTimeQueryParam+"=true", req.URL.String())
+
+				respBody, _ := json.Marshal(record{Version: 1, OneTime: true})
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  e2eKey,
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name:   "should fail storing end-to-end record for recipients",
+			id:     []byte(testID),
+			data:   []byte(testData),
+			opts:   utils.StoreOptions{Recipients: [][]byte{recipientPublic}},
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				t.Error("Unexpected request")
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Recipients are not supported in end-to-end mode",
+		},
+		{
+			name:   "should fail storing end-to-end record with content signature",
+			id:     []byte(testID),
+			data:   []byte(testData),
+			opts:   utils.StoreOptions{ContentSignature: contentSignature},
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				t.Error("Unexpected request")
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Content signatures are not supported in end-to-end mode",
+		},
+		{
+			name: "should store binary record successfully",
+			id:   []byte(testID),
+			data: binaryData,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify non-UTF-8 bytes are sent unmodified
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, binaryData, body)
+
+				responseRecord := record{
+					Key:     hex.EncodeToString([]byte(testKey)),
+					Version: 1,
+					Size:    int64(len(binaryData)),
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  []byte(testKey),
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(binaryData))},
+			wantErr:  false,
+		},
+		{
+			name:    "should store named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			data:    []byte(testData),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, httpMethodPOST, req.Method)
+				assert.Equal(t, serverUserRecordsAddr, req.URL.String())
+
+				responseRecord := record{
+					ID:      hex.EncodeToString([]byte(testID)),
+					Name:    hex.EncodeToString([]byte(testName)),
+					Key:     hex.EncodeToString([]byte(testKey)),
+					Version: 1,
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  []byte(testKey),
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store one-time record successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{OneTime: true, NoCompress: true},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify one-time and compression flags are sent as query parameters
+				assert.Equal(t, "true", req.URL.Query().Get(oneTimeQueryParam))
+				assert.Equal(t, "true", req.URL.Query().Get(noCompressQueryParam))
+
+				responseRecord := record{
+					Key:     hex.EncodeToString([]byte(testKey)),
+					OneTime: true,
+					Version: 1,
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  []byte(testKey),
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store record for recipients successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{Recipients: [][]byte{recipientPublic, []byte(testKey)}},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify recipients are sent as repeated query parameters
+				assert.Equal(t, []string{hex.EncodeToString(recipientPublic), hex.EncodeToString([]byte(testKey))},
+					req.URL.Query()[recipientQueryParam])
+
+				// Records stored for recipients return no key
+				respBody, _ := json.Marshal(record{Version: 1})
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantInfo: utils.RecordInfo{Version: 1},
+			wantErr:  false,
+		},
+		{
+			name: "should store record with attributes successfully",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{ContentType: testContentType, Labels: testLabels},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify attributes are sent as headers
+				assert.Equal(t, testContentType, req.Header.Get(recordContentTypeHeader))
+				assert.Equal(t, "env=prod", req.Header.Get(labelsHeader))
+
+				responseRecord := record{
+					ID:          hex.EncodeToString([]byte(testID)),
+					Key:         hex.EncodeToString([]byte(testKey)),
+					Version:     2,
+					Size:        int64(len(testData)),
+					ContentType: testContentType,
+					Labels:      testLabels,
+					Created:     created,
+					Updated:     updated,
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  []byte(testKey),
+			wantInfo: attributedInfo,
+			wantErr:  false,
+		},
+		{
+			name: "should fail on mismatched revision",
+			id:   []byte(testID),
+			data: []byte(testData),
+			opts: utils.StoreOptions{Match: utils.Precondition{IfMatch: 2}},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify precondition headers
+				assert.Equal(t, `"2"`, req.Header.Get(ifMatchHeader))
+				assert.Empty(t, req.Header.Get(ifNoneMatchHeader))
+
+				return &http.Response{
+					StatusCode: http.StatusPreconditionFailed,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+					Status:     bad412Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
 		},
 		{
 			name: "should fail when request returns non-201 status",
@@ -190,6 +648,20 @@ func TestClient_StoreRecord(t *testing.T) {
 			wantErr:     true,
 			errContains: "Error unmarshalling record",
 		},
+		{
+			name: "should fail on invalid response key",
+			id:   []byte(testID),
+			data: []byte(testData),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"zz"}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding key",
+		},
 	}
 
 	for _, test := range tests {
@@ -197,9 +669,10 @@ func TestClient_StoreRecord(t *testing.T) {
 			client := &clientImpl{
 				serverAddr: serverAddr,
 				httpClient: createMockClient(test.mockFn),
+				keygen:     test.keygen,
 			}
 
-			got, err := client.StoreRecord(test.id, test.data)
+			got, info, err := client.StoreRecord(test.id, test.recName, test.data, test.opts)
 
 			if test.wantErr {
 				assert.Error(t, err)
@@ -207,6 +680,7 @@ func TestClient_StoreRecord(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, test.wantKey, got)
+				assert.Equal(t, test.wantInfo, info)
 			}
 		})
 	}
@@ -217,9 +691,12 @@ func TestClient_RetrieveRecord(t *testing.T) {
 	tests := []struct {
 		name        string
 		id          []byte
+		recName     []byte
 		key         []byte
+		keygen      utils.KeyGen
 		mockFn      func(req *http.Request) (*http.Response, error)
 		wantData    []byte
+		wantInfo    utils.RecordInfo
 		wantErr     bool
 		errContains string
 	}{
@@ -232,80 +709,1119 @@ func TestClient_RetrieveRecord(t *testing.T) {
 				assert.Equal(t, httpMethodGET, req.Method)
 				assert.True(t, strings.Contains(req.URL.String(), serverRecordsEndpoint))
 				assert.True(t, strings.Contains(req.URL.RawQuery, serverRecordsQuery))
+				assert.Equal(t, contentTypeOctetStream, req.Header.Get(acceptHeader))
 
-				// Return successful response
-				responseRecord := record{
-					ID:   hex.EncodeToString([]byte(testID)),
-					Key:  hex.EncodeToString([]byte(testKey)),
-					Data: hex.EncodeToString([]byte(testData)),
-				}
-				respBody, _ := json.Marshal(responseRecord)
-
+				// Return raw record bytes with attribute headers
 				return &http.Response{
 					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(strings.NewReader(string(respBody))),
-					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     recordHeaders(etagHeader, `"3"`, sizeHeader, strconv.Itoa(len(testData))),
 				}, nil
 			},
-			wantData: []byte(hex.EncodeToString([]byte(testData))),
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 3, Size: int64(len(testData))},
 			wantErr:  false,
 		},
 		{
-			name: "should fail when request returns non-200 status",
-			id:   []byte(testID),
-			key:  []byte(testKey),
+			name:    "should retrieve named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			key:     []byte(testKey),
 			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.True(t, strings.HasPrefix(req.URL.String(), serverUserRecordsAddr+"?"))
+
 				return &http.Response{
-					StatusCode: http.StatusNotFound,
-					Body:       io.NopCloser(strings.NewReader(errNotFound)),
-					Header:     make(http.Header),
-					Status:     bad404Status,
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData))),
 				}, nil
 			},
-			wantErr:     true,
-			errContains: "Bad status making GET request",
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(testData))},
+			wantErr:  false,
 		},
 		{
-			name: "should fail on request error",
+			name: "should retrieve binary record successfully",
 			id:   []byte(testID),
 			key:  []byte(testKey),
 			mockFn: func(req *http.Request) (*http.Response, error) {
-				return nil, errors.New(errConnectionRefused)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(binaryData)),
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(binaryData))),
+				}, nil
 			},
-			wantErr:     true,
-			errContains: "Error making GET request",
+			wantData: binaryData,
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(binaryData))},
+			wantErr:  false,
 		},
 		{
-			name: "should fail on invalid response JSON",
+			name: "should retrieve record with attributes successfully",
 			id:   []byte(testID),
 			key:  []byte(testKey),
 			mockFn: func(req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(strings.NewReader(errInvalidJSON)),
-					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header: recordHeaders(
+						etagHeader, `"2"`,
+						lastModifiedHeader, updated.Format(http.TimeFormat),
+						createdHeader, created.Format(http.TimeFormat),
+						sizeHeader, strconv.Itoa(len(testData)),
+						recordContentTypeHeader, testContentType,
+						labelsHeader, "env=prod",
+					),
 				}, nil
 			},
-			wantErr:     true,
-			errContains: "Error unmarshalling record",
+			wantData: []byte(testData),
+			wantInfo: attributedInfo,
+			wantErr:  false,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			client := &clientImpl{
-				serverAddr: serverAddr,
-				httpClient: createMockClient(test.mockFn),
-			}
+		{
+			name:   "should retrieve end-to-end record successfully",
+			id:     []byte(testID),
+			key:    e2eKey,
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify the cipher entry is requested with the key commitment
+				assert.Equal(t, httpMethodGET, req.Method)
+				assert.Equal(t, serverSealedRecordAddr+"?"+keyHashQueryParam+"="+e2eKeyHashStr, req.URL.String())
 
-			got, err := client.RetrieveRecord(test.id, test.key)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(e2eSealed)),
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(testData))},
+			wantErr:  false,
+		},
+		{
+			name:   "should fail opening end-to-end record with the wrong key",
+			id:     []byte(testID),
+			key:    []byte(strings.Repeat("k", len(e2eKey))),
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(e2eSealed)),
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decrypting record",
+		},
+		{
+			name: "should retrieve signed record successfully",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header: recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData)),
+						signerHeader, hex.EncodeToString(contentSignature.KeyID)),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(testData)), Signer: contentSignature.KeyID},
+			wantErr:  false,
+		},
+		{
+			name: "should fail with invalid signer header",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header: recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData)),
+						signerHeader, "zz"),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding signer",
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(errNotFound)),
+					Header:     make(http.Header),
+					Status:     bad404Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making GET request",
+		},
+		{
+			name: "should fail on request error",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making GET request",
+		},
+		{
+			name: "should fail on invalid attribute headers",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding ETag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+				keygen:     test.keygen,
+			}
+
+			got, info, err := client.RetrieveRecord(test.id, test.recName, test.key)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantData, got)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// StoreRecordShares() - Test Method
+func TestClient_StoreRecordShares(t *testing.T) {
+	keyShares := [][]byte{[]byte("share-1"), []byte("share-2"), []byte("share-3")}
+
+	tests := []struct {
+		name        string
+		recName     []byte
+		keygen      utils.KeyGen
+		mockFn      func(req *http.Request) (*http.Response, error)
+		want        [][]byte
+		wantKey     []byte
+		wantInfo    utils.RecordInfo
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should store record with key shares successfully",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify share counts are requested
+				assert.Equal(t, httpMethodPOST, req.Method)
+				assert.Equal(t, serverRecordsAddr+"?id=746573742d6964&shares=3&threshold=2", req.URL.String())
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, []byte(testData), body)
+
+				responseRecord := record{Version: 1}
+				for _, share := range keyShares {
+					responseRecord.KeyShares = append(responseRecord.KeyShares, hex.EncodeToString(share))
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			want:     keyShares,
+			wantInfo: utils.RecordInfo{Version: 1},
+		},
+		{
+			name:   "should store end-to-end record with key shares successfully",
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify the key is split by the client, not the server
+				assert.Equal(t, serverSealedRecordAddr+"?"+keyHashQueryParam+"="+e2eKeyHashStr, req.URL.String())
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, []byte(testData), openE2E(body))
+
+				respBody, _ := json.Marshal(record{Version: 1})
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantKey:  e2eKey,
+			wantInfo: utils.RecordInfo{Version: 1},
+		},
+		{
+			name:    "should store named record with key shares successfully",
+			recName: []byte(testName),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, serverUserRecordsAddr+"?shares=3&threshold=2", req.URL.String())
+
+				responseRecord := record{Version: 1}
+				for _, share := range keyShares {
+					responseRecord.KeyShares = append(responseRecord.KeyShares, hex.EncodeToString(share))
+				}
+				respBody, _ := json.Marshal(responseRecord)
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			want:     keyShares,
+			wantInfo: utils.RecordInfo{Version: 1},
+		},
+		{
+			name: "should fail when key shares are missing",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				respBody, _ := json.Marshal(record{Key: hex.EncodeToString([]byte(testKey)), Version: 1})
+
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "expected 3 key shares, received 0",
+		},
+		{
+			name: "should fail on invalid hex key share",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"keyShares":["invalid"]}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding key share",
+		},
+		{
+			name: "should fail when request returns non-201 status",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making POST request",
+		},
+		{
+			name: "should fail on request error",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making POST request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+				keygen:     test.keygen,
+			}
+
+			got, info, err := client.StoreRecordShares([]byte(testID), test.recName, []byte(testData), 3, 2,
+				utils.StoreOptions{})
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				assert.Nil(t, got)
+			} else if test.wantKey != nil {
+				// Shares split by the client recover its key
+				assert.NoError(t, err)
+				assert.Len(t, got, 3)
+				key, _ := shamir.Combine(got[1:])
+				assert.Equal(t, test.wantKey, key)
+				assert.Equal(t, test.wantInfo, info)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// RetrieveRecordShares() - Test Method
+func TestClient_RetrieveRecordShares(t *testing.T) {
+	keyShares := [][]byte{[]byte("share-1"), []byte("share-2")}
+
+	tests := []struct {
+		name        string
+		recName     []byte
+		shares      [][]byte
+		keygen      utils.KeyGen
+		mockFn      func(req *http.Request) (*http.Response, error)
+		wantData    []byte
+		wantInfo    utils.RecordInfo
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should retrieve record with key shares successfully",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify key shares are presented in place of the key
+				assert.Equal(t, httpMethodGET, req.Method)
+				assert.Equal(t, serverRecordsAddr+"/746573742d6964?share="+hex.EncodeToString(keyShares[0])+
+					"&share="+hex.EncodeToString(keyShares[1]), req.URL.String())
+				assert.Equal(t, contentTypeOctetStream, req.Header.Get(acceptHeader))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     recordHeaders(etagHeader, `"3"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 3, Size: int64(len(testData))},
+		},
+		{
+			name:   "should retrieve end-to-end record with key shares successfully",
+			shares: e2eKeyShares[:2],
+			keygen: e2eKeyGen,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify the key is recovered by the client, not the server
+				assert.Equal(t, serverSealedRecordAddr+"?"+keyHashQueryParam+"="+e2eKeyHashStr, req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(e2eSealed)),
+					Header:     recordHeaders(etagHeader, `"3"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 3, Size: int64(len(testData))},
+		},
+		{
+			name:    "should retrieve named record with key shares successfully",
+			recName: []byte(testName),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.True(t, strings.HasPrefix(req.URL.String(), serverUserRecordsAddr+"?share="))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     recordHeaders(etagHeader, `"3"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 3, Size: int64(len(testData))},
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making GET request",
+		},
+		{
+			name: "should fail on request error",
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making GET request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+				keygen:     test.keygen,
+			}
+			shares := test.shares
+			if shares == nil {
+				shares = keyShares
+			}
+
+			got, info, err := client.RetrieveRecordShares([]byte(testID), test.recName, shares)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantData, got)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// RetrieveRecordFor() - Test Method
+func TestClient_RetrieveRecordFor(t *testing.T) {
+
+	// Key slot response for the test recipient, optionally corrupted
+	keySlotResponse := func(slot utils.KeySlot) (*http.Response, error) {
+		respBody, _ := json.Marshal(keySlot{
+			Recipient: hex.EncodeToString(slot.Recipient),
+			Ephemeral: hex.EncodeToString(slot.Ephemeral),
+			Wrapped:   hex.EncodeToString(slot.Wrapped),
+		})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(respBody)),
+			Header:     make(http.Header),
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		id          []byte
+		recName     []byte
+		private     []byte
+		mockFn      func(req *http.Request) (*http.Response, error)
+		wantData    []byte
+		wantInfo    utils.RecordInfo
+		wantErr     error
+		errContains string
+	}{
+		{
+			name:    "should retrieve record for recipient successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, httpMethodGET, req.Method)
+				assert.True(t, strings.HasPrefix(req.URL.String(), serverUserRecordsAddr))
+
+				// Verify the key slot is requested for the recipient public key
+				if strings.HasSuffix(req.URL.Path, keySlotEndpoint) {
+					assert.Equal(t, hex.EncodeToString(recipientPublic), req.URL.Query().Get(recipientQueryParam))
+					return keySlotResponse(recipientSlot)
+				}
+
+				// Verify the record is retrieved with the unwrapped key
+				assert.Equal(t, hex.EncodeToString([]byte(testKey)), req.URL.Query().Get("key"))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(testData)),
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, strconv.Itoa(len(testData))),
+				}, nil
+			},
+			wantData: []byte(testData),
+			wantInfo: utils.RecordInfo{Version: 1, Size: int64(len(testData))},
+		},
+		{
+			name:    "should fail without a key slot for the recipient",
+			id:      []byte(testID),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(errNotFound)),
+					Header:     make(http.Header),
+					Status:     bad404Status,
+				}, nil
+			},
+			wantErr:     utils.ErrNoKeySlot,
+			errContains: utils.ErrNoKeySlot.Error(),
+		},
+		{
+			name:    "should fail when request returns non-200 status",
+			id:      []byte(testID),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+					Status:     bad500Status,
+				}, nil
+			},
+			errContains: "Bad status making GET request",
+		},
+		{
+			name:    "should fail on request error",
+			id:      []byte(testID),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			errContains: "Error making GET request",
+		},
+		{
+			name:    "should fail on invalid JSON response",
+			id:      []byte(testID),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(errInvalidJSON)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			errContains: "Error unmarshalling key slot",
+		},
+		{
+			name:    "should fail unwrapping a corrupted key slot",
+			id:      []byte(testID),
+			private: recipientPrivate,
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				slot := recipientSlot
+				slot.Wrapped = slot.Wrapped[1:]
+				return keySlotResponse(slot)
+			},
+			errContains: errDecryptMessage,
+		},
+		{
+			name:        "should fail with invalid private key",
+			id:          []byte(testID),
+			private:     []byte(testKey),
+			errContains: "crypto/ecdh: invalid private key size",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+			}
+
+			got, info, err := client.RetrieveRecordFor(test.id, test.recName, test.private)
+
+			if test.errContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				if test.wantErr != nil {
+					assert.Equal(t, test.wantErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantData, got)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// AddRecipients() - Test Method
+func TestClient_AddRecipients(t *testing.T) {
+	slotID := utils.KeySlotID(recipientSlot)
+	slotsBody := `{"id":"746573742d6964","version":3,"slots":["` + hex.EncodeToString(slotID) + `"]}`
+
+	tests := []struct {
+		name        string
+		id          []byte
+		recName     []byte
+		mockFn      func(req *http.Request) (*http.Response, error)
+		want        [][]byte
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should add recipients successfully",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify request details
+				assert.Equal(t, httpMethodPOST, req.Method)
+				assert.Equal(t, serverRecordsAddr+"/746573742d6964"+recipientsEndpoint, req.URL.String())
+
+				// Verify request body
+				body, _ := io.ReadAll(req.Body)
+				var reqBody map[string]any
+				_ = json.Unmarshal(body, &reqBody)
+				assert.Equal(t, hex.EncodeToString([]byte(testKey)), reqBody["key"])
+				assert.Equal(t, []any{hex.EncodeToString(recipientPublic)}, reqBody["recipients"])
+				assert.Equal(t, []any{hex.EncodeToString([]byte(testKey))}, reqBody["members"])
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(slotsBody)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			want: [][]byte{slotID},
+		},
+		{
+			name:    "should add recipients to named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, serverUserRecordsAddr+recipientsEndpoint, req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(slotsBody)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			want: [][]byte{slotID},
+		},
+		{
+			name: "should fail with a key not granting the record key",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name: "should fail on a record changed concurrently",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusPreconditionFailed,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+					Status:     bad412Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+					Status:     bad500Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making POST request",
+		},
+		{
+			name: "should fail on invalid JSON response",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(errInvalidJSON)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error unmarshalling key slots",
+		},
+		{
+			name: "should fail on request error",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making POST request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+			}
+
+			got, err := client.AddRecipients(test.id, test.recName, []byte(testKey),
+				[][]byte{recipientPublic}, [][]byte{[]byte(testKey)})
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+// RevokeRecipient() - Test Method
+func TestClient_RevokeRecipient(t *testing.T) {
+	slotID := utils.KeySlotID(recipientSlot)
+
+	tests := []struct {
+		name        string
+		id          []byte
+		recName     []byte
+		mockFn      func(req *http.Request) (*http.Response, error)
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should revoke recipient successfully",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify request details
+				assert.Equal(t, httpMethodDELETE, req.Method)
+				assert.Equal(t, serverRecordsAddr+"/746573742d6964"+recipientsEndpoint+"/"+
+					hex.EncodeToString(slotID)+"?"+serverRecordsQuery+hex.EncodeToString([]byte(testKey)),
+					req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"746573742d6964","slots":[]}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+		{
+			name: "should fail without a key slot",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+					Status:     bad404Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrNoKeySlot.Error(),
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+					Status:     bad500Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making DELETE request",
+		},
+		{
+			name: "should fail on request error",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making DELETE request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+			}
+
+			err := client.RevokeRecipient(test.id, test.recName, []byte(testKey), slotID)
 
 			if test.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), test.errContains)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, test.wantData, got)
+			}
+		})
+	}
+}
+
+// StatRecord() - Test Method
+func TestClient_StatRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          []byte
+		recName     []byte
+		mockFn      func(req *http.Request) (*http.Response, error)
+		wantInfo    utils.RecordInfo
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should stat record successfully",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify request details
+				assert.Equal(t, httpMethodHEAD, req.Method)
+				assert.Equal(t, serverRecordsAddr+"/746573742d6964", req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header: recordHeaders(
+						etagHeader, `"2"`,
+						lastModifiedHeader, updated.Format(http.TimeFormat),
+						createdHeader, created.Format(http.TimeFormat),
+						sizeHeader, strconv.Itoa(len(testData)),
+						recordContentTypeHeader, testContentType,
+						labelsHeader, "env=prod",
+					),
+				}, nil
+			},
+			wantInfo: attributedInfo,
+		},
+		{
+			name:    "should stat named record without attributes",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, serverUserRecordsAddr, req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, "0"),
+				}, nil
+			},
+			wantInfo: utils.RecordInfo{Version: 1},
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       http.NoBody,
+					Header:     make(http.Header),
+					Status:     bad404Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making HEAD request",
+		},
+		{
+			name: "should fail on request error",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making HEAD request",
+		},
+		{
+			name: "should fail on invalid ETag",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header:     recordHeaders(etagHeader, "latest", sizeHeader, "0"),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding ETag",
+		},
+		{
+			name: "should fail on invalid size",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, "large"),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding size",
+		},
+		{
+			name: "should fail on invalid update time",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, "0", lastModifiedHeader, "yesterday"),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding update time",
+		},
+		{
+			name: "should fail on invalid labels",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       http.NoBody,
+					Header:     recordHeaders(etagHeader, `"1"`, sizeHeader, "0", labelsHeader, "env=%zz"),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error decoding labels",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+			}
+
+			info, err := client.StatRecord(test.id, test.recName)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantInfo, info)
+			}
+		})
+	}
+}
+
+// ListRecords() - Test Method
+func TestClient_ListRecords(t *testing.T) {
+	created := time.UnixMilli(1700000000000).UTC()
+
+	tests := []struct {
+		name        string
+		id          []byte
+		opts        utils.ListOptions
+		mockFn      func(req *http.Request) (*http.Response, error)
+		wantRecords []utils.RecordSummary
+		wantNext    string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "should list records successfully",
+			id:   []byte(testID),
+			opts: utils.ListOptions{Cursor: "abc", Limit: 1},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify request details
+				assert.Equal(t, httpMethodGET, req.Method)
+				assert.Equal(t, serverUserListAddr+"?cursor=abc&limit=1", req.URL.String())
+
+				// Return successful response
+				responseList := recordList{
+					ID: hex.EncodeToString([]byte(testID)),
+					Records: []recordSummary{{
+						Name:    hex.EncodeToString([]byte(testName)),
+						Size:    9,
+						Created: created,
+					}},
+					Next: "def",
+				}
+				respBody, _ := json.Marshal(responseList)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(string(respBody))),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantRecords: []utils.RecordSummary{{
+				Name:    []byte(testName),
+				Size:    9,
+				Created: created,
+			}},
+			wantNext: "def",
+			wantErr:  false,
+		},
+		{
+			name: "should list without options",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, serverUserListAddr, req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"","records":[]}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail when request returns non-200 status",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       io.NopCloser(strings.NewReader(errServerError)),
+					Header:     make(http.Header),
+					Status:     bad500Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Bad status making GET request",
+		},
+		{
+			name: "should fail on request error",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New(errConnectionRefused)
+			},
+			wantErr:     true,
+			errContains: "Error making GET request",
+		},
+		{
+			name: "should fail on invalid response JSON",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(errInvalidJSON)),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: "Error unmarshalling record list",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &clientImpl{
+				serverAddr: serverAddr,
+				httpClient: createMockClient(test.mockFn),
+			}
+
+			got, next, err := client.ListRecords(test.id, test.opts)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.errContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantRecords, got)
+				assert.Equal(t, test.wantNext, next)
 			}
 		})
 	}
@@ -316,6 +1832,9 @@ func TestClient_DeleteRecord(t *testing.T) {
 	tests := []struct {
 		name        string
 		id          []byte
+		recName     []byte
+		key         []byte
+		opts        utils.DeleteOptions
 		mockFn      func(req *http.Request) (*http.Response, error)
 		wantErr     bool
 		errContains string
@@ -323,10 +1842,62 @@ func TestClient_DeleteRecord(t *testing.T) {
 		{
 			name: "should delete record successfully",
 			id:   []byte(testID),
+			key:  []byte(testKey),
 			mockFn: func(req *http.Request) (*http.Response, error) {
-				// Verify request details
+				// Verify request details, carrying the record key
 				assert.Equal(t, httpMethodDELETE, req.Method)
 				assert.True(t, strings.HasPrefix(req.URL.String(), serverRecordsAddr))
+				assert.Equal(t, hex.EncodeToString([]byte(testKey)), req.URL.Query().Get("key"))
+
+				return &http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name:    "should delete named record successfully",
+			id:      []byte(testID),
+			recName: []byte(testName),
+			key:     []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify named records are addressed under their user ID
+				assert.Equal(t, httpMethodDELETE, req.Method)
+				assert.Equal(t, serverUserRecordsAddr+"?key="+hex.EncodeToString([]byte(testKey)), req.URL.String())
+
+				return &http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "should delete record without key as admin successfully",
+			id:   []byte(testID),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify no key is presented
+				assert.Empty(t, req.URL.RawQuery)
+
+				return &http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "should delete record with preconditions successfully",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			opts: utils.DeleteOptions{Match: utils.Precondition{IfExists: true}},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				// Verify precondition headers
+				assert.Equal(t, utils.AnyRevision, req.Header.Get(ifMatchHeader))
 
 				return &http.Response{
 					StatusCode: http.StatusAccepted,
@@ -336,6 +1907,36 @@ func TestClient_DeleteRecord(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should fail on mismatched key",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name: "should fail on mismatched revision",
+			id:   []byte(testID),
+			key:  []byte(testKey),
+			opts: utils.DeleteOptions{Match: utils.Precondition{IfMatch: 2}},
+			mockFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusPreconditionFailed,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+					Status:     bad412Status,
+				}, nil
+			},
+			wantErr:     true,
+			errContains: utils.ErrPreconditionFailed.Error(),
+		},
 		{
 			name: "should fail when request returns non-202 status",
 			id:   []byte(testID),
@@ -368,7 +1969,7 @@ func TestClient_DeleteRecord(t *testing.T) {
 				httpClient: createMockClient(test.mockFn),
 			}
 
-			err := client.DeleteRecord(test.id)
+			err := client.DeleteRecord(test.id, test.recName, test.key, test.opts)
 
 			if test.wantErr {
 				assert.Error(t, err)
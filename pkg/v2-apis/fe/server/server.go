@@ -2,119 +2,1726 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
-	"log"
+	"io"
+	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"enc-server-go/pkg/shamir"
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/client"
 )
 
 type Record struct {
-	ID   string `json:"id"`
-	Key  string `json:"key"`
-	Data string `json:"data"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name,omitempty"`
+	Key         string            `json:"key"`
+	Data        string            `json:"data"`
+	Encoding    string            `json:"encoding,omitempty"`
+	OneTime     bool              `json:"oneTime,omitempty"`
+	NoCompress  bool              `json:"noCompress,omitempty"`
+	Recipients  []string          `json:"recipients,omitempty"`
+	Shares      int               `json:"shares,omitempty"`
+	Threshold   int               `json:"threshold,omitempty"`
+	KeyShares   []string          `json:"keyShares,omitempty"`
+	Signer      string            `json:"signer,omitempty"`
+	Signature   string            `json:"signature,omitempty"`
+	Version     int64             `json:"version,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Size        int64             `json:"size"`
+	Created     time.Time         `json:"created,omitzero"`
+	Updated     time.Time         `json:"updated,omitzero"`
+}
+
+// Record data encodings within JSON bodies. Stored data defaults to hex;
+// retrieved data defaults to the raw string for existing clients.
+const (
+	EncodingHex    = "hex"
+	EncodingBase64 = "base64"
+)
+
+// Record body media types.
+const (
+	MIMEOctetStream = "application/octet-stream"
+	MIMEMultipart   = "multipart/form-data"
+)
+
+type KeySlot struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Recipient string `json:"recipient"`
+	Ephemeral string `json:"ephemeral"`
+	Wrapped   string `json:"wrapped"`
+	Version   int64  `json:"version,omitempty"`
+}
+
+type Recipients struct {
+	Key        string   `json:"key"`
+	Recipients []string `json:"recipients,omitempty"`
+	Members    []string `json:"members,omitempty"`
+}
+
+type KeySlots struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Version int64    `json:"version,omitempty"`
+	Slots   []string `json:"slots"`
+}
+
+type Versions struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name,omitempty"`
+	Versions []int64 `json:"versions"`
+}
+
+type RecordSummary struct {
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType,omitempty"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+type RecordList struct {
+	ID      string          `json:"id"`
+	Records []RecordSummary `json:"records"`
+	Next    string          `json:"next,omitempty"`
 }
 
 type Server interface {
 
-	// Start server.
-	Start() (err error)
-}
+	// Start server.
+	Start() (err error)
+}
+
+// Server implementation
+type serverImpl struct {
+	keygen utils.KeyGen
+
+	idNonce    []byte
+	idCipher   cipher.AEAD
+	nameKey    []byte
+	metaCipher cipher.AEAD
+
+	compressor utils.Compressor
+	padder     utils.Padder
+	signer     utils.Signer
+
+	auth   utils.Authenticator
+	tokens utils.TokenVerifier
+
+	limiter        utils.RateLimiter
+	trustedProxies []string
+	auditor        utils.Auditor
+
+	beClient utils.ClientBE
+
+	serverAddr string
+}
+
+func backendStatus(err error, status int) int {
+
+	// Failed preconditions, mismatched keys, untrusted signatures and missing
+	// records, versions or key slots are reported to the caller, not as server
+	// faults.
+	switch {
+	case errors.Is(err, utils.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, utils.ErrKeyMismatch), errors.Is(err, utils.ErrSignerUnknown),
+		errors.Is(err, utils.ErrSignatureInvalid), errors.Is(err, utils.ErrSignatureMissing):
+		return http.StatusForbidden
+	case errors.Is(err, utils.ErrNoKeySlot), errors.Is(err, utils.ErrRecordNotFound),
+		errors.Is(err, utils.ErrVersionNotFound):
+		return http.StatusNotFound
+	}
+	return status
+}
+
+func decodeData(encoding, dataStr string) (data []byte, err error) {
+
+	// Stored data is hex-encoded unless base64 is requested.
+	switch encoding {
+	case "", EncodingHex:
+		return hex.DecodeString(dataStr)
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(dataStr)
+	}
+	return nil, errors.New("Unsupported encoding " + encoding)
+}
+
+func encodeData(encoding string, data []byte) (dataStr string, err error) {
+
+	// Retrieved data is returned as a raw string unless an encoding is requested.
+	switch encoding {
+	case "":
+		return string(data), nil
+	case EncodingHex:
+		return hex.EncodeToString(data), nil
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+	return "", errors.New("Unsupported encoding " + encoding)
+}
+
+func decodeRecipients(recipientStrs []string) (recipients [][]byte, err error) {
+
+	// Recipients are hex-encoded X25519 public keys.
+	for _, recipientStr := range recipientStrs {
+		recipient, err := hex.DecodeString(recipientStr)
+		if err != nil {
+			return nil, err
+		}
+		if err = utils.ParseRecipient(recipient); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+func decodeMembers(memberStrs []string) (members [][]byte, err error) {
+
+	// Members are hex-encoded AES keys of their own.
+	for _, memberStr := range memberStrs {
+		member, err := hex.DecodeString(memberStr)
+		if err != nil {
+			return nil, err
+		}
+		if err = utils.ParseMemberKey(member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func decodeKey(keyStr string, shareStrs []string) (key []byte, err error) {
+
+	// Keys split on store are presented as a threshold of hex-encoded shares.
+	if keyStr != "" || len(shareStrs) == 0 {
+		return hex.DecodeString(keyStr)
+	}
+	shares := make([][]byte, len(shareStrs))
+	for i, shareStr := range shareStrs {
+		if shares[i], err = hex.DecodeString(shareStr); err != nil {
+			return nil, err
+		}
+	}
+	return shamir.Combine(shares)
+}
+
+func decodeKeyHash(keyHashStr string) (keyHash []byte, err error) {
+
+	// Sealed records are authorized by a SHA-256 commitment to their key.
+	keyHash, err = hex.DecodeString(keyHashStr)
+	if err != nil || len(keyHash) != sha256.Size {
+		return nil, errors.New("Sealed records require a hex SHA-256 keyHash")
+	}
+	return keyHash, nil
+}
+
+func parseShares(sharesStr, thresholdStr string) (shares, threshold int, err error) {
+
+	// Share counts outside JSON bodies are carried as decimal strings.
+	if sharesStr != "" {
+		if shares, err = strconv.Atoi(sharesStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	if thresholdStr != "" {
+		if threshold, err = strconv.Atoi(thresholdStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	return shares, threshold, nil
+}
+
+func decodeSignature(signerStr, signatureStr string) (content *utils.Signature, err error) {
+
+	// Content signatures carry the hex key ID of their signer.
+	if signerStr == "" && signatureStr == "" {
+		return nil, nil
+	}
+	content = &utils.Signature{}
+	if content.KeyID, err = hex.DecodeString(signerStr); err != nil {
+		return nil, err
+	}
+	if content.Signature, err = hex.DecodeString(signatureStr); err != nil {
+		return nil, err
+	}
+	if len(content.KeyID) == 0 || len(content.Signature) == 0 {
+		return nil, errors.New("Content signatures require a signer and signature")
+	}
+	return content, nil
+}
+
+func parseLabels(labelsStr string) (labels map[string]string, err error) {
+
+	// Labels outside JSON bodies are carried URL-encoded.
+	if labelsStr == "" {
+		return nil, nil
+	}
+	values, err := url.ParseQuery(labelsStr)
+	if err != nil {
+		return nil, err
+	}
+	labels = make(map[string]string, len(values))
+	for label := range values {
+		labels[label] = values.Get(label)
+	}
+	return labels, nil
+}
+
+func bindRecord(c *gin.Context) (newRecord Record, data []byte, body io.ReadCloser, err error) {
+
+	// Record bodies are JSON, raw bytes, or a multipart form. Raw and
+	// multipart bodies are returned unread so they can be streamed.
+	switch c.ContentType() {
+	case MIMEOctetStream:
+		newRecord.ID = c.Query("id")
+		newRecord.OneTime = c.Query("oneTime") == "true"
+		newRecord.NoCompress = c.Query("noCompress") == "true"
+		newRecord.Recipients = c.QueryArray("recipient")
+		newRecord.ContentType = c.GetHeader("X-Record-Content-Type")
+		if newRecord.Labels, err = parseLabels(c.GetHeader("X-Record-Labels")); err != nil {
+			return newRecord, nil, nil, err
+		}
+		if newRecord.Shares, newRecord.Threshold, err = parseShares(c.Query("shares"), c.Query("threshold")); err != nil {
+			return newRecord, nil, nil, err
+		}
+		newRecord.Signer, newRecord.Signature = c.Query("signer"), c.Query("signature")
+		return newRecord, nil, c.Request.Body, nil
+
+	case MIMEMultipart:
+		file, err := c.FormFile("data")
+		if err != nil {
+			return newRecord, nil, nil, err
+		}
+		newRecord.ID = c.PostForm("id")
+		newRecord.OneTime = c.PostForm("oneTime") == "true"
+		newRecord.NoCompress = c.PostForm("noCompress") == "true"
+		newRecord.Recipients = c.PostFormArray("recipient")
+		newRecord.ContentType = c.DefaultPostForm("contentType", file.Header.Get("Content-Type"))
+		if newRecord.Labels, err = parseLabels(c.PostForm("labels")); err != nil {
+			return newRecord, nil, nil, err
+		}
+		if newRecord.Shares, newRecord.Threshold, err = parseShares(c.PostForm("shares"), c.PostForm("threshold")); err != nil {
+			return newRecord, nil, nil, err
+		}
+		newRecord.Signer, newRecord.Signature = c.PostForm("signer"), c.PostForm("signature")
+		part, err := file.Open()
+		if err != nil {
+			return newRecord, nil, nil, err
+		}
+		return newRecord, nil, part, nil
+	}
+
+	if err = c.BindJSON(&newRecord); err != nil {
+		return newRecord, nil, nil, err
+	}
+	data, err = decodeData(newRecord.Encoding, newRecord.Data)
+	return newRecord, data, nil, err
+}
+
+// Record body sealed as a chunked stream while it is read.
+type sealedBody struct {
+	*io.PipeReader
+	size     int64
+	unpadded int64
+}
+
+func sealBody(aead cipher.AEAD, prefix []byte, compressor utils.Compressor, padder utils.Padder,
+	body io.Reader) (sealed *sealedBody) {
+	pr, pw := io.Pipe()
+	sealed = &sealedBody{PipeReader: pr}
+
+	// Bodies are compressed, then padded ahead of sealing when a compressor
+	// or padder is given. The plaintext and unpadded sizes are final once the
+	// sealed stream has been read to its end. Closing the reader early stops
+	// sealing.
+	go func() {
+		pw.CloseWithError(sealed.seal(aead, prefix, compressor, padder, body, pw))
+	}()
+	return sealed
+}
+
+func (sealed *sealedBody) seal(aead cipher.AEAD, prefix []byte, compressor utils.Compressor, padder utils.Padder,
+	body io.Reader, w io.Writer) (err error) {
+
+	// Writers are layered over the sealer and closed from the outermost in.
+	sealer, err := utils.NewStreamSealer(aead, prefix, w)
+	if err != nil {
+		return err
+	}
+	writers := []io.WriteCloser{sealer}
+	var padded *utils.PadWriter
+	if padder != nil {
+		padded = utils.NewPadWriter(padder, sealer)
+		writers = append(writers, padded)
+	}
+	if compressor != nil {
+		cw, err := compressor.NewWriter(writers[len(writers)-1])
+		if err != nil {
+			return err
+		}
+		writers = append(writers, cw)
+	}
+
+	if sealed.size, err = io.Copy(writers[len(writers)-1], body); err != nil {
+		return err
+	}
+	for i := len(writers) - 1; i >= 0; i-- {
+		if err = writers[i].Close(); err != nil {
+			return err
+		}
+	}
+	if padded != nil {
+		sealed.unpadded = padded.Unpadded()
+	}
+	return nil
+}
+
+func setRecordHeaders(c *gin.Context, info utils.RecordInfo) {
+
+	// Record attributes are reported without the record contents.
+	c.Header("ETag", utils.FormatETag(info.Version))
+	if !info.Updated.IsZero() {
+		c.Header("Last-Modified", info.Updated.UTC().Format(http.TimeFormat))
+	}
+	if !info.Created.IsZero() {
+		c.Header("X-Record-Created", info.Created.UTC().Format(http.TimeFormat))
+	}
+	c.Header("X-Record-Size", strconv.FormatInt(info.Size, 10))
+	if info.ContentType != "" {
+		c.Header("X-Record-Content-Type", info.ContentType)
+	}
+	if len(info.Labels) > 0 {
+		labels := url.Values{}
+		for label, value := range info.Labels {
+			labels.Set(label, value)
+		}
+		c.Header("X-Record-Labels", labels.Encode())
+	}
+	if len(info.Signer) > 0 {
+		c.Header("X-Record-Signer", hex.EncodeToString(info.Signer))
+	}
+}
+
+func (s *serverImpl) openRecordInfo(id, name, idEncrypt, nameEncrypt []byte,
+	info utils.RecordInfo) (opened utils.RecordInfo, content *utils.Signature, err error) {
+
+	// Record signatures are verified before the attributes they cover are
+	// decrypted, returning any content signature to verify over the record.
+	if content, err = s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures); err != nil {
+		return info, nil, err
+	}
+	if opened, err = utils.OpenRecordInfo(s.metaCipher, id, name, info); err != nil {
+		return info, nil, err
+	}
+	return opened, content, nil
+}
+
+func authStatus(err error) int {
+
+	// Unknown keys and invalid tokens must authenticate, known keys and
+	// tokens lack a scope or user ID, and keys failing to load leave the
+	// service unavailable.
+	switch {
+	case errors.Is(err, utils.ErrUnauthenticated), errors.Is(err, utils.ErrTokenInvalid),
+		errors.Is(err, utils.ErrTokenExpired):
+		return http.StatusUnauthorized
+	case errors.Is(err, utils.ErrForbidden), errors.Is(err, utils.ErrUserForbidden):
+		return http.StatusForbidden
+	}
+	return http.StatusServiceUnavailable
+}
+
+func (s *serverImpl) authenticate(c *gin.Context, scope string) (client string, err error) {
+
+	// Bearer tokens carry a principal, which may only address its own user
+	// IDs unless an admin.
+	token, bearer := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if bearer && s.tokens.Enabled() {
+		principal, err := s.tokens.Verify(token)
+		if err != nil {
+			return "", err
+		}
+		c.Set("principal", principal)
+
+		// Routes without the user ID in their path check it once their
+		// handler has read it from the request.
+		idStr, ok := c.Params.Get("id")
+		if !ok {
+			return principal.Subject, principal.AuthorizeScope(scope)
+		}
+		id, err := hex.DecodeString(idStr)
+		if err != nil {
+			return principal.Subject, utils.ErrUserForbidden
+		}
+		return principal.Subject, principal.Authorize(scope, id)
+	}
+
+	// Servers accepting only bearer tokens require one.
+	if s.tokens.Enabled() && !s.auth.Enabled() {
+		return "", utils.ErrTokenInvalid
+	}
+
+	// Otherwise requests present an API key holding the scope of their route.
+	return s.auth.Authorize(c.GetHeader("X-API-Key"), scope)
+}
+
+func (s *serverImpl) authorize(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		// The client or token subject, and any token principal, are kept for
+		// audit logging, whether authorized or not.
+		client, err := s.authenticate(c, scope)
+		c.Set("client", client)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "FE server authorize error", "client", client, "error", err)
+			c.Abort()
+			c.IndentedJSON(authStatus(err), gin.H{"message": err.Error()})
+			return
+		}
+		if !s.allow(c, s.clientKeys(c)...) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// Checks any bearer token principal may address the user ID a handler read
+// from its request, responding when not.
+func authorizeID(c *gin.Context, scope string, id []byte) bool {
+	principal, ok := c.Get("principal")
+	if !ok {
+		return true
+	}
+	if err := principal.(utils.Principal).Authorize(scope, id); err != nil {
+		slog.WarnContext(c.Request.Context(), "FE server authorize error", "client", c.GetString("client"),
+			"error", err)
+		c.IndentedJSON(authStatus(err), gin.H{"message": err.Error()})
+		return false
+	}
+	return true
+}
+
+func (s *serverImpl) audit(operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if !s.auditor.Enabled() {
+			return
+		}
+
+		// Records are identified by path, or by the body of an unnamed store.
+		idStr, nameStr := c.Param("id"), c.Param("name")
+		if idStr == "" {
+			idStr, nameStr = c.GetString("recordID"), c.GetString("recordName")
+		}
+		event := utils.AuditEvent{
+			Operation: operation,
+			Principal: c.GetString("client"),
+			Outcome:   utils.AuditOK,
+			RequestID: utils.RequestIDFromContext(c.Request.Context()),
+		}
+		id, idErr := hex.DecodeString(idStr)
+		name, nameErr := hex.DecodeString(nameStr)
+		if idErr == nil && nameErr == nil && len(id) > 0 {
+			event.RecordID = utils.AuditRecordID(s.idCipher.Seal(s.idNonce, s.idNonce, id, nil),
+				utils.SealName(s.idCipher, s.nameKey, id, name))
+		}
+		if status := c.Writer.Status(); status >= http.StatusBadRequest {
+			event.Outcome = strconv.Itoa(status) + " " + http.StatusText(status)
+		}
+
+		if err := s.auditor.Record(event); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server audit error", "error", err)
+		}
+	}
+}
+
+// Names the operation of a request by its route, so unmatched paths share one.
+func operation(c *gin.Context) string {
+	if c.FullPath() == "" {
+		return "unmatched"
+	}
+	return c.Request.Method + " " + c.FullPath()
+}
+
+func observe(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+	utils.ObserveRequest(operation(c), start, utils.StatusErrorType(c.Writer.Status()))
+}
+
+func traceRequest(c *gin.Context) {
+
+	// Back-end calls made by handlers are traced under the request span.
+	ctx, span := utils.StartRequestSpan(c.Request, operation(c))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+	utils.EndRequestSpan(span, c.FullPath(), c.Writer.Status())
+}
+
+// Requests are identified by the ID their caller passed in an X-Request-ID
+// header, or a new one, returned in the response and carried in the request
+// context for logging and passing on to the back-end service.
+func requestID(c *gin.Context) {
+	id := c.GetHeader(utils.RequestIDHeader)
+	if !utils.ValidRequestID(id) {
+		id = utils.NewRequestID()
+	}
+	c.Header(utils.RequestIDHeader, id)
+	c.Request = c.Request.WithContext(utils.ContextWithRequestID(c.Request.Context(), id))
+	c.Next()
+}
+
+func (s *serverImpl) rateLimit(c *gin.Context) {
+
+	// Requests draw on buckets for their route keyed by their client IP, and
+	// once authorized by their credential and user ID too.
+	if !s.allow(c, "ip:"+c.ClientIP()) {
+		return
+	}
+	c.Next()
+}
+
+// Keys of the buckets authorized requests draw on: a hash of the credential
+// that authorized them and the user ID they address.
+func (s *serverImpl) clientKeys(c *gin.Context) (keys []string) {
+	credential := ""
+	if _, ok := c.Get("principal"); ok {
+		credential = c.GetHeader("Authorization")
+	} else if s.auth.Enabled() {
+		credential = c.GetHeader("X-API-Key")
+	}
+	if credential != "" {
+		keys = append(keys, "key:"+utils.CredentialHash(credential))
+	}
+	if id := c.Param("id"); id != "" {
+		keys = append(keys, "id:"+id)
+	}
+	return keys
+}
+
+// Takes a request from the buckets of keys for the route, responding when
+// any is empty.
+func (s *serverImpl) allow(c *gin.Context, keys ...string) bool {
+	retryAfter, err := s.limiter.Allow(c.Request.Method+" "+c.FullPath(), keys...)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "FE server rateLimit error", "error", err)
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.Abort()
+		c.IndentedJSON(http.StatusTooManyRequests, gin.H{"message": err.Error()})
+		return false
+	}
+	return true
+}
+
+func (s *serverImpl) loadRecord(ctx context.Context, id, name, key []byte) (record []byte, err error) {
+
+	// Generate fixed cipher entries for ID and name, and cipher for record.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+	cipher, err := s.keygen.GetGCMCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the record whole, then verify and decrypt it as getRecord does.
+	opts := utils.RetrieveOptions{KeyHash: utils.KeyHash(key)}
+	recordEncrypt, info, err := s.beClient.WithContext(ctx).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		return nil, err
+	}
+	info, content, err := s.openRecordInfo(id, name, idEncrypt, nameEncrypt, info)
+	if err != nil {
+		return nil, err
+	}
+	if info.Digest != nil {
+		if err = utils.VerifyDigest(recordEncrypt, info.Digest); err != nil {
+			return nil, err
+		}
+	}
+	if info.Chunked {
+		record, err = utils.OpenStream(cipher, recordEncrypt)
+	} else if len(recordEncrypt) < cipher.NonceSize() {
+		err = errors.New("Sealed record too short")
+	} else {
+		nonce, remainder := recordEncrypt[:cipher.NonceSize()], recordEncrypt[cipher.NonceSize():]
+		record, err = cipher.Open(nil, nonce, remainder, nil)
+	}
+	if err == nil && info.Padding != "" {
+		record, err = utils.Unpad(record, info.Unpadded)
+	}
+	if err == nil {
+		record, err = utils.Decompress(s.compressor, info.Compression, info.Size, record)
+	}
+	if err == nil && content != nil {
+		digest := sha256.Sum256(record)
+		err = s.signer.VerifyContent(id, name, digest[:], content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *serverImpl) admin(c *gin.Context) bool {
+
+	// Admins present a bearer token or API key holding the admin scope, so
+	// there are none when authentication is off.
+	if principal, ok := c.Get("principal"); ok {
+		return slices.Contains(principal.(utils.Principal).Scopes, utils.ScopeAdmin)
+	}
+	if !s.auth.Enabled() {
+		return false
+	}
+	_, err := s.auth.Authorize(c.GetHeader("X-API-Key"), utils.ScopeAdmin)
+	return err == nil
+}
+
+func (s *serverImpl) proveKey(ctx context.Context, id, name, idEncrypt, nameEncrypt,
+	presented []byte) (version int64, err error) {
+
+	// Record attributes are read without consuming one-time records.
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	_, info, err := s.beClient.WithContext(ctx).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures); err != nil {
+		return 0, err
+	}
+	meta, err := utils.OpenMetadata(s.metaCipher, id, name, info.Metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	// Only the record key is verified, against its sealed commitment, so
+	// member keys cannot delete. Records stored before commitments were
+	// sealed prove the key by opening their cipher entry.
+	err = utils.AuthorizeOwner(meta, presented)
+	if errors.Is(err, utils.ErrKeyMismatch) && len(meta.KeyHash) == 0 {
+		if _, err = s.loadRecord(ctx, id, name, presented); err != nil {
+			err = utils.ErrKeyMismatch
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Version, nil
+}
+
+func (s *serverImpl) postRecord(c *gin.Context) {
+
+	// Extract record ID and data
+	newRecord, data, body, err := bindRecord(c)
+	if body != nil {
+		defer body.Close()
+	}
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Named records take their ID and name from the path
+	if idStr := c.Param("id"); idStr != "" {
+		newRecord.ID, newRecord.Name = idStr, c.Param("name")
+	}
+	c.Set("recordID", newRecord.ID)
+	c.Set("recordName", newRecord.Name)
+
+	slog.InfoContext(c.Request.Context(), "FE server received a post request", "id", newRecord.ID)
+
+	// Extract revision preconditions
+	match, err := utils.ParsePrecondition(c.GetHeader("If-Match"), c.GetHeader("If-None-Match"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(newRecord.ID)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	if !authorizeID(c, utils.ScopeStore, id) {
+		return
+	}
+
+	// Records posted without the ID in their path draw on its bucket once it
+	// is read from the body.
+	if c.Param("id") == "" && !s.allow(c, "id:"+newRecord.ID) {
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(newRecord.Name)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract recipient public keys to hex
+	recipients, err := decodeRecipients(newRecord.Recipients)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract optional client content signature
+	content, err := decodeSignature(newRecord.Signer, newRecord.Signature)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Records stored for recipients return no key to split.
+	split := newRecord.Shares != 0 || newRecord.Threshold != 0
+	if split && len(recipients) > 0 {
+		err = errors.New("Key shares cannot be combined with recipients")
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Generate cipher entries for ID and name.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Generate random AES key.
+	key, err := s.keygen.RandomKey()
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Split the record key into shares, any threshold of which recover it.
+	var shares [][]byte
+	if split {
+		if shares, err = shamir.Split(key, newRecord.Shares, newRecord.Threshold); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	// Generate key, cipher, and nonce for record.
+	cipher, err := s.keygen.GetGCMCipher(key)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Randomly generate nonce (initialization vector). Streamed records
+	// take a nonce prefix completed per segment.
+	nonceSize := cipher.NonceSize()
+	if body != nil {
+		nonceSize = utils.StreamPrefixSize
+	}
+	nonce, err := s.keygen.RandomNonce(nonceSize)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// One-time records carry a commitment to their key.
+	opts := utils.StoreOptions{OneTime: newRecord.OneTime, Match: match}
+	if newRecord.OneTime {
+		opts.KeyHash = utils.KeyHash(key)
+	}
+
+	// Seal record attributes under the ID cipher with a fresh nonce. The key
+	// commitment authorizes later changes to the record recipients.
+	meta := utils.RecordMetadata{
+		ContentType: newRecord.ContentType,
+		Labels:      newRecord.Labels,
+		KeyHash:     utils.KeyHash(key),
+	}
+	metaNonce, err := s.keygen.RandomNonce(s.idCipher.NonceSize())
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Wrap the record key for each recipient in place of returning it.
+	for _, recipient := range recipients {
+		slot, err := utils.WrapKey(recipient, key)
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		meta.KeySlots = append(meta.KeySlots, slot)
+	}
+
+	// Compress record contents before sealing unless opted out.
+	var compressor utils.Compressor
+	if codec := s.compressor.Codec(); codec != "" && !newRecord.NoCompress {
+		compressor, meta.Compression = s.compressor, codec
+	}
+
+	// Pad record contents to hide their length when configured.
+	var padder utils.Padder
+	if scheme := s.padder.Scheme(); scheme != "" {
+		padder, meta.Padding = s.padder, scheme
+	}
+
+	// Signed attributes carry a digest of the stored cipher entry, and
+	// sign any content signature along with them.
+	opts.ContentSignature = content
+	opts.SignMetadata = func(metadata []byte) ([]byte, error) {
+		return s.signer.SignEnvelope(idEncrypt, nameEncrypt, metadata, content)
+	}
+
+	var info utils.RecordInfo
+	if body != nil {
+
+		// Seal raw and multipart bodies as a chunked stream as they arrive.
+		// Attributes are sealed and content signatures verified once the
+		// whole body has been read.
+		plainHash, cipherHash := sha256.New(), sha256.New()
+		sealed := sealBody(cipher, nonce, compressor, padder, io.TeeReader(body, plainHash))
+		defer sealed.Close()
+		meta.Chunked = true
+		opts.SealMetadata = func() ([]byte, error) {
+			if content != nil {
+				if err := s.signer.VerifyContent(id, name, plainHash.Sum(nil), content); err != nil {
+					return nil, err
+				}
+			}
+			if s.signer.Signing() {
+				meta.Digest = cipherHash.Sum(nil)
+			}
+			meta.Size, meta.Unpadded = sealed.size, sealed.unpadded
+			return utils.SealMetadata(s.metaCipher, metaNonce, id, name, meta)
+		}
+		beClient := s.beClient.WithContext(c.Request.Context())
+		info, err = beClient.StoreStream(idEncrypt, nameEncrypt, io.TeeReader(sealed, cipherHash), opts)
+	} else {
+		if content != nil {
+			digest := sha256.Sum256(data)
+			if err = s.signer.VerifyContent(id, name, digest[:], content); err != nil {
+				slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+				c.IndentedJSON(backendStatus(err, http.StatusBadRequest), gin.H{"message": err.Error()})
+				return
+			}
+		}
+		meta.Size = int64(len(data))
+		if compressor != nil {
+			if data, err = utils.Compress(compressor, data); err != nil {
+				slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+				c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+				return
+			}
+		}
+		if padder != nil {
+			meta.Unpadded = int64(len(data))
+			data = utils.Pad(padder, data)
+		}
+
+		// Generate cipher entry for record, then seal and sign attributes.
+		recordEncrypt := cipher.Seal(nonce, nonce, data, nil)
+		if s.signer.Signing() {
+			digest := sha256.Sum256(recordEncrypt)
+			meta.Digest = digest[:]
+		}
+		if opts.Metadata, err = utils.SealMetadata(s.metaCipher, metaNonce, id, name, meta); err == nil {
+			opts.Signatures, err = opts.SignMetadata(opts.Metadata)
+		}
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+
+		// Place in data store.
+		info, err = s.beClient.WithContext(c.Request.Context()).StoreRecord(idEncrypt, nameEncrypt, recordEncrypt, opts)
+	}
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusBadGateway), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Return new record with key, version and attributes. Records stored
+	// for recipients return no key, and split keys return their shares.
+	if split {
+		for _, share := range shares {
+			newRecord.KeyShares = append(newRecord.KeyShares, hex.EncodeToString(share))
+		}
+	} else if len(recipients) == 0 {
+		newRecord.Key = hex.EncodeToString(key)
+	}
+	newRecord.Version = info.Version
+	newRecord.Size = meta.Size
+	newRecord.Created, newRecord.Updated = info.Created, info.Updated
+	c.Header("ETag", utils.FormatETag(info.Version))
+	c.IndentedJSON(http.StatusCreated, newRecord)
+}
+
+func (s *serverImpl) getRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+	keyStr := c.Query("key")
+	shareStrs := c.QueryArray("share")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a get request", "id", idStr)
+
+	// Verify paramaters
+	if keyStr == "" && len(shareStrs) == 0 {
+		slog.WarnContext(c.Request.Context(), "FE server getRecord error", "error", "key not defined")
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "key not defined"})
+		return
+	}
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract key to hex, or recover it from key shares
+	key, err := decodeKey(keyStr, shareStrs)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract optional prior version
+	opts := utils.RetrieveOptions{KeyHash: utils.KeyHash(key)}
+	if versionStr := c.Query("version"); versionStr != "" {
+		if opts.Version, err = strconv.ParseInt(versionStr, 10, 64); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	// Verify requested data encoding before touching the record
+	encoding := c.Query("encoding")
+	if _, err = encodeData(encoding, nil); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Generate cipher for record.
+	cipher, err := s.keygen.GetGCMCipher(key)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Retrieve record from data store. One-time records are consumed
+	// against the record key, which members unwrap from their key slot.
+	ctx := c.Request.Context()
+	record, info, err := s.beClient.WithContext(ctx).RetrieveStream(idEncrypt, nameEncrypt, opts)
+	if errors.Is(err, utils.ErrKeyMismatch) {
+		if recordKey, memberErr := s.memberKey(ctx, id, name, key); memberErr == nil {
+			opts.KeyHash = utils.KeyHash(recordKey)
+			record, info, err = s.beClient.WithContext(ctx).RetrieveStream(idEncrypt, nameEncrypt, opts)
+		}
+	}
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+	defer record.Close()
+
+	// Verify record signatures and decrypt record attributes.
+	info, content, err := s.openRecordInfo(id, name, idEncrypt, nameEncrypt, info)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Signed records fail in place of ending when their cipher entry does
+	// not match its signed digest.
+	var recordEncrypt io.Reader = record
+	if info.Digest != nil {
+		recordEncrypt = utils.NewDigestReader(record, info.Digest)
+	}
+
+	// Member keys unwrap the record key from their key slot.
+	if recordKey, err := utils.MemberKey(info.KeySlots, key); err == nil {
+		if cipher, err = s.keygen.GetGCMCipher(recordKey); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	// Chunked records are decrypted, unpadded and decompressed as they are
+	// read. Others are decrypted whole from their cipher entry.
+	var plain io.Reader
+	if info.Chunked {
+		var unpadded io.Reader = utils.NewStreamOpener(cipher, recordEncrypt)
+		if info.Padding != "" {
+			unpadded = utils.NewUnpadReader(unpadded, info.Unpadded)
+		}
+		opened, err := s.compressor.NewReader(info.Compression, info.Size, unpadded)
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		defer opened.Close()
+		plain = opened
+	} else {
+		var data []byte
+		sealed, err := io.ReadAll(recordEncrypt)
+		if err == nil && len(sealed) < cipher.NonceSize() {
+			err = errors.New("Sealed record too short")
+		}
+		if err == nil {
+
+			// Cipher entries failing authentication were sealed under
+			// another key.
+			nonce := sealed[:cipher.NonceSize()]
+			remainder := sealed[cipher.NonceSize():]
+			if data, err = cipher.Open(nil, nonce, remainder, nil); err != nil {
+				err = utils.ErrKeyMismatch
+			}
+		}
+		if err == nil && info.Padding != "" {
+			data, err = utils.Unpad(data, info.Unpadded)
+		}
+		if err == nil {
+			data, err = utils.Decompress(s.compressor, info.Compression, info.Size, data)
+		}
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+			c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+			return
+		}
+		info.Size = int64(len(data))
+		plain = bytes.NewReader(data)
+	}
+
+	// Content signatures are verified once the record has been read, and
+	// report their signer.
+	if content != nil {
+		plain = utils.NewContentReader(s.signer, plain, id, name, content)
+		info.Signer = content.KeyID
+	}
+
+	// Return raw record bytes when requested, with attributes as headers.
+	// Streamed records failing to decrypt midway end short of their size.
+	if c.NegotiateFormat(gin.MIMEJSON, MIMEOctetStream) == MIMEOctetStream {
+		setRecordHeaders(c, info)
+		c.DataFromReader(http.StatusOK, info.Size, MIMEOctetStream, plain, nil)
+		if last := c.Errors.Last(); last != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(last.Err), "FE server getRecord error", "error", last.Err)
+		}
+		return
+	}
+
+	data, err := io.ReadAll(plain)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	info.Size = int64(len(data))
+
+	// Return retrieved record with key and attributes
+	dataStr, _ := encodeData(encoding, data)
+	retrievedRecord := Record{
+		ID:          idStr,
+		Name:        nameStr,
+		Data:        dataStr,
+		Encoding:    encoding,
+		Key:         keyStr,
+		Version:     info.Version,
+		ContentType: info.ContentType,
+		Labels:      info.Labels,
+		Size:        info.Size,
+		Created:     info.Created,
+		Updated:     info.Updated,
+	}
+	if content != nil {
+		retrievedRecord.Signer = hex.EncodeToString(content.KeyID)
+	}
+	c.Header("ETag", utils.FormatETag(info.Version))
+	c.IndentedJSON(http.StatusOK, retrievedRecord)
+}
+
+func (s *serverImpl) postSealedRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a sealed post request", "id", idStr)
+
+	// Sealed records are posted as raw cipher entries.
+	if c.ContentType() != MIMEOctetStream {
+		err := errors.New("Sealed records must be posted as " + MIMEOctetStream)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract revision preconditions
+	match, err := utils.ParsePrecondition(c.GetHeader("If-Match"), c.GetHeader("If-None-Match"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract the commitment to the client's record key
+	keyHash, err := decodeKeyHash(c.Query("keyHash"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract record attributes
+	labels, err := parseLabels(c.GetHeader("X-Record-Labels"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Read the cipher entry, sealed whole by the client as the server seals
+	// records: a nonce followed by the AES-GCM ciphertext.
+	recordEncrypt, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	overhead := s.idCipher.NonceSize() + s.idCipher.Overhead()
+	if len(recordEncrypt) < overhead {
+		err = errors.New("Sealed record too short")
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Generate cipher entries for ID and name.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// One-time records carry a commitment to their key.
+	oneTime := c.Query("oneTime") == "true"
+	opts := utils.StoreOptions{OneTime: oneTime, Match: match}
+	if oneTime {
+		opts.KeyHash = keyHash
+	}
+
+	// Seal and sign record attributes as for records sealed by the server.
+	// The contents are never seen, so no content signature applies.
+	meta := utils.RecordMetadata{
+		ContentType:  c.GetHeader("X-Record-Content-Type"),
+		Size:         int64(len(recordEncrypt) - overhead),
+		Labels:       labels,
+		KeyHash:      keyHash,
+		ClientSealed: true,
+	}
+	if s.signer.Signing() {
+		digest := sha256.Sum256(recordEncrypt)
+		meta.Digest = digest[:]
+	}
+	metaNonce, err := s.keygen.RandomNonce(s.idCipher.NonceSize())
+	if err == nil {
+		opts.Metadata, err = utils.SealMetadata(s.metaCipher, metaNonce, id, name, meta)
+	}
+	if err == nil {
+		opts.Signatures, err = s.signer.SignEnvelope(idEncrypt, nameEncrypt, opts.Metadata, nil)
+	}
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Place in data store.
+	info, err := s.beClient.WithContext(c.Request.Context()).StoreRecord(idEncrypt, nameEncrypt, recordEncrypt, opts)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postSealedRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusBadGateway), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Return new record version and attributes. The key stays with the client.
+	newRecord := Record{
+		ID:          idStr,
+		Name:        nameStr,
+		OneTime:     oneTime,
+		Version:     info.Version,
+		ContentType: meta.ContentType,
+		Labels:      labels,
+		Size:        meta.Size,
+		Created:     info.Created,
+		Updated:     info.Updated,
+	}
+	c.Header("ETag", utils.FormatETag(info.Version))
+	c.IndentedJSON(http.StatusCreated, newRecord)
+}
+
+func (s *serverImpl) getSealedRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a sealed get request", "id", idStr)
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract the commitment to the client's record key
+	keyHash, err := decodeKeyHash(c.Query("keyHash"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract optional prior version
+	opts := utils.RetrieveOptions{KeyHash: keyHash}
+	if versionStr := c.Query("version"); versionStr != "" {
+		if opts.Version, err = strconv.ParseInt(versionStr, 10, 64); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Retrieve record from data store. One-time records are consumed
+	// against the key commitment.
+	recordEncrypt, info, err := s.beClient.WithContext(c.Request.Context()).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Verify record signatures and decrypt record attributes.
+	if _, err = s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+	meta, err := utils.OpenMetadata(s.metaCipher, id, name, info.Metadata)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
 
-// Server implementation
-type serverImpl struct {
-	keygen utils.KeyGen
+	// Only records sealed by the client are returned sealed, to holders of
+	// their key commitment.
+	if !meta.ClientSealed {
+		err = errors.New("Record was not sealed by the client")
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusConflict, gin.H{"message": err.Error()})
+		return
+	}
+	if subtle.ConstantTimeCompare(meta.KeyHash, keyHash) != 1 {
+		err = utils.ErrKeyMismatch
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+		c.IndentedJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		return
+	}
+	if meta.Digest != nil {
+		if err = utils.VerifyDigest(recordEncrypt, meta.Digest); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getSealedRecord error", "error", err)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+	}
 
-	idNonce  []byte
-	idCipher cipher.AEAD
+	// Return the cipher entry with attributes as headers.
+	info, _ = utils.OpenRecordInfo(s.metaCipher, id, name, info)
+	setRecordHeaders(c, info)
+	c.Data(http.StatusOK, MIMEOctetStream, recordEncrypt)
+}
 
-	beClient utils.ClientBE
+func (s *serverImpl) memberKey(ctx context.Context, id, name, member []byte) (key []byte, err error) {
 
-	serverAddr string
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Record attributes are read without consuming one-time records.
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	_, info, err := s.beClient.WithContext(ctx).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		return nil, err
+	}
+	if info, _, err = s.openRecordInfo(id, name, idEncrypt, nameEncrypt, info); err != nil {
+		return nil, err
+	}
+	return utils.MemberKey(info.KeySlots, member)
 }
 
-func (s *serverImpl) postRecord(c *gin.Context) {
+func (s *serverImpl) headRecord(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
 
-	// Extract record ID and data
-	var newRecord Record
-	if err := c.BindJSON(&newRecord); err != nil {
-		log.Println("FE server postRecord error:", err)
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	slog.InfoContext(c.Request.Context(), "FE server received a head request", "id", idStr)
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server headRecord error", "error", err)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server headRecord error", "error", err)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Extract optional prior version
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	if versionStr := c.Query("version"); versionStr != "" {
+		if opts.Version, err = strconv.ParseInt(versionStr, 10, 64); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server headRecord error", "error", err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Retrieve record attributes from data store.
+	_, info, err := s.beClient.WithContext(c.Request.Context()).RetrieveRecord(idEncrypt, nameEncrypt, opts)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server headRecord error", "error", err)
+		c.Status(backendStatus(err, http.StatusInternalServerError))
 		return
 	}
 
-	log.Println("FE server received a post request for", newRecord.ID)
+	// Verify record signatures and decrypt record attributes.
+	if info, _, err = s.openRecordInfo(id, name, idEncrypt, nameEncrypt, info); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server headRecord error", "error", err)
+		c.Status(backendStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	// Return record attributes as headers
+	setRecordHeaders(c, info)
+	c.Status(http.StatusOK)
+}
+
+func (s *serverImpl) getKeySlot(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+	recipientStr := c.Query("recipient")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a key slot request", "id", idStr)
 
 	// Extract ID to hex
-	id, err := hex.DecodeString(newRecord.ID)
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
 	if err != nil {
-		log.Println("FE server postRecord error:", err)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Extract data to hex
-	data, err := hex.DecodeString(newRecord.Data)
+	// Extract recipient public key to hex
+	recipient, err := hex.DecodeString(recipientStr)
 	if err != nil {
-		log.Println("FE server postRecord error:", err)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Generate cipher entry for ID.
+	// Generate fixed cipher entries for ID and name for lookup.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
 
-	// Generate random AES key.
-	key, err := s.keygen.RandomKey()
+	// Retrieve record attributes from data store.
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	_, info, err := s.beClient.WithContext(c.Request.Context()).RetrieveRecord(idEncrypt, nameEncrypt, opts)
 	if err != nil {
-		log.Println("FE server postRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
 		return
 	}
 
-	// Generate key, cipher, and nonce for record.
-	cipher, err := s.keygen.GetGCMCipher(key)
+	// Verify record signatures and decrypt record attributes.
+	if info, _, err = s.openRecordInfo(id, name, idEncrypt, nameEncrypt, info); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Find the record key wrapped for the recipient. Only the holder of the
+	// matching private key can unwrap it.
+	slot, err := utils.FindKeySlot(info.KeySlots, recipient)
 	if err != nil {
-		log.Println("FE server postRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getKeySlot error", "error", err)
+		c.IndentedJSON(http.StatusNotFound, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Randomly generate nonce (initialization vector).
-	nonce, err := s.keygen.RandomNonce(cipher.NonceSize())
+	// Return key slot
+	c.IndentedJSON(http.StatusOK, KeySlot{
+		ID:        idStr,
+		Name:      nameStr,
+		Recipient: hex.EncodeToString(slot.Recipient),
+		Ephemeral: hex.EncodeToString(slot.Ephemeral),
+		Wrapped:   hex.EncodeToString(slot.Wrapped),
+		Version:   info.Version,
+	})
+}
+
+func (s *serverImpl) updateKeySlots(ctx context.Context, id, name, presented []byte,
+	update func(meta *utils.RecordMetadata, key []byte) error) (info utils.RecordInfo, err error) {
+
+	// Generate fixed cipher entries for ID and name for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Retrieve sealed record attributes from data store.
+	beClient := s.beClient.WithContext(ctx)
+	opts := utils.RetrieveOptions{MetadataOnly: true}
+	_, info, err = beClient.RetrieveRecord(idEncrypt, nameEncrypt, opts)
 	if err != nil {
-		log.Println("FE server postRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return info, err
+	}
+	content, err := s.signer.VerifyEnvelope(idEncrypt, nameEncrypt, info.Metadata, info.Signatures)
+	if err != nil {
+		return info, err
+	}
+	meta, err := utils.OpenMetadata(s.metaCipher, id, name, info.Metadata)
+	if err != nil {
+		return info, err
+	}
+
+	// Only holders of the record key may change recipients; member keys
+	// only grant reads.
+	if err = utils.AuthorizeOwner(meta, presented); err != nil {
+		return info, err
+	}
+	if err = update(&meta, presented); err != nil {
+		return info, err
+	}
+
+	// Re-seal and re-sign record attributes with a fresh nonce, keeping any
+	// content signature. The update only applies if the attributes have not
+	// changed since they were read.
+	nonce, err := s.keygen.RandomNonce(s.idCipher.NonceSize())
+	if err != nil {
+		return info, err
+	}
+	metadata, err := utils.SealMetadata(s.metaCipher, nonce, id, name, meta)
+	if err != nil {
+		return info, err
+	}
+	signatures, err := s.signer.SignEnvelope(idEncrypt, nameEncrypt, metadata, content)
+	if err != nil {
+		return info, err
+	}
+	if info, err = beClient.UpdateMetadata(idEncrypt, nameEncrypt, info.Metadata, metadata, signatures); err != nil {
+		return info, err
+	}
+	return utils.OpenRecordInfo(s.metaCipher, id, name, info)
+}
+
+func keySlotIDs(slots []utils.KeySlot) (slotIDs []string) {
+
+	// Key slots are identified by hex recipient public keys or member key IDs.
+	slotIDs = []string{}
+	for _, slot := range slots {
+		slotIDs = append(slotIDs, hex.EncodeToString(utils.KeySlotID(slot)))
+	}
+	return slotIDs
+}
+
+func (s *serverImpl) postRecipients(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a recipients request", "id", idStr)
+
+	// Extract presented key and new recipients
+	var req Recipients
+	if err := c.BindJSON(&req); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Generate cipher entry for record. Place in data store.
-	recordEncrypt := cipher.Seal(nonce, nonce, data, nil)
-	if err := s.beClient.StoreRecord(idEncrypt, recordEncrypt); err != nil {
-		log.Println("FE server postRecord error:", err)
-		c.IndentedJSON(http.StatusBadGateway, gin.H{"message": err.Error()})
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Return new record with key
-	newRecord.Key = hex.EncodeToString(key)
-	c.IndentedJSON(http.StatusCreated, newRecord)
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract key to hex
+	key, err := hex.DecodeString(req.Key)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract recipient public keys and member keys to hex
+	recipients, err := decodeRecipients(req.Recipients)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	members, err := decodeMembers(req.Members)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Wrap the record key for each recipient and member, replacing any slot
+	// they already hold.
+	var slots []utils.KeySlot
+	ctx := c.Request.Context()
+	info, err := s.updateKeySlots(ctx, id, name, key, func(meta *utils.RecordMetadata, recordKey []byte) error {
+		for _, recipient := range recipients {
+			slot, err := utils.WrapKey(recipient, recordKey)
+			if err != nil {
+				return err
+			}
+			meta.KeySlots = utils.SetKeySlot(meta.KeySlots, slot)
+		}
+		for _, member := range members {
+			slot, err := utils.WrapMemberKey(member, recordKey)
+			if err != nil {
+				return err
+			}
+			meta.KeySlots = utils.SetKeySlot(meta.KeySlots, slot)
+		}
+		slots = meta.KeySlots
+		return nil
+	})
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server postRecipients error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Return the record key slots
+	c.IndentedJSON(http.StatusOK, KeySlots{ID: idStr, Name: nameStr, Version: info.Version, Slots: keySlotIDs(slots)})
 }
 
-func (s *serverImpl) getRecord(c *gin.Context) {
+func (s *serverImpl) deleteRecipient(c *gin.Context) {
 	idStr := c.Param("id")
+	nameStr := c.Param("name")
+	slotStr := c.Param("slot")
 	keyStr := c.Query("key")
 
-	log.Println("FE server received a get request for", idStr)
+	slog.InfoContext(c.Request.Context(), "FE server received a revoke request", "id", idStr)
 
 	// Verify paramaters
 	if keyStr == "" {
-		log.Println("FE server getRecord error: key not defined")
+		slog.WarnContext(c.Request.Context(), "FE server deleteRecipient error", "error", "key not defined")
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "key not defined"})
 		return
 	}
@@ -122,7 +1729,23 @@ func (s *serverImpl) getRecord(c *gin.Context) {
 	// Extract ID to hex
 	id, err := hex.DecodeString(idStr)
 	if err != nil {
-		log.Println("FE server getRecord error:", err)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecipient error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecipient error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract key slot identifier to hex
+	slotID, err := hex.DecodeString(slotStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecipient error", "error", err)
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
@@ -130,69 +1753,193 @@ func (s *serverImpl) getRecord(c *gin.Context) {
 	// Extract key to hex
 	key, err := hex.DecodeString(keyStr)
 	if err != nil {
-		log.Println("FE server getRecord error:", err)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecipient error", "error", err)
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Generate fixed cipher entry for ID for lookup.
+	// Remove the key slot. The record key is unchanged, so prior versions
+	// and anyone who already unwrapped it keep access.
+	var slots []utils.KeySlot
+	ctx := c.Request.Context()
+	info, err := s.updateKeySlots(ctx, id, name, key, func(meta *utils.RecordMetadata, recordKey []byte) (err error) {
+		meta.KeySlots, err = utils.RemoveKeySlot(meta.KeySlots, slotID)
+		slots = meta.KeySlots
+		return err
+	})
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecipient error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+		return
+	}
+
+	// Return the remaining record key slots
+	c.IndentedJSON(http.StatusOK, KeySlots{ID: idStr, Name: nameStr, Version: info.Version, Slots: keySlotIDs(slots)})
+}
+
+func (s *serverImpl) getVersions(c *gin.Context) {
+	idStr := c.Param("id")
+	nameStr := c.Param("name")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a versions request", "id", idStr)
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getVersions error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getVersions error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
 
-	// Generate cipher for record.
-	cipher, err := s.keygen.GetGCMCipher(key)
+	// List retained versions from data store.
+	versions, err := s.beClient.WithContext(c.Request.Context()).ListVersions(idEncrypt, nameEncrypt)
 	if err != nil {
-		log.Println("FE server getRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getVersions error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
 		return
 	}
 
-	// Retrieve record from data store.
-	recordEncrypt, err := s.beClient.RetrieveRecord(idEncrypt)
+	// Return retained versions
+	c.IndentedJSON(http.StatusOK, Versions{ID: idStr, Name: nameStr, Versions: versions})
+}
+
+func (s *serverImpl) getRecords(c *gin.Context) {
+	idStr := c.Param("id")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a list request", "id", idStr)
+
+	// Extract ID to hex
+	id, err := hex.DecodeString(idStr)
 	if err != nil {
-		log.Println("FE server getRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecords error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract optional cursor and page size
+	opts := utils.ListOptions{Cursor: c.Query("cursor")}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if opts.Limit, err = strconv.ParseInt(limitStr, 10, 64); err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecords error", "error", err)
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	// Generate fixed cipher entry for ID for lookup.
+	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+
+	// List records from data store.
+	records, next, err := s.beClient.WithContext(c.Request.Context()).ListRecords(idEncrypt, opts)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecords error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
 		return
 	}
 
-	// Decrypt record from cipher entry.
-	var data []byte
-	nonce := recordEncrypt[:cipher.NonceSize()]
-	remainder := recordEncrypt[cipher.NonceSize():]
-	if data, err = cipher.Open(nil, nonce, remainder, nil); err != nil {
-		log.Println("FE server getRecord error:", err)
+	// Decrypt record names.
+	if err = utils.OpenRecordList(s.idCipher, s.metaCipher, id, records); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server getRecords error", "error", err)
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Return retrieved record with key
-	retrievedRecord := Record{
-		ID:   idStr,
-		Data: string(data),
-		Key:  keyStr,
+	// Return record summaries
+	list := RecordList{ID: idStr, Records: []RecordSummary{}, Next: next}
+	for _, record := range records {
+		list.Records = append(list.Records, RecordSummary{
+			Name:        hex.EncodeToString(record.Name),
+			Size:        record.Size,
+			ContentType: record.ContentType,
+			Created:     record.Created,
+			Updated:     record.Updated,
+		})
 	}
-	c.IndentedJSON(http.StatusOK, retrievedRecord)
+	c.IndentedJSON(http.StatusOK, list)
 }
 
 func (s *serverImpl) deleteRecord(c *gin.Context) {
 	idStr := c.Param("id")
+	nameStr := c.Param("name")
+	keyStr := c.Query("key")
+	shareStrs := c.QueryArray("share")
+
+	slog.InfoContext(c.Request.Context(), "FE server received a delete request", "id", idStr)
 
-	log.Println("FE server received a delete request for", idStr)
+	// Verify paramaters. Admins may delete without the record key.
+	admin := s.admin(c)
+	if keyStr == "" && len(shareStrs) == 0 && !admin {
+		slog.WarnContext(c.Request.Context(), "FE server deleteRecord error", "error", "key not defined")
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": "key not defined"})
+		return
+	}
 
 	// Extract ID to hex
 	id, err := hex.DecodeString(idStr)
 	if err != nil {
-		log.Println("FE server deleteRecord error:", err)
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	// Generate fixed cipher entry for ID for lookup.
+	// Extract name to hex
+	name, err := hex.DecodeString(nameStr)
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Extract revision preconditions
+	match, err := utils.ParsePrecondition(c.GetHeader("If-Match"), c.GetHeader("If-None-Match"))
+	if err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// Generate fixed cipher entries for ID and name for lookup.
 	idEncrypt := s.idCipher.Seal(s.idNonce, s.idNonce, id, nil)
+	nameEncrypt := utils.SealName(s.idCipher, s.nameKey, id, name)
+
+	// Prove ownership of the record with its key, a threshold of key shares
+	// or a member key. Unless conditioned otherwise, the delete only applies
+	// to the revision the key was proven against.
+	if !admin {
+		key, err := decodeKey(keyStr, shareStrs)
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		version, err := s.proveKey(c.Request.Context(), id, name, idEncrypt, nameEncrypt, key)
+		if err != nil {
+			slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
+			c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
+			return
+		}
+		if match == (utils.Precondition{}) {
+			match.IfMatch = version
+		}
+	}
 
 	// Delete record from data store.
-	if err = s.beClient.DeleteRecord(idEncrypt); err != nil {
-		log.Println("FE server deleteRecord error:", err)
-		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	opts := utils.DeleteOptions{Match: match}
+	if err = s.beClient.WithContext(c.Request.Context()).DeleteRecord(idEncrypt, nameEncrypt, opts); err != nil {
+		slog.Log(c.Request.Context(), utils.ErrorLevel(err), "FE server deleteRecord error", "error", err)
+		c.IndentedJSON(backendStatus(err, http.StatusInternalServerError), gin.H{"message": err.Error()})
 		return
 	}
 
@@ -203,21 +1950,74 @@ func (s *serverImpl) deleteRecord(c *gin.Context) {
 func (s *serverImpl) Start() (err error) {
 
 	// Create Gin router
-	router := gin.Default()
-
-	// RESTful endpoints
-	router.POST("/records", s.postRecord)
-	router.GET("/records/:id", s.getRecord)
-	router.DELETE("/records/:id", s.deleteRecord)
+	router, err := s.newRouter()
+	if err != nil {
+		return err
+	}
+	s.routes(router)
 
 	// Start router
 	return router.Run(s.serverAddr)
 }
 
+// Creates the router and its middleware. Client IPs are read from
+// X-Forwarded-For only when sent by trusted proxies, so clients cannot pick
+// the IP they are rate limited by.
+func (s *serverImpl) newRouter() (router *gin.Engine, err error) {
+	router = gin.Default()
+	if err = router.SetTrustedProxies(s.trustedProxies); err != nil {
+		return nil, err
+	}
+	router.Use(requestID, observe, traceRequest, s.rateLimit)
+	router.GET(utils.MetricsPath, gin.WrapH(utils.MetricsHandler()))
+	return router, nil
+}
+
+// Parses the comma-separated IPs and CIDR ranges of trusted proxies.
+func parseTrustedProxies(proxiesStr string) (proxies []string, err error) {
+	if proxiesStr == "" {
+		return nil, nil
+	}
+	for _, proxy := range strings.Split(proxiesStr, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, errors.New("MakeServer cannot be configured with trustedProxies " + proxiesStr)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+// Registers the RESTful endpoints, each audited as the operation it performs
+// on a record and authorized for its scope.
+func (s *serverImpl) routes(router gin.IRoutes) {
+	router.POST("/records", s.audit(utils.AuditStore), s.authorize(utils.ScopeStore), s.postRecord)
+	router.GET("/records/:id", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getRecord)
+	router.HEAD("/records/:id", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.headRecord)
+	router.GET("/records/:id/versions", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getVersions)
+	router.GET("/records/:id/keyslot", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getKeySlot)
+	router.POST("/records/:id/recipients", s.audit(utils.AuditRotate), s.authorize(utils.ScopeStore), s.postRecipients)
+	router.DELETE("/records/:id/recipients/:slot", s.audit(utils.AuditRotate), s.authorize(utils.ScopeStore), s.deleteRecipient)
+	router.DELETE("/records/:id", s.audit(utils.AuditDelete), s.authorize(utils.ScopeDelete), s.deleteRecord)
+	router.GET("/users/:id/records", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getRecords)
+	router.POST("/users/:id/records/:name", s.audit(utils.AuditStore), s.authorize(utils.ScopeStore), s.postRecord)
+	router.GET("/users/:id/records/:name", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getRecord)
+	router.HEAD("/users/:id/records/:name", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.headRecord)
+	router.GET("/users/:id/records/:name/versions", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getVersions)
+	router.GET("/users/:id/records/:name/keyslot", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getKeySlot)
+	router.POST("/users/:id/records/:name/recipients", s.audit(utils.AuditRotate), s.authorize(utils.ScopeStore), s.postRecipients)
+	router.DELETE("/users/:id/records/:name/recipients/:slot", s.audit(utils.AuditRotate), s.authorize(utils.ScopeStore), s.deleteRecipient)
+	router.DELETE("/users/:id/records/:name", s.audit(utils.AuditDelete), s.authorize(utils.ScopeDelete), s.deleteRecord)
+	router.POST("/sealed/records/:id", s.audit(utils.AuditStore), s.authorize(utils.ScopeStore), s.postSealedRecord)
+	router.GET("/sealed/records/:id", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getSealedRecord)
+	router.POST("/sealed/users/:id/records/:name", s.audit(utils.AuditStore), s.authorize(utils.ScopeStore), s.postSealedRecord)
+	router.GET("/sealed/users/:id/records/:name", s.audit(utils.AuditRetrieve), s.authorize(utils.ScopeRetrieve), s.getSealedRecord)
+}
+
 func MakeServer(configs map[string]string,
 	beClientConfigs map[string]string) (s Server, err error) {
 
-	log.Println("FE server MakeServer with configs:", configs, beClientConfigs)
+	slog.Info("FE server MakeServer", "port", configs["port"], "beServerAddr", beClientConfigs["serverAddr"])
 
 	// Verify required configurations.
 	if ok, missing := utils.VerifyConfigs(configs,
@@ -237,6 +2037,37 @@ func MakeServer(configs map[string]string,
 		return nil, err
 	}
 
+	// Record names are sealed with nonces keyed apart from the ID cipher.
+	nameKey, err := utils.NameKey([]byte(configs["idKeyStr"]))
+	if err != nil {
+		return nil, err
+	}
+
+	// Record metadata is sealed under a key derived apart from the ID cipher.
+	metaKey, err := utils.MetadataKey([]byte(configs["idKeyStr"]))
+	if err != nil {
+		return nil, err
+	}
+	metaCipher, err := keygen.GetGCMCipher(metaKey)
+	if err != nil {
+		return nil, err
+	}
+
+	compressor, err := utils.MakeCompressor(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	padder, err := utils.MakePadder(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := utils.MakeSigner(configs)
+	if err != nil {
+		return nil, err
+	}
+
 	beClient, err := client.MakeClient(beClientConfigs)
 	if err != nil {
 		return nil, err
@@ -246,13 +2077,39 @@ func MakeServer(configs map[string]string,
 	si := &serverImpl{
 		keygen: keygen,
 
-		idNonce:  []byte(configs["idNonceStr"]),
-		idCipher: idCipher,
+		idNonce:    []byte(configs["idNonceStr"]),
+		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+
+		compressor: compressor,
+		padder:     padder,
+		signer:     signer,
 
 		beClient: beClient,
 
 		serverAddr: ":" + configs["port"],
 	}
 
+	// API keys may be held in a record, read through this server.
+	loadRecord := func(id, key []byte) ([]byte, error) {
+		return si.loadRecord(context.Background(), id, nil, key)
+	}
+	if si.auth, err = utils.MakeAuthenticator(configs, loadRecord); err != nil {
+		return nil, err
+	}
+	if si.tokens, err = utils.MakeTokenVerifier(configs); err != nil {
+		return nil, err
+	}
+	if si.limiter, err = utils.MakeRateLimiter(configs); err != nil {
+		return nil, err
+	}
+	if si.trustedProxies, err = parseTrustedProxies(configs["trustedProxies"]); err != nil {
+		return nil, err
+	}
+	if si.auditor, err = utils.MakeAuditor(configs, beClient); err != nil {
+		return nil, err
+	}
+
 	return si, nil
 }
@@ -2,21 +2,44 @@ package server
 
 import (
 	"bytes"
+	"context"
+	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
+	"math/big"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 
+	"enc-server-go/pkg/shamir"
 	"enc-server-go/pkg/utils"
 	"enc-server-go/pkg/v2-apis/be/client"
 )
@@ -29,13 +52,23 @@ const port = "7777"
 
 const serverAddr = "enc-server-go-be:8888"
 const serverRecordsPath = "/records"
+const serverSealedPath = "/sealed"
 
 const badKeySizeStr = "fff"
+const badCompressionStr = "lz4"
+const badPaddingStr = "random"
+const badSigningKeyStr = "fff"
+
+const signingKeyHexStr = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+const clientSeedStr = "4ccd089b28ff96da9db6c346ec114e0f"
 
 const idStr = "JTH"
 const idHexStr = "4a5448"
 const idHexEncStr = "396263343233393039616335acc30dd405c51d37675d4e0002a526ae113d56"
 
+const nameStr = "notes"
+const nameHexStr = "6e6f746573"
+
 const recordStr = "PAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADSPAYLOADS"
 const recordHexStr = "5041594c4f4144535041594c4f4144535041594c4f414453504159" +
 	"4c4f4144535041594c4f4144535041594c4f4144535041594c4f4144535041594c4f414453"
@@ -46,26 +79,85 @@ const recordHexEncStr = "396263343233393039616335b6d61c6839a0dda2524d19b4e5d" +
 // Content types
 const contentTypeJSON = "application/json"
 const contentTypeHeader = "Content-Type"
+const contentTypeOctetStream = "application/octet-stream"
+const acceptHeader = "Accept"
+
+// Data encodings
+const encodingBase64 = "base64"
+
+// Precondition headers
+const etagHeader = "ETag"
+
+// Attribute headers
+const lastModifiedHeader = "Last-Modified"
+const createdHeader = "X-Record-Created"
+const sizeHeader = "X-Record-Size"
+const recordContentTypeHeader = "X-Record-Content-Type"
+const labelsHeader = "X-Record-Labels"
+const signerHeader = "X-Record-Signer"
+const ifMatchHeader = "If-Match"
+const ifNoneMatchHeader = "If-None-Match"
 
 // HTTP methods
 const httpMethodPOST = "POST"
 const httpMethodGET = "GET"
 const httpMethodDELETE = "DELETE"
+const httpMethodHEAD = "HEAD"
 
 // Query parameters
 const keyQueryParam = "key"
 const idQueryParam = "id"
+const nameQueryParam = "name"
+const versionQueryParam = "version"
+const cursorQueryParam = "cursor"
+const limitQueryParam = "limit"
+const oneTimeQueryParam = "oneTime"
+const encodingQueryParam = "encoding"
+const noCompressQueryParam = "noCompress"
+const recipientQueryParam = "recipient"
+const sharesQueryParam = "shares"
+const thresholdQueryParam = "threshold"
+const shareQueryParam = "share"
+const signerQueryParam = "signer"
+const signatureQueryParam = "signature"
+const keyHashQueryParam = "keyHash"
+
+// API keys
+const apiKeyHeader = "X-API-Key"
+const authorizationHeader = "Authorization"
+
+// Bearer tokens
+const jwtSecretStr = "kP3xN8qR2vW6yZ1cF5hJ9mL4tB7dG0sA"
+const jwtIssuerStr = "https://gateway.example"
+const jwtAudienceStr = "enc-server-go"
+const storeAPIKey = "store-api-key"
+const adminAPIKey = "admin-api-key"
 
 // Error Descriptions
 const badServerMessage = "MakeServer missing configuration keySize"
 const badClientMessage = "MakeClient missing configuration serverAddr"
+const badCompressionMessage = "MakeCompressor cannot be configured with unsupported compression lz4"
+const badPaddingMessage = "MakePadder cannot be configured with unsupported padding random"
+const badSigningMessage = "MakeSigner cannot be configured with invalid signingKey"
+const badAuthMessage = "MakeAuthenticator cannot be configured with invalid apiKeysRecord"
+const badTokensMessage = "MakeTokenVerifier cannot be configured with jwtSecret shorter than 32 bytes"
+const badRateLimitMessage = "MakeRateLimiter cannot be configured with rateLimit 0"
+const badTrustedProxiesMessage = "MakeServer cannot be configured with trustedProxies 10.0.0.0/8,proxy"
+const badAuditMessage = "MakeAuditor cannot be configured with invalid auditRecordID"
 const badRandomKeyMessage = "KeyGen.RandomKey error"
 const badGetGCMCipherMessage = "KeyGen.GetGCMKey error"
 const badRandomNonceMessage = "KeyGen.RandomNonce error"
 const badBEClientMessage = "Back-end client error"
 const badDecryptMessage = "cipher: message authentication failed"
+const badRecipientMessage = "crypto/ecdh: invalid public key"
 const badRequest = "Malformed request"
 const badDecode = "encoding/hex: invalid byte: U+0069 'i'"
+const badVersion = "invalid syntax"
+
+// Record attributes
+const contentTypeText = "text/plain"
+const createdMs = 1700000000000
+const updatedMs = 1700000600000
 
 // Test data strings
 const invalidHexID = "invalid-hex-gg"
@@ -82,6 +174,7 @@ const errMockError = "mock error"
 // Test Variables
 var (
 	id     = []byte(idStr)
+	name   = []byte(nameStr)
 	record = []byte(recordStr)
 
 	idEnc, _ = hex.DecodeString(idHexEncStr)
@@ -93,7 +186,205 @@ var (
 
 	keygen, _ = utils.MakeKeyGen(map[string]string{"keySize": keySizeStr})
 
-	idCipher, _ = keygen.GetGCMCipher([]byte(idKeyStr))
+	idCipher, _   = keygen.GetGCMCipher([]byte(idKeyStr))
+	metaCipher, _ = keygen.GetGCMCipher(metaKey)
+
+	nameKey, _  = utils.NameKey(idKey)
+	metaKey, _  = utils.MetadataKey(idKey)
+	auditKey, _ = utils.AuditKey(idKey)
+	nameEnc     = utils.SealName(idCipher, nameKey, id, name)
+
+	labels = map[string]string{"env": "prod"}
+
+	noCompressor, _      = utils.MakeCompressor(map[string]string{})
+	gzipCompressor, _    = utils.MakeCompressor(map[string]string{"compression": utils.CompressionGzip})
+	limitedCompressor, _ = utils.MakeCompressor(map[string]string{"compression": utils.CompressionGzip,
+		"maxDecompressedSize": "16"})
+
+	noPadder, _    = utils.MakePadder(map[string]string{})
+	blockPadder, _ = utils.MakePadder(map[string]string{"padding": utils.PaddingBlock, "paddingBlockSize": "100"})
+
+	// Client signing key, trusted alongside the server signing key
+	clientKey        = ed25519.NewKeyFromSeed([]byte(clientSeedStr))
+	clientKeyHexStr  = hex.EncodeToString(utils.SignerKeyID(clientKey.Public().(ed25519.PublicKey)))
+	contentSignature = utils.SignContent(clientKey, id, nil, record)
+
+	noSigner, _      = utils.MakeSigner(map[string]string{})
+	signingSigner, _ = utils.MakeSigner(map[string]string{"signingKey": signingKeyHexStr,
+		"verifyKeys": hex.EncodeToString(clientKey.Public().(ed25519.PublicKey))})
+	requireSigner, _ = utils.MakeSigner(map[string]string{"signingKey": signingKeyHexStr,
+		"requireSignatures": "true"})
+
+	// API key list holding a store and retrieve key and an admin key, and
+	// authenticators loading it from a record
+	apiKeysJSON = []byte(`[{"name":"ci","hash":"` + utils.HashAPIKey(storeAPIKey) + `","scopes":["store","retrieve"]},` +
+		`{"name":"ops","hash":"` + utils.HashAPIKey(adminAPIKey) + `","scopes":["admin"]}]`)
+	apiKeysConfig = map[string]string{"apiKeysRecord": idHexStr, "apiKeysRecordKey": idKeyHexStr}
+
+	noAuth, _   = utils.MakeAuthenticator(map[string]string{}, nil)
+	noTokens, _ = utils.MakeTokenVerifier(map[string]string{})
+	noLimits, _ = utils.MakeRateLimiter(map[string]string{})
+	noAudit, _  = utils.MakeAuditor(map[string]string{}, nil)
+	keyAuth, _  = utils.MakeAuthenticator(apiKeysConfig, func(id, key []byte) ([]byte, error) {
+		return apiKeysJSON, nil
+	})
+	failingAuth, _ = utils.MakeAuthenticator(apiKeysConfig, func(id, key []byte) ([]byte, error) {
+		return nil, errors.New(errBackendRetrievalFailed)
+	})
+
+	created = utils.FromUnixMilli(createdMs)
+	updated = utils.FromUnixMilli(updatedMs)
+
+	// Attributes as sealed by the FE and reported by the BE
+	storedInfo = func(named bool) utils.RecordInfo {
+		var recName []byte
+		if named {
+			recName = name
+		}
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, recName, utils.RecordMetadata{
+			ContentType: contentTypeText,
+			Size:        int64(len(record)),
+			Labels:      labels,
+		})
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata}
+	}
+
+	// Attributes of a record sealed whole under the given key with the digest
+	// of its cipher entry, signed along with the content signature
+	signedRecord = func(key []byte) (utils.RecordInfo, []byte) {
+		cipher, _ := keygen.GetGCMCipher(key)
+		nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+		sealed := cipher.Seal(nonce, nonce, record, nil)
+		digest := sha256.Sum256(sealed)
+		metaNonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, metaNonce, id, nil, utils.RecordMetadata{
+			ContentType: contentTypeText,
+			Size:        int64(len(record)),
+			Labels:      labels,
+			Digest:      digest[:],
+		})
+		signatures, _ := signingSigner.SignEnvelope(idEnc, nil, metadata, contentSignature)
+		info := utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata,
+			Signatures: signatures}
+		return info, sealed
+	}
+
+	// Record sealed by the client under the test key, and the commitment to
+	// the test key
+	clientSealedRecord = func() []byte {
+		nonce, _ := keygen.RandomNonce(idCipher.NonceSize())
+		return idCipher.Seal(nonce, nonce, record, nil)
+	}()
+	keyHashHexStr = hex.EncodeToString(utils.KeyHash(idKey))
+
+	// Attributes of a record sealed by the client or the server, committing
+	// to the test key and signed by the given signer
+	clientSealedInfo = func(clientSealed bool, signer utils.Signer) utils.RecordInfo {
+		meta := utils.RecordMetadata{
+			ContentType:  contentTypeText,
+			Size:         int64(len(record)),
+			Labels:       labels,
+			KeyHash:      utils.KeyHash(idKey),
+			ClientSealed: clientSealed,
+		}
+		if signer.Signing() {
+			digest := sha256.Sum256(clientSealedRecord)
+			meta.Digest = digest[:]
+		}
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, meta)
+		signatures, _ := signer.SignEnvelope(idEnc, nil, metadata, nil)
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata,
+			Signatures: signatures}
+	}
+
+	// Attributes of a record sealed whole or as a chunked stream, compressed
+	// with the given codec
+	sealedInfo = func(chunked bool, compression string) utils.RecordInfo {
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, utils.RecordMetadata{
+			ContentType: contentTypeText,
+			Size:        int64(len(record)),
+			Labels:      labels,
+			Chunked:     chunked,
+			Compression: compression,
+		})
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata}
+	}
+
+	// Attributes of a record sealed whole or as a chunked stream, padded
+	// from the given length
+	paddedInfo = func(chunked bool, unpadded int64) utils.RecordInfo {
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, utils.RecordMetadata{
+			ContentType: contentTypeText,
+			Size:        int64(len(record)),
+			Labels:      labels,
+			Chunked:     chunked,
+			Padding:     utils.PaddingBlock,
+			Unpadded:    unpadded,
+		})
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata}
+	}
+
+	// Data sealed as a chunked stream under the given key
+	sealedStream = func(key, data []byte) []byte {
+		cipher, _ := keygen.GetGCMCipher(key)
+		prefix, _ := keygen.RandomNonce(utils.StreamPrefixSize)
+		var sealed bytes.Buffer
+		sealer, _ := utils.NewStreamSealer(cipher, prefix, &sealed)
+		_, _ = sealer.Write(data)
+		_ = sealer.Close()
+		return sealed.Bytes()
+	}
+
+	// Record compressed with gzip
+	compressedRecord, _ = utils.Compress(gzipCompressor, record)
+
+	// Record padded to the next block
+	paddedRecord = utils.Pad(blockPadder, record)
+
+	// Recipient key pair and the test key wrapped for it
+	recipientPrivate, recipientPublic, _ = utils.GenerateRecipientKey()
+	recipientHexStr                      = hex.EncodeToString(recipientPublic)
+	recipientSlot, _                     = utils.WrapKey(recipientPublic, idKey)
+
+	// Test key split into 2 of 3 hex key shares
+	idKeyShares = func() (shareStrs []string) {
+		shares, _ := shamir.Split(idKey, 3, 2)
+		for _, share := range shares {
+			shareStrs = append(shareStrs, hex.EncodeToString(share))
+		}
+		return shareStrs
+	}()
+
+	// Member key and the test key wrapped in its key slot
+	memberKey       = []byte("mQ3kT8vZ2pL6nR1wX9cY4bH7jF5sD0aE")
+	memberKeyHexStr = hex.EncodeToString(memberKey)
+	memberSlot, _   = utils.WrapMemberKey(memberKey, idKey)
+
+	// Attributes of a record shared with the test member and committing to
+	// the test key
+	memberInfo = func() utils.RecordInfo {
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, utils.RecordMetadata{
+			Size:     int64(len(record)),
+			KeySlots: []utils.KeySlot{memberSlot},
+			KeyHash:  utils.KeyHash(idKey),
+		})
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata}
+	}
+
+	// Attributes of a record stored for the test recipient
+	slottedInfo = func() utils.RecordInfo {
+		nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+		metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, utils.RecordMetadata{
+			Size:     int64(len(record)),
+			KeySlots: []utils.KeySlot{recipientSlot},
+		})
+		return utils.RecordInfo{Version: 3, Created: created, Updated: updated, Metadata: metadata}
+	}
 
 	goodClientConfig = map[string]string{
 		"serverAddr": serverAddr}
@@ -112,6 +403,15 @@ var (
 		keygen:     keygen,
 		idNonce:    idNonce,
 		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+		compressor: noCompressor,
+		padder:     noPadder,
+		signer:     noSigner,
+		auth:       noAuth,
+		tokens:     noTokens,
+		limiter:    noLimits,
+		auditor:    noAudit,
 		beClient:   goodClient,
 		serverAddr: ":" + port,
 	}
@@ -131,6 +431,54 @@ var (
 		return m
 	}()
 
+	badCompressionConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["compression"] = badCompressionStr
+		return m
+	}()
+
+	badPaddingConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["padding"] = badPaddingStr
+		return m
+	}()
+
+	badSigningConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["signingKey"] = badSigningKeyStr
+		return m
+	}()
+
+	badAuthConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["apiKeysRecord"] = invalidHexID
+		return m
+	}()
+
+	badTokensConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["jwtSecret"] = "short"
+		return m
+	}()
+
+	badAuditConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["auditRecordID"] = "zz"
+		return m
+	}()
+
+	badRateLimitConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["rateLimit"] = "0"
+		return m
+	}()
+
+	badTrustedProxiesConfig = func() map[string]string {
+		m := maps.Clone(goodServerConfig)
+		m["trustedProxies"] = "10.0.0.0/8,proxy"
+		return m
+	}()
+
 	badClientConfig = map[string]string{
 		"foo": "bar"}
 
@@ -171,32 +519,95 @@ func (m *mockKeyGen) RandomNonce(nonceSize int) ([]byte, error) {
 
 // Mock ClientBE
 type mockClientBE struct {
-	storeRecordFn    func(id, record []byte) error
-	retrieveRecordFn func(id []byte) ([]byte, error)
-	deleteRecordFn   func(id []byte) error
+	storeRecordFn    func(id, name, record []byte, opts utils.StoreOptions) error
+	retrieveRecordFn func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error)
+	retrieveInfoFn   func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error)
+	deleteRecordFn   func(id, name []byte, opts utils.DeleteOptions) error
+	listVersionsFn   func(id, name []byte) ([]int64, error)
+	listRecordsFn    func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error)
+	updateMetadataFn func(id, name, prior, metadata, signatures []byte) error
 }
 
-func (m *mockClientBE) StoreRecord(id, record []byte) error {
+func (m *mockClientBE) StoreRecord(id, name, record []byte, opts utils.StoreOptions) (utils.RecordInfo, error) {
 	if m.storeRecordFn != nil {
-		return m.storeRecordFn(id, record)
+		return utils.RecordInfo{Version: 1}, m.storeRecordFn(id, name, record, opts)
 	}
-	return nil
+	return utils.RecordInfo{Version: 1}, nil
 }
 
-func (m *mockClientBE) RetrieveRecord(id []byte) ([]byte, error) {
+func (m *mockClientBE) RetrieveRecord(id, name []byte, opts utils.RetrieveOptions) ([]byte, utils.RecordInfo, error) {
+	info := utils.RecordInfo{Version: max(opts.Version, 1)}
+	if m.retrieveInfoFn != nil {
+		var err error
+		if info, err = m.retrieveInfoFn(id, name, opts); err != nil || opts.MetadataOnly {
+			return nil, info, err
+		}
+	}
 	if m.retrieveRecordFn != nil {
-		return m.retrieveRecordFn(id)
+		record, err := m.retrieveRecordFn(id, name, opts)
+		return record, info, err
+	}
+	return nil, utils.RecordInfo{}, errors.New(errMockError)
+}
+
+func (m *mockClientBE) StoreStream(id, name []byte, record io.Reader, opts utils.StoreOptions) (utils.RecordInfo, error) {
+	data, err := io.ReadAll(record)
+	if err != nil {
+		return utils.RecordInfo{}, err
+	}
+	if opts.SealMetadata != nil {
+		if opts.Metadata, err = opts.SealMetadata(); err != nil {
+			return utils.RecordInfo{}, err
+		}
+	}
+	if opts.SignMetadata != nil {
+		if opts.Signatures, err = opts.SignMetadata(opts.Metadata); err != nil {
+			return utils.RecordInfo{}, err
+		}
+	}
+	return m.StoreRecord(id, name, data, opts)
+}
+
+func (m *mockClientBE) RetrieveStream(id, name []byte, opts utils.RetrieveOptions) (io.ReadCloser, utils.RecordInfo, error) {
+	data, info, err := m.RetrieveRecord(id, name, opts)
+	if err != nil {
+		return nil, info, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), info, nil
+}
+
+func (m *mockClientBE) ListVersions(id, name []byte) ([]int64, error) {
+	if m.listVersionsFn != nil {
+		return m.listVersionsFn(id, name)
 	}
 	return nil, errors.New(errMockError)
 }
 
-func (m *mockClientBE) DeleteRecord(id []byte) error {
+func (m *mockClientBE) ListRecords(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+	if m.listRecordsFn != nil {
+		return m.listRecordsFn(id, opts)
+	}
+	return nil, "", errors.New(errMockError)
+}
+
+func (m *mockClientBE) DeleteRecord(id, name []byte, opts utils.DeleteOptions) error {
 	if m.deleteRecordFn != nil {
-		return m.deleteRecordFn(id)
+		return m.deleteRecordFn(id, name, opts)
 	}
 	return nil
 }
 
+func (m *mockClientBE) UpdateMetadata(id, name, prior, metadata, signatures []byte) (utils.RecordInfo, error) {
+	if m.updateMetadataFn != nil {
+		return utils.RecordInfo{Version: 3, Metadata: metadata, Signatures: signatures}, m.updateMetadataFn(id, name, prior, metadata, signatures)
+	}
+	return utils.RecordInfo{}, errors.New(errMockError)
+}
+
+func (m *mockClientBE) WithContext(ctx context.Context) utils.ClientBE {
+	return m
+}
+
 // MakeServer() - Test Method
 func TestServer_MakeServer(t *testing.T) {
 
@@ -236,11 +647,51 @@ func TestServer_MakeServer(t *testing.T) {
 			args:    args{badIdKeyConfig, goodClientConfig},
 			wantErr: aes.KeySizeError(0),
 		},
+		{
+			name:    "should fail building compressor",
+			args:    args{badCompressionConfig, goodClientConfig},
+			wantErr: errors.New(badCompressionMessage),
+		},
+		{
+			name:    "should fail building padder",
+			args:    args{badPaddingConfig, goodClientConfig},
+			wantErr: errors.New(badPaddingMessage),
+		},
+		{
+			name:    "should fail building signer",
+			args:    args{badSigningConfig, goodClientConfig},
+			wantErr: errors.New(badSigningMessage),
+		},
 		{
 			name:    "should fail building back-end client",
 			args:    args{goodServerConfig, badClientConfig},
 			wantErr: errors.New(badClientMessage),
 		},
+		{
+			name:    "should fail building authenticator",
+			args:    args{badAuthConfig, goodClientConfig},
+			wantErr: errors.New(badAuthMessage),
+		},
+		{
+			name:    "should fail building token verifier",
+			args:    args{badTokensConfig, goodClientConfig},
+			wantErr: errors.New(badTokensMessage),
+		},
+		{
+			name:    "should fail building rate limiter",
+			args:    args{badRateLimitConfig, goodClientConfig},
+			wantErr: errors.New(badRateLimitMessage),
+		},
+		{
+			name:    "should fail parsing trusted proxies",
+			args:    args{badTrustedProxiesConfig, goodClientConfig},
+			wantErr: errors.New(badTrustedProxiesMessage),
+		},
+		{
+			name:    "should fail building auditor",
+			args:    args{badAuditConfig, goodClientConfig},
+			wantErr: errors.New(badAuditMessage),
+		},
 	}
 
 	for _, test := range tests {
@@ -252,159 +703,4330 @@ func TestServer_MakeServer(t *testing.T) {
 	}
 }
 
-// postRecord() - Test Method
-func TestServer_postRecord(t *testing.T) {
+// authorize() - Test Method
+func TestServer_authorize(t *testing.T) {
+
+	// Token signing keys: an HMAC secret, and Ed25519 and RSA keys listed in
+	// a JWKS file, along with a file mapping a subject to the test user ID
+	edPublic, edPrivate, _ := ed25519.GenerateKey(nil)
+	rsaPrivate, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "OKP", "crv": "Ed25519", "kid": "ed", "x": base64.RawURLEncoding.EncodeToString(edPublic)},
+		{"kty": "RSA", "kid": "rsa", "n": base64.RawURLEncoding.EncodeToString(rsaPrivate.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaPrivate.E)).Bytes())},
+	}})
+	_ = os.WriteFile(jwksFile, jwks, 0o600)
+	subjectsFile := filepath.Join(t.TempDir(), "subjects.json")
+	_ = os.WriteFile(subjectsFile, []byte(`{"alice": ["`+idHexStr+`"]}`), 0o600)
+
+	tokens, err := utils.MakeTokenVerifier(map[string]string{"jwtSecret": jwtSecretStr, "jwksFile": jwksFile,
+		"jwtIssuer": jwtIssuerStr, "jwtAudience": jwtAudienceStr})
+	assert.NoError(t, err)
+	subjectTokens, err := utils.MakeTokenVerifier(map[string]string{"jwtSecret": jwtSecretStr,
+		"jwtSubjectsFile": subjectsFile})
+	assert.NoError(t, err)
+
+	hmacSign := func(secret string) func([]byte) []byte {
+		return func(signed []byte) []byte {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(signed)
+			return mac.Sum(nil)
+		}
+	}
+	edSign := func(signed []byte) []byte { return ed25519.Sign(edPrivate, signed) }
+	rsaSign := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, _ := rsa.SignPKCS1v15(rand.Reader, rsaPrivate, crypto.SHA256, digest[:])
+		return signature
+	}
+
+	// Claims for the subject, valid for the configured issuer and audience
+	claims := func(subject, scope string, expires time.Duration) map[string]any {
+		c := map[string]any{"sub": subject, "iss": jwtIssuerStr, "aud": []string{jwtAudienceStr},
+			"exp": time.Now().Add(expires).Unix()}
+		if scope != "" {
+			c["scope"] = scope
+		}
+		return c
+	}
+
 	tests := []struct {
-		name             string
-		requestBody      Record
-		mockKeyGenFn     func() utils.KeyGen
-		mockClientBEFn   func() utils.ClientBE
-		expectedStatus   int
-		expectedHasKey   bool
-		expectedErrorMsg string
+		name           string
+		auth           utils.Authenticator
+		tokens         utils.TokenVerifier
+		apiKey         string
+		token          string
+		scope          string
+		post           bool
+		expectedStatus int
+		expectedClient string
 	}{
 		{
-			name: "should post record successfully",
-			requestBody: Record{
-				ID:   idHexStr,
-				Data: recordHexStr,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{
-					storeRecordFn: func(id, record []byte) error {
-						// Verify encrypted ID and record are passed
-						assert.NotNil(t, id)
-						assert.NotNil(t, record)
-						return nil
-					},
-				}
-			},
-			expectedStatus: http.StatusCreated,
-			expectedHasKey: true,
+			name:           "should pass without authentication",
+			auth:           noAuth,
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "should fail with invalid hex ID",
-			requestBody: Record{
-				ID:   invalidHexID,
-				Data: recordHexStr,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{}
-			},
-			expectedStatus:   http.StatusBadRequest,
-			expectedErrorMsg: badDecode,
+			name:           "should pass API key holding scope",
+			auth:           keyAuth,
+			apiKey:         storeAPIKey,
+			scope:          utils.ScopeStore,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ci",
 		},
 		{
-			name: "should fail with invalid hex data",
-			requestBody: Record{
-				ID:   idHexStr,
-				Data: invalidHexID,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{}
-			},
-			expectedStatus:   http.StatusBadRequest,
-			expectedErrorMsg: badDecode,
+			name:           "should pass admin API key for any scope",
+			auth:           keyAuth,
+			apiKey:         adminAPIKey,
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ops",
 		},
 		{
-			name: "should fail when random key generation fails",
-			requestBody: Record{
-				ID:   idHexStr,
-				Data: recordHexStr,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return &mockKeyGen{
-					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
-						return idCipher, nil
-					},
-					randomKeyFn: func() ([]byte, error) {
-						return nil, errors.New(errRandomKeyGenFailed)
-					},
-				}
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{}
-			},
-			expectedStatus: http.StatusInternalServerError,
+			name:           "should fail API key lacking scope",
+			auth:           keyAuth,
+			apiKey:         storeAPIKey,
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusForbidden,
 		},
 		{
-			name: "should fail when nonce generation fails",
-			requestBody: Record{
-				ID:   idHexStr,
-				Data: recordHexStr,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return &mockKeyGen{
-					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
-						return idCipher, nil
-					},
-					randomKeyFn: func() ([]byte, error) {
-						return keygen.RandomKey()
-					},
-					randomNonceFn: func(nonceSize int) ([]byte, error) {
-						return nil, errors.New(errNonceGenFailed)
-					},
-				}
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{}
-			},
-			expectedStatus: http.StatusInternalServerError,
+			name:           "should fail without API key",
+			auth:           keyAuth,
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
-			name: "should fail when backend client fails to store record",
-			requestBody: Record{
-				ID:   idHexStr,
-				Data: recordHexStr,
-			},
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
-			},
-			mockClientBEFn: func() utils.ClientBE {
-				return &mockClientBE{
-					storeRecordFn: func(id, record []byte) error {
-						return errors.New(errBackendStorageFailed)
-					},
-				}
-			},
-			expectedStatus: http.StatusBadGateway,
+			name:           "should fail with unknown API key",
+			auth:           keyAuth,
+			apiKey:         "unknown-api-key",
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			kg := test.mockKeyGenFn()
-			mockKg, _ := kg.(*mockKeyGen)
-			if mockKg == nil {
-				mockKg = &mockKeyGen{
-					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
-						return keygen.GetGCMCipher(key)
-					},
-				}
-			}
-
-			idCipherTest, _ := kg.GetGCMCipher([]byte(idKeyStr))
-			server := &serverImpl{
-				keygen:     kg,
-				idNonce:    idNonce,
-				idCipher:   idCipherTest,
+		{
+			name:           "should fail when API keys fail to load",
+			auth:           failingAuth,
+			apiKey:         storeAPIKey,
+			scope:          utils.ScopeStore,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "should pass HS256 token for own user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "store", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeStore,
+			expectedStatus: http.StatusOK,
+			expectedClient: idStr,
+		},
+		{
+			name:           "should pass EdDSA token without scope claim",
+			tokens:         tokens,
+			token:          signToken(utils.TokenEdDSA, "ed", claims(idStr, "", time.Hour), edSign),
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusOK,
+			expectedClient: idStr,
+		},
+		{
+			name:           "should pass RS256 token",
+			tokens:         tokens,
+			token:          signToken(utils.TokenRS256, "rsa", claims(idStr, "retrieve", time.Hour), rsaSign),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusOK,
+			expectedClient: idStr,
+		},
+		{
+			name:           "should pass admin token for other user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims("ops", "admin", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ops",
+		},
+		{
+			name:           "should pass token subject mapped to user ID",
+			tokens:         subjectTokens,
+			token:          signToken(utils.TokenHS256, "", claims("alice", "", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusOK,
+			expectedClient: "alice",
+		},
+		{
+			name:           "should pass API key alongside token verification",
+			auth:           keyAuth,
+			tokens:         tokens,
+			apiKey:         storeAPIKey,
+			scope:          utils.ScopeStore,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ci",
+		},
+		{
+			name:           "should fail token for other user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims("bob", "", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should fail token lacking scope",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "retrieve", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeDelete,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should fail expired token",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "", -time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should fail token signed with other secret",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "", time.Hour), hmacSign(idKeyStr)),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "should fail token naming other algorithm than its key",
+			tokens: tokens,
+			token: signToken(utils.TokenHS256, "ed", claims(idStr, "", time.Hour),
+				hmacSign(string(edPublic))),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should fail unsigned token",
+			tokens:         tokens,
+			token:          signToken("none", "", claims(idStr, "", time.Hour), func([]byte) []byte { return nil }),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "should fail token for other audience",
+			tokens: tokens,
+			token: signToken(utils.TokenHS256, "", map[string]any{"sub": idStr, "iss": jwtIssuerStr,
+				"aud": "other", "exp": time.Now().Add(time.Hour).Unix()}, hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should fail malformed token",
+			tokens:         tokens,
+			token:          "not-a-token",
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should fail without token",
+			tokens:         tokens,
+			scope:          utils.ScopeRetrieve,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should pass token posting record for own user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "store", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeStore,
+			post:           true,
+			expectedStatus: http.StatusOK,
+			expectedClient: idStr,
+		},
+		{
+			name:           "should pass admin token posting record for other user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims("ops", "admin", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeStore,
+			post:           true,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ops",
+		},
+		{
+			name:           "should pass API key posting record",
+			auth:           keyAuth,
+			tokens:         tokens,
+			apiKey:         storeAPIKey,
+			scope:          utils.ScopeStore,
+			post:           true,
+			expectedStatus: http.StatusOK,
+			expectedClient: "ci",
+		},
+		{
+			name:           "should fail token posting record for other user ID",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims("bob", "store", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeStore,
+			post:           true,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should fail token posting record lacking scope",
+			tokens:         tokens,
+			token:          signToken(utils.TokenHS256, "", claims(idStr, "retrieve", time.Hour), hmacSign(jwtSecretStr)),
+			scope:          utils.ScopeStore,
+			post:           true,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &serverImpl{auth: test.auth, tokens: test.tokens, limiter: noLimits}
+			if server.auth == nil {
+				server.auth = noAuth
+			}
+			if server.tokens == nil {
+				server.tokens = noTokens
+			}
+
+			// Route handlers reporting the authorized client, posting records
+			// checking the user ID read from the request as postRecord does
+			router := gin.New()
+			router.GET(serverRecordsPath+"/:id", server.authorize(test.scope), func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString("client"))
+			})
+			router.POST(serverRecordsPath, server.authorize(test.scope), func(c *gin.Context) {
+				id, _ := hex.DecodeString(c.Query("id"))
+				if authorizeID(c, test.scope, id) {
+					c.String(http.StatusOK, c.GetString("client"))
+				}
+			})
+
+			req, _ := http.NewRequest(httpMethodGET, serverRecordsPath+"/"+idHexStr, nil)
+			if test.post {
+				req, _ = http.NewRequest(httpMethodPOST, serverRecordsPath+"?id="+idHexStr, nil)
+			}
+			if test.apiKey != "" {
+				req.Header.Set(apiKeyHeader, test.apiKey)
+			}
+			if test.token != "" {
+				req.Header.Set(authorizationHeader, "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Verify status code, and the client once authorized
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, test.expectedClient, w.Body.String())
+			}
+		})
+	}
+}
+
+// rateLimit() - Test Method
+func TestServer_rateLimit(t *testing.T) {
+
+	// Rate limits for retrieving records only, by route.
+	rateLimitsFile := filepath.Join(t.TempDir(), "ratelimits.json")
+	_ = os.WriteFile(rateLimitsFile, []byte(`{"GET /records/:id": "1/2"}`), 0o600)
+
+	type request struct {
+		method    string
+		path      string
+		apiKey    string
+		ip        string
+		forwarded string
+		postID    string
+	}
+	tests := []struct {
+		name               string
+		auth               utils.Authenticator
+		trustedProxies     []string
+		rateLimits         map[string]string
+		requests           []request
+		expectedStatus     []int
+		expectedRetryAfter string
+	}{
+		{
+			name: "should pass without rate limits",
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "", "", "", ""}},
+			expectedStatus: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name:       "should limit requests beyond the burst",
+			rateLimits: map[string]string{"rateLimit": "0.5/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "", "", "", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedRetryAfter: "2",
+		},
+		{
+			name:       "should limit requests by user ID across client IPs",
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "192.0.2.1", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "", "192.0.2.2", "", ""},
+				{httpMethodGET, "/records/00", "", "192.0.2.3", "", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:       "should limit requests by API key across user IDs",
+			auth:       keyAuth,
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, storeAPIKey, "192.0.2.1", "", ""},
+				{httpMethodGET, "/records/00", storeAPIKey, "192.0.2.2", "", ""},
+				{httpMethodGET, "/records/01", adminAPIKey, "192.0.2.3", "", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:       "should limit posted records by the user ID in their body",
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodPOST, "/records", "", "192.0.2.1", "", idHexStr},
+				{httpMethodPOST, "/records", "", "192.0.2.2", "", idHexStr},
+				{httpMethodPOST, "/records", "", "192.0.2.3", "", "00"}},
+			expectedStatus:     []int{http.StatusCreated, http.StatusTooManyRequests, http.StatusCreated},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:       "should limit requests failing authentication by client IP only",
+			auth:       keyAuth,
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "unknown-api-key", "192.0.2.1", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "unknown-api-key", "192.0.2.2", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, storeAPIKey, "192.0.2.3", "", ""},
+				{httpMethodGET, "/records/00", "unknown-api-key", "192.0.2.1", "", ""}},
+			expectedStatus: []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusOK,
+				http.StatusTooManyRequests},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:       "should limit requests by client IP despite forwarded IPs",
+			rateLimits: map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "192.0.2.1", "198.51.100.1", ""},
+				{httpMethodGET, "/records/00", "", "192.0.2.1", "198.51.100.2", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:           "should limit requests by forwarded IP behind trusted proxies",
+			trustedProxies: []string{"192.0.2.0/24"},
+			rateLimits:     map[string]string{"rateLimit": "1/1"},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "192.0.2.1", "198.51.100.1", ""},
+				{httpMethodGET, "/records/00", "", "192.0.2.1", "198.51.100.2", ""},
+				{httpMethodGET, "/records/01", "", "192.0.2.2", "198.51.100.1", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedRetryAfter: "1",
+		},
+		{
+			name:       "should limit configured routes only",
+			rateLimits: map[string]string{"rateLimitsFile": rateLimitsFile},
+			requests: []request{{httpMethodGET, "/records/" + idHexStr, "", "", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "", "", "", ""},
+				{httpMethodGET, "/records/" + idHexStr, "", "", "", ""},
+				{httpMethodDELETE, "/records/" + idHexStr, "", "", "", ""}},
+			expectedStatus:     []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusOK},
+			expectedRetryAfter: "1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter, err := utils.MakeRateLimiter(test.rateLimits)
+			assert.NoError(t, err)
+			auth := test.auth
+			if auth == nil {
+				auth = noAuth
+			}
+			server := &serverImpl{
+				keygen:         keygen,
+				idNonce:        idNonce,
+				idCipher:       idCipher,
+				nameKey:        nameKey,
+				metaCipher:     metaCipher,
+				compressor:     noCompressor,
+				padder:         noPadder,
+				signer:         noSigner,
+				auth:           auth,
+				tokens:         noTokens,
+				limiter:        limiter,
+				trustedProxies: test.trustedProxies,
+				beClient:       &mockClientBE{},
+			}
+
+			// Route handlers answering once authorized and past the rate limit
+			router, err := server.newRouter()
+			assert.NoError(t, err)
+			router.GET(serverRecordsPath+"/:id", server.authorize(utils.ScopeRetrieve),
+				func(c *gin.Context) { c.Status(http.StatusOK) })
+			router.DELETE(serverRecordsPath+"/:id", server.authorize(utils.ScopeDelete),
+				func(c *gin.Context) { c.Status(http.StatusOK) })
+			router.POST(serverRecordsPath, server.authorize(utils.ScopeStore), server.postRecord)
+
+			for i, r := range test.requests {
+				var body io.Reader
+				if r.postID != "" {
+					data, _ := json.Marshal(Record{ID: r.postID, Data: recordHexStr})
+					body = bytes.NewReader(data)
+				}
+				req, _ := http.NewRequest(r.method, r.path, body)
+				req.Header.Set(contentTypeHeader, contentTypeJSON)
+				if r.apiKey != "" {
+					req.Header.Set(apiKeyHeader, r.apiKey)
+				}
+				if r.ip != "" {
+					req.RemoteAddr = r.ip + ":1234"
+				}
+				if r.forwarded != "" {
+					req.Header.Set("X-Forwarded-For", r.forwarded)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				// Verify status code, and the wait before retrying once limited
+				assert.Equal(t, test.expectedStatus[i], w.Code)
+				if w.Code == http.StatusTooManyRequests {
+					assert.Equal(t, test.expectedRetryAfter, w.Header().Get("Retry-After"))
+				}
+			}
+		})
+	}
+}
+
+// requestID() - Test Method
+func TestServer_requestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantSame  bool
+	}{
+		{
+			name:     "should generate request IDs",
+			wantSame: false,
+		},
+		{
+			name:      "should accept the request ID of the caller",
+			requestID: "req-1",
+			wantSame:  true,
+		},
+		{
+			name:      "should replace invalid request IDs",
+			requestID: "req 1",
+			wantSame:  false,
+		},
+		{
+			name:      "should replace overlong request IDs",
+			requestID: strings.Repeat("a", 129),
+			wantSame:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Route handler echoing the request ID of its context
+			router := gin.New()
+			router.Use(requestID)
+			router.GET(serverRecordsPath+"/:id", func(c *gin.Context) {
+				c.String(http.StatusOK, utils.RequestIDFromContext(c.Request.Context()))
+			})
+
+			req, _ := http.NewRequest(httpMethodGET, serverRecordsPath+"/"+idHexStr, nil)
+			if test.requestID != "" {
+				req.Header.Set(utils.RequestIDHeader, test.requestID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Verify the response returns the request ID handlers see
+			got := w.Header().Get(utils.RequestIDHeader)
+			assert.True(t, utils.ValidRequestID(got))
+			assert.Equal(t, got, w.Body.String())
+			assert.Equal(t, test.wantSame, got == test.requestID)
+		})
+	}
+}
+
+// observe() - Test Method
+func TestServer_observe(t *testing.T) {
+
+	// Routes answering by the status requested, on a path no other test uses
+	router := gin.New()
+	router.Use(observe)
+	router.GET("/observed/:status", func(c *gin.Context) {
+		code, _ := strconv.Atoi(c.Param("status"))
+		c.Status(code)
+	})
+	router.GET(utils.MetricsPath, gin.WrapH(utils.MetricsHandler()))
+
+	for _, status := range []string{"200", "200", "404", "429", "500"} {
+		req, _ := http.NewRequest(httpMethodGET, "/observed/"+status, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	req, _ := http.NewRequest(httpMethodGET, "/unrouted", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	w := httptest.NewRecorder()
+	req, _ = http.NewRequest(httpMethodGET, utils.MetricsPath, nil)
+	router.ServeHTTP(w, req)
+
+	// Verify requests are counted by route, and errors by type
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	for _, line := range []string{
+		`enc_server_requests_total{operation="GET /observed/:status"} 5`,
+		`enc_server_request_duration_seconds_count{operation="GET /observed/:status"} 5`,
+		`enc_server_request_errors_total{operation="GET /observed/:status",type="not_found"} 1`,
+		`enc_server_request_errors_total{operation="GET /observed/:status",type="rate_limited"} 1`,
+		`enc_server_request_errors_total{operation="GET /observed/:status",type="internal"} 1`,
+		`enc_server_requests_total{operation="unmatched"} 1`,
+		`enc_server_request_errors_total{operation="unmatched",type="not_found"} 1`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+// traceRequest() - Test Method
+func TestServer_traceRequest(t *testing.T) {
+
+	// Spans are recorded in memory.
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentID := "00f067aa0ba902b7"
+	tests := []struct {
+		name        string
+		traceparent string
+		status      int
+		wantCode    codes.Code
+	}{
+		{
+			name:   "should trace requests as new traces",
+			status: http.StatusOK,
+		},
+		{
+			name:        "should continue the trace of the caller",
+			traceparent: "00-" + traceID + "-" + parentID + "-01",
+			status:      http.StatusOK,
+		},
+		{
+			name:     "should mark server errors failed",
+			status:   http.StatusInternalServerError,
+			wantCode: codes.Error,
+		},
+		{
+			name:   "should not mark client errors failed",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Route handler making a traced call
+			router := gin.New()
+			router.Use(traceRequest)
+			router.GET(serverRecordsPath+"/:id", func(c *gin.Context) {
+				_, span := utils.StartSpan(c.Request.Context(), "call")
+				span.End()
+				c.Status(test.status)
+			})
+
+			req, _ := http.NewRequest(httpMethodGET, serverRecordsPath+"/"+idHexStr, nil)
+			if test.traceparent != "" {
+				req.Header.Set("traceparent", test.traceparent)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			// Verify the call span is a child of the request span
+			spans := recorder.Ended()
+			assert.Len(t, spans, 2)
+			call, request := spans[len(spans)-2], spans[len(spans)-1]
+			recorder.Reset()
+
+			assert.Equal(t, "GET /records/:id", request.Name())
+			assert.Equal(t, trace.SpanKindServer, request.SpanKind())
+			assert.Equal(t, test.wantCode, request.Status().Code)
+			assert.Contains(t, request.Attributes(), attribute.Int("http.response.status_code", test.status))
+			assert.Equal(t, request.SpanContext().SpanID(), call.Parent().SpanID())
+			assert.Equal(t, request.SpanContext().TraceID(), call.SpanContext().TraceID())
+			if test.traceparent != "" {
+				assert.Equal(t, traceID, request.SpanContext().TraceID().String())
+				assert.Equal(t, parentID, request.Parent().SpanID().String())
+			} else {
+				assert.False(t, request.Parent().IsValid())
+			}
+		})
+	}
+}
+
+// audit() - Test Method
+func TestServer_audit(t *testing.T) {
+	auditFile := filepath.Join(t.TempDir(), "audit.log")
+	auditor, err := utils.MakeAuditor(map[string]string{"auditFile": auditFile, "idKeyStr": idKeyStr}, nil)
+	assert.NoError(t, err)
+	server := &serverImpl{
+		idNonce:    idNonce,
+		idCipher:   idCipher,
+		nameKey:    nameKey,
+		metaCipher: metaCipher,
+		auth:       keyAuth,
+		tokens:     noTokens,
+		limiter:    noLimits,
+		auditor:    auditor,
+	}
+
+	// Route handlers standing in for the record endpoints
+	router := gin.New()
+	router.Use(requestID)
+	router.POST(serverRecordsPath, server.audit(utils.AuditStore), server.authorize(utils.ScopeStore),
+		func(c *gin.Context) {
+			c.Set("recordID", idHexStr)
+			c.Status(http.StatusCreated)
+		})
+	router.GET(serverRecordsPath+"/:id", server.audit(utils.AuditRetrieve), server.authorize(utils.ScopeRetrieve),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/users/:id/records/:name", server.audit(utils.AuditRetrieve), server.authorize(utils.ScopeRetrieve),
+		func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	router.DELETE(serverRecordsPath+"/:id", server.audit(utils.AuditDelete), server.authorize(utils.ScopeDelete),
+		func(c *gin.Context) { c.Status(http.StatusAccepted) })
+
+	// Record operations are audited whether they succeed or not.
+	for _, r := range []struct {
+		method    string
+		path      string
+		apiKey    string
+		requestID string
+	}{
+		{httpMethodPOST, serverRecordsPath, storeAPIKey, "req-1"},
+		{httpMethodGET, serverRecordsPath + "/" + idHexStr, storeAPIKey, "req-2"},
+		{httpMethodGET, "/users/" + idHexStr + "/records/" + nameHexStr, storeAPIKey, "req-3"},
+		{httpMethodDELETE, serverRecordsPath + "/" + idHexStr, storeAPIKey, "req-4"},
+		{httpMethodDELETE, serverRecordsPath + "/" + idHexStr, "", "req-5"},
+	} {
+		req, _ := http.NewRequest(r.method, r.path, nil)
+		req.Header.Set(apiKeyHeader, r.apiKey)
+		req.Header.Set(utils.RequestIDHeader, r.requestID)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	file, err := os.Open(auditFile)
+	assert.NoError(t, err)
+	defer file.Close()
+	entries, err := utils.ReadAuditLog(file)
+	assert.NoError(t, err)
+
+	recordID := utils.AuditRecordID(idEnc, utils.SealName(idCipher, idKey, id, nil))
+	namedRecordID := utils.AuditRecordID(idEnc, nameEnc)
+	want := []utils.AuditEvent{
+		{Operation: utils.AuditStore, Principal: "ci", RecordID: recordID, Outcome: utils.AuditOK, RequestID: "req-1"},
+		{Operation: utils.AuditRetrieve, Principal: "ci", RecordID: recordID, Outcome: utils.AuditOK, RequestID: "req-2"},
+		{Operation: utils.AuditRetrieve, Principal: "ci", RecordID: namedRecordID, Outcome: "404 Not Found",
+			RequestID: "req-3"},
+		{Operation: utils.AuditDelete, Principal: "ci", RecordID: recordID, Outcome: "403 Forbidden", RequestID: "req-4"},
+		{Operation: utils.AuditDelete, RecordID: recordID, Outcome: "401 Unauthorized", RequestID: "req-5"},
+	}
+	if assert.Len(t, entries, len(want)) {
+		for i, entry := range entries {
+			assert.Equal(t, want[i], entry.AuditEvent)
+		}
+	}
+
+	// Entries chain from the first, and a removed entry breaks the chain.
+	_, err = utils.VerifyAuditLog(entries, auditKey)
+	assert.NoError(t, err)
+	_, err = utils.VerifyAuditLog(append(entries[:1], entries[2:]...), auditKey)
+	assert.Equal(t, errors.New("Audit log has a gap before entry 3"), err)
+}
+
+// routes() - Test Method
+func TestServer_routes(t *testing.T) {
+	recordID := utils.AuditRecordID(idEnc, nil)
+	namedRecordID := utils.AuditRecordID(idEnc, nameEnc)
+
+	// Requests reading records, their attributes, versions and key slots, or
+	// listing a user's records, are audited as retrieves.
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		wantRecordID string
+	}{
+		{
+			name:         "should audit record attributes",
+			method:       httpMethodHEAD,
+			path:         serverRecordsPath + "/" + idHexStr,
+			wantRecordID: recordID,
+		},
+		{
+			name:         "should audit named record attributes",
+			method:       httpMethodHEAD,
+			path:         "/users/" + idHexStr + "/records/" + nameHexStr,
+			wantRecordID: namedRecordID,
+		},
+		{
+			name:         "should audit record versions",
+			method:       httpMethodGET,
+			path:         serverRecordsPath + "/" + idHexStr + "/versions",
+			wantRecordID: recordID,
+		},
+		{
+			name:         "should audit named record versions",
+			method:       httpMethodGET,
+			path:         "/users/" + idHexStr + "/records/" + nameHexStr + "/versions",
+			wantRecordID: namedRecordID,
+		},
+		{
+			name:         "should audit record key slots",
+			method:       httpMethodGET,
+			path:         serverRecordsPath + "/" + idHexStr + "/keyslot",
+			wantRecordID: recordID,
+		},
+		{
+			name:         "should audit named record key slots",
+			method:       httpMethodGET,
+			path:         "/users/" + idHexStr + "/records/" + nameHexStr + "/keyslot",
+			wantRecordID: namedRecordID,
+		},
+		{
+			name:         "should audit record lists",
+			method:       httpMethodGET,
+			path:         "/users/" + idHexStr + "/records",
+			wantRecordID: recordID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditFile := filepath.Join(t.TempDir(), "audit.log")
+			auditor, err := utils.MakeAuditor(map[string]string{"auditFile": auditFile, "idKeyStr": idKeyStr}, nil)
+			assert.NoError(t, err)
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				auth:       keyAuth,
+				tokens:     noTokens,
+				limiter:    noLimits,
+				auditor:    auditor,
+				beClient:   &mockClientBE{},
+			}
+			router := gin.New()
+			server.routes(router)
+
+			req, _ := http.NewRequest(test.method, test.path, nil)
+			req.Header.Set(apiKeyHeader, storeAPIKey)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			file, err := os.Open(auditFile)
+			assert.NoError(t, err)
+			defer file.Close()
+			entries, err := utils.ReadAuditLog(file)
+			assert.NoError(t, err)
+			if assert.Len(t, entries, 1) {
+				assert.Equal(t, utils.AuditRetrieve, entries[0].Operation)
+				assert.Equal(t, "ci", entries[0].Principal)
+				assert.Equal(t, test.wantRecordID, entries[0].RecordID)
+			}
+		})
+	}
+}
+
+// loadRecord() - Test Method
+func TestServer_loadRecord(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            []byte
+		mockClientBEFn func() utils.ClientBE
+		want           []byte
+		wantErr        bool
+	}{
+		{
+			name: "should load record successfully",
+			key:  idKey,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// Verify the default record is retrieved against the key
+						assert.Equal(t, idEnc, id)
+						assert.Nil(t, name)
+						assert.Equal(t, utils.KeyHash(idKey), opts.KeyHash)
+						return storedInfo(false), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			want: record,
+		},
+		{
+			name: "should load chunked record successfully",
+			key:  idKey,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, ""), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(idKey, record), nil
+					},
+				}
+			},
+			want: record,
+		},
+		{
+			name: "should fail with the wrong key",
+			key:  memberKey,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return storedInfo(false), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name:           "should fail when backend client fails to retrieve",
+			key:            idKey,
+			mockClientBEFn: func() utils.ClientBE { return &mockClientBE{} },
+			wantErr:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				beClient:   test.mockClientBEFn(),
+			}
+
+			got, err := server.loadRecord(context.Background(), id, nil, test.key)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+// Helper function to sign a bearer token over the given claims
+func signToken(alg, kid string, claims map[string]any, sign func(signed []byte) []byte) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	body, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+// Helper function to build a multipart form with an optional data file part
+func multipartBody(fields map[string]string, fileType string, data []byte) ([]byte, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for field, value := range fields {
+		_ = writer.WriteField(field, value)
+	}
+	if data != nil {
+		header := make(textproto.MIMEHeader)
+		header.Set(contentTypeHeader, fileType)
+		header.Set("Content-Disposition", `form-data; name="data"; filename="record"`)
+		part, _ := writer.CreatePart(header)
+		_, _ = part.Write(data)
+	}
+	_ = writer.Close()
+	return body.Bytes(), writer.FormDataContentType()
+}
+
+// Helper function to open attributes sealed on store, verifying they commit
+// to the random record key
+func storedMetadata(t *testing.T, metadata []byte) utils.RecordMetadata {
+	meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, metadata)
+	assert.NoError(t, err)
+	assert.Len(t, meta.KeyHash, sha256.Size)
+	meta.KeyHash = nil
+	return meta
+}
+
+// postRecord() - Test Method
+func TestServer_postRecord(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      Record
+		rawBody          func() (body []byte, contentType string)
+		query            string
+		params           gin.Params
+		headers          map[string]string
+		compressor       utils.Compressor
+		padder           utils.Padder
+		signer           utils.Signer
+		mockKeyGenFn     func() utils.KeyGen
+		mockClientBEFn   func() utils.ClientBE
+		principal        *utils.Principal
+		expectedStatus   int
+		expectedHasKey   bool
+		expectedShares   int
+		expectedErrorMsg string
+	}{
+		{
+			name: "should post record successfully",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify encrypted ID and record are passed
+						assert.NotNil(t, id)
+						assert.Nil(t, name)
+						assert.NotNil(t, record)
+						assert.False(t, opts.OneTime)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post record for user ID of token successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:     "?" + idQueryParam + "=" + idHexStr,
+			principal: &utils.Principal{Subject: idStr, Scopes: []string{utils.ScopeStore}, UserIDs: [][]byte{id}},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should fail to post record for user ID other than token's",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:     "?" + idQueryParam + "=" + idHexStr,
+			principal: &utils.Principal{Subject: "bob", Scopes: []string{utils.ScopeStore}, UserIDs: [][]byte{[]byte("bob")}},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrUserForbidden.Error(),
+		},
+		{
+			name: "should post record with attributes successfully",
+			requestBody: Record{
+				ID:          idHexStr,
+				Data:        recordHexStr,
+				ContentType: contentTypeText,
+				Labels:      labels,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify attributes are sealed for the plaintext ID
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							ContentType: contentTypeText,
+							Size:        int64(len(recordStr)),
+							Labels:      labels,
+						}, meta)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post base64 record successfully",
+			requestBody: Record{
+				ID:       idHexStr,
+				Data:     base64.StdEncoding.EncodeToString(record),
+				Encoding: encodingBase64,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post raw record successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + oneTimeQueryParam + "=true",
+			headers: map[string]string{
+				recordContentTypeHeader: contentTypeText,
+				labelsHeader:            "env=prod",
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify ID, flags and attributes are taken from the query and headers
+						assert.Equal(t, idEnc, id)
+						assert.True(t, opts.OneTime)
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							ContentType: contentTypeText,
+							Size:        int64(len(recordStr)),
+							Labels:      labels,
+							Chunked:     true,
+						}, meta)
+
+						// Verify the body is sealed as a chunked stream
+						plain, err := utils.OpenStream(idCipher, record)
+						assert.NoError(t, err)
+						assert.Equal(t, []byte(recordStr), plain)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post multipart record successfully",
+			rawBody: func() ([]byte, string) {
+				return multipartBody(map[string]string{
+					idQueryParam: idHexStr,
+					"labels":     "env=prod",
+				}, contentTypeText, record)
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify form fields and the file part's content type are used
+						assert.Equal(t, idEnc, id)
+						assert.False(t, opts.OneTime)
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							ContentType: contentTypeText,
+							Size:        int64(len(recordStr)),
+							Labels:      labels,
+							Chunked:     true,
+						}, meta)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post compressed record successfully",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			compressor: gzipCompressor,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the record is compressed before sealing
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							Size:        int64(len(recordStr)),
+							Compression: utils.CompressionGzip,
+						}, meta)
+						compressed, err := idCipher.Open(nil, record[:idCipher.NonceSize()], record[idCipher.NonceSize():], nil)
+						assert.NoError(t, err)
+						assert.Equal(t, compressedRecord, compressed)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post compressed raw record successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:      "?" + idQueryParam + "=" + idHexStr,
+			compressor: gzipCompressor,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the body is compressed as it is sealed
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							Size:        int64(len(recordStr)),
+							Chunked:     true,
+							Compression: utils.CompressionGzip,
+						}, meta)
+						compressed, err := utils.OpenStream(idCipher, record)
+						assert.NoError(t, err)
+						plain, err := utils.Decompress(gzipCompressor, meta.Compression, meta.Size, compressed)
+						assert.NoError(t, err)
+						assert.Equal(t, []byte(recordStr), plain)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post padded record successfully",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			padder: blockPadder,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the record is padded before sealing
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							Size:     int64(len(recordStr)),
+							Padding:  utils.PaddingBlock,
+							Unpadded: int64(len(recordStr)),
+						}, meta)
+						padded, err := idCipher.Open(nil, record[:idCipher.NonceSize()], record[idCipher.NonceSize():], nil)
+						assert.NoError(t, err)
+						assert.Equal(t, paddedRecord, padded)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post compressed and padded raw record successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:      "?" + idQueryParam + "=" + idHexStr,
+			compressor: gzipCompressor,
+			padder:     blockPadder,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the body is compressed, then padded as it is sealed
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Equal(t, utils.RecordMetadata{
+							Size:        int64(len(recordStr)),
+							Chunked:     true,
+							Compression: utils.CompressionGzip,
+							Padding:     utils.PaddingBlock,
+							Unpadded:    int64(len(compressedRecord)),
+						}, meta)
+						padded, err := utils.OpenStream(idCipher, record)
+						assert.NoError(t, err)
+						assert.Len(t, padded, 100)
+						compressed, err := utils.Unpad(padded, meta.Unpadded)
+						assert.NoError(t, err)
+						plain, err := utils.Decompress(gzipCompressor, meta.Compression, meta.Size, compressed)
+						assert.NoError(t, err)
+						assert.Equal(t, []byte(recordStr), plain)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post record for recipients successfully",
+			requestBody: Record{
+				ID:         idHexStr,
+				Data:       recordHexStr,
+				Recipients: []string{recipientHexStr},
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the record key is wrapped for the recipient
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Len(t, meta.KeySlots, 1)
+						key, err := utils.UnwrapKey(recipientPrivate, meta.KeySlots[0])
+						assert.NoError(t, err)
+						assert.Equal(t, idKey, key)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "should post raw record for recipients successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + recipientQueryParam + "=" + recipientHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the record key is wrapped for the recipient
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Len(t, meta.KeySlots, 1)
+						key, err := utils.UnwrapKey(recipientPrivate, meta.KeySlots[0])
+						assert.NoError(t, err)
+						assert.Equal(t, idKey, key)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "should post record with key shares successfully",
+			requestBody: Record{
+				ID:        idHexStr,
+				Data:      recordHexStr,
+				Shares:    5,
+				Threshold: 3,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedShares: 5,
+		},
+		{
+			name: "should post raw record with key shares successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + sharesQueryParam + "=2&" + thresholdQueryParam + "=2",
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedShares: 2,
+		},
+		{
+			name: "should post signed record successfully",
+			requestBody: Record{
+				ID:        idHexStr,
+				Data:      recordHexStr,
+				Signer:    clientKeyHexStr,
+				Signature: hex.EncodeToString(contentSignature.Signature),
+			},
+			signer: signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify attributes carry the cipher entry digest and are
+						// signed along with the content signature
+						digest := sha256.Sum256(record)
+						assert.Equal(t, digest[:], storedMetadata(t, opts.Metadata).Digest)
+						content, err := signingSigner.VerifyEnvelope(id, name, opts.Metadata, opts.Signatures)
+						assert.NoError(t, err)
+						assert.Equal(t, contentSignature, content)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post signed raw record successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + signerQueryParam + "=" + clientKeyHexStr +
+				"&" + signatureQueryParam + "=" + hex.EncodeToString(contentSignature.Signature),
+			signer: signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the digest covers the sealed stream
+						digest := sha256.Sum256(record)
+						assert.Equal(t, digest[:], storedMetadata(t, opts.Metadata).Digest)
+						content, err := signingSigner.VerifyEnvelope(id, name, opts.Metadata, opts.Signatures)
+						assert.NoError(t, err)
+						assert.Equal(t, contentSignature, content)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should fail with untrusted content signature",
+			requestBody: Record{
+				ID:        idHexStr,
+				Data:      recordHexStr,
+				Signer:    clientKeyHexStr,
+				Signature: hex.EncodeToString(contentSignature.Signature),
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignerUnknown.Error(),
+		},
+		{
+			name: "should fail with invalid raw content signature",
+			rawBody: func() ([]byte, string) {
+				return []byte(nameStr), contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + signerQueryParam + "=" + clientKeyHexStr +
+				"&" + signatureQueryParam + "=" + hex.EncodeToString(contentSignature.Signature),
+			signer: signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name: "should fail with content signature missing its signer",
+			requestBody: Record{
+				ID:        idHexStr,
+				Data:      recordHexStr,
+				Signature: hex.EncodeToString(contentSignature.Signature),
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Content signatures require a signer and signature",
+		},
+		{
+			name: "should fail with threshold above key shares",
+			requestBody: Record{
+				ID:        idHexStr,
+				Data:      recordHexStr,
+				Shares:    2,
+				Threshold: 3,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: shamir.ErrInvalidThreshold.Error(),
+		},
+		{
+			name: "should fail with key shares for recipients",
+			requestBody: Record{
+				ID:         idHexStr,
+				Data:       recordHexStr,
+				Recipients: []string{recipientHexStr},
+				Shares:     3,
+				Threshold:  2,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Key shares cannot be combined with recipients",
+		},
+		{
+			name: "should fail with invalid raw key share count",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr + "&" + sharesQueryParam + "=three",
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: strconv.ErrSyntax.Error(),
+		},
+		{
+			name: "should fail with invalid recipient public key",
+			requestBody: Record{
+				ID:         idHexStr,
+				Data:       recordHexStr,
+				Recipients: []string{idHexStr},
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badRecipientMessage,
+		},
+		{
+			name: "should post raw record opted out of compression successfully",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:      "?" + idQueryParam + "=" + idHexStr + "&" + noCompressQueryParam + "=true",
+			compressor: gzipCompressor,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    func() ([]byte, error) { return idKey, nil },
+					randomNonceFn:  keygen.RandomNonce,
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify the body is sealed uncompressed
+						meta := storedMetadata(t, opts.Metadata)
+						assert.Empty(t, meta.Compression)
+						plain, err := utils.OpenStream(idCipher, record)
+						assert.NoError(t, err)
+						assert.Equal(t, []byte(recordStr), plain)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should fail when nonce prefix generation fails for raw record",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: keygen.GetGCMCipher,
+					randomKeyFn:    keygen.RandomKey,
+					randomNonceFn: func(nonceSize int) ([]byte, error) {
+						assert.Equal(t, utils.StreamPrefixSize, nonceSize)
+						return nil, errors.New(errNonceGenFailed)
+					},
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errNonceGenFailed,
+		},
+		{
+			name: "should fail when backend client fails to store raw record",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query: "?" + idQueryParam + "=" + idHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						return errors.New(errBackendStorageFailed)
+					},
+				}
+			},
+			expectedStatus:   http.StatusBadGateway,
+			expectedErrorMsg: errBackendStorageFailed,
+		},
+		{
+			name: "should fail with multipart form missing data",
+			rawBody: func() ([]byte, string) {
+				return multipartBody(map[string]string{idQueryParam: idHexStr}, "", nil)
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: http.ErrMissingFile.Error(),
+		},
+		{
+			name: "should fail with malformed raw record labels",
+			rawBody: func() ([]byte, string) {
+				return record, contentTypeOctetStream
+			},
+			query:   "?" + idQueryParam + "=" + idHexStr,
+			headers: map[string]string{labelsHeader: "env=%zz"},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "invalid URL escape",
+		},
+		{
+			name: "should fail with unsupported encoding",
+			requestBody: Record{
+				ID:       idHexStr,
+				Data:     recordStr,
+				Encoding: "base32",
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Unsupported encoding base32",
+		},
+		{
+			name: "should post named record successfully",
+			requestBody: Record{
+				Data: recordHexStr,
+			},
+			params: gin.Params{
+				{Key: idQueryParam, Value: idHexStr},
+				{Key: nameQueryParam, Value: nameHexStr},
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify ID and name are taken from the path
+						assert.Equal(t, idEnc, id)
+						assert.Equal(t, nameEnc, name)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should fail with invalid hex name",
+			requestBody: Record{
+				Data: recordHexStr,
+			},
+			params: gin.Params{
+				{Key: idQueryParam, Value: idHexStr},
+				{Key: nameQueryParam, Value: invalidHexID},
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name: "should post one-time record successfully",
+			requestBody: Record{
+				ID:      idHexStr,
+				Data:    recordHexStr,
+				OneTime: true,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify key commitment accompanies one-time record
+						assert.True(t, opts.OneTime)
+						assert.Len(t, opts.KeyHash, 32)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should post record with preconditions successfully",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			headers: map[string]string{ifMatchHeader: `"1"`},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						// Verify revision precondition is forwarded
+						assert.Equal(t, utils.Precondition{IfMatch: 1}, opts.Match)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedHasKey: true,
+		},
+		{
+			name: "should fail with unsupported If-None-Match",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			headers: map[string]string{ifNoneMatchHeader: `"1"`},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "If-None-Match only supports *",
+		},
+		{
+			name: "should fail when create-only record exists",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			headers: map[string]string{ifNoneMatchHeader: utils.AnyRevision},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						assert.True(t, opts.Match.IfNotExists)
+						return utils.ErrPreconditionFailed
+					},
+				}
+			},
+			expectedStatus:   http.StatusPreconditionFailed,
+			expectedErrorMsg: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name: "should fail with invalid hex ID",
+			requestBody: Record{
+				ID:   invalidHexID,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name: "should fail with invalid hex data",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: invalidHexID,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name: "should fail when random key generation fails",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
+						return idCipher, nil
+					},
+					randomKeyFn: func() ([]byte, error) {
+						return nil, errors.New(errRandomKeyGenFailed)
+					},
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "should fail when nonce generation fails",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
+						return idCipher, nil
+					},
+					randomKeyFn: func() ([]byte, error) {
+						return keygen.RandomKey()
+					},
+					randomNonceFn: func(nonceSize int) ([]byte, error) {
+						return nil, errors.New(errNonceGenFailed)
+					},
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "should fail when metadata nonce generation fails",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				calls := 0
+				return &mockKeyGen{
+					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
+						return keygen.GetGCMCipher(key)
+					},
+					randomKeyFn: func() ([]byte, error) {
+						return keygen.RandomKey()
+					},
+					randomNonceFn: func(nonceSize int) ([]byte, error) {
+						if calls++; calls > 1 {
+							return nil, errors.New(errNonceGenFailed)
+						}
+						return keygen.RandomNonce(nonceSize)
+					},
+				}
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "should fail when backend client fails to store record",
+			requestBody: Record{
+				ID:   idHexStr,
+				Data: recordHexStr,
+			},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, record []byte, opts utils.StoreOptions) error {
+						return errors.New(errBackendStorageFailed)
+					},
+				}
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kg := test.mockKeyGenFn()
+			mockKg, _ := kg.(*mockKeyGen)
+			if mockKg == nil {
+				mockKg = &mockKeyGen{
+					getGCMCipherFn: func(key []byte) (cipher.AEAD, error) {
+						return keygen.GetGCMCipher(key)
+					},
+				}
+			}
+
+			compressor := test.compressor
+			if compressor == nil {
+				compressor = noCompressor
+			}
+			padder := test.padder
+			if padder == nil {
+				padder = noPadder
+			}
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+
+			idCipherTest, _ := kg.GetGCMCipher([]byte(idKeyStr))
+			server := &serverImpl{
+				keygen:     kg,
+				idNonce:    idNonce,
+				idCipher:   idCipherTest,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: compressor,
+				padder:     padder,
+				signer:     signer,
+				limiter:    noLimits,
+				beClient:   test.mockClientBEFn(),
+				serverAddr: ":" + port,
+			}
+
+			// Create request
+			body, _ := json.Marshal(test.requestBody)
+			contentType := contentTypeJSON
+			if test.rawBody != nil {
+				body, contentType = test.rawBody()
+			}
+			req, _ := http.NewRequest(httpMethodPOST, serverRecordsPath+test.query, bytes.NewReader(body))
+			req.Header.Set(contentTypeHeader, contentType)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = test.params
+			if test.principal != nil {
+				ctx.Set("principal", *test.principal)
+			}
+
+			// Call handler
+			server.postRecord(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify response has key unless stored for recipients
+			if test.expectedStatus == http.StatusCreated {
+				var resp Record
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, test.expectedHasKey, resp.Key != "")
+				assert.Len(t, resp.KeyShares, test.expectedShares)
+
+				// Verify a threshold of key shares recovers the record key
+				if test.expectedShares > 0 {
+					var shares [][]byte
+					for _, shareStr := range resp.KeyShares[test.expectedShares-resp.Threshold:] {
+						share, _ := hex.DecodeString(shareStr)
+						shares = append(shares, share)
+					}
+					key, err := shamir.Combine(shares)
+					assert.NoError(t, err)
+					assert.Equal(t, idKey, key)
+				}
+				if idParam := test.params.ByName(idQueryParam); idParam != "" {
+					assert.Equal(t, idParam, resp.ID)
+				} else {
+					assert.Equal(t, idHexStr, resp.ID)
+				}
+				assert.Equal(t, test.params.ByName(nameQueryParam), resp.Name)
+				assert.Equal(t, int64(1), resp.Version)
+				assert.Equal(t, int64(len(recordStr)), resp.Size)
+				if test.rawBody == nil {
+					assert.Equal(t, test.requestBody.ContentType, resp.ContentType)
+					assert.Equal(t, test.requestBody.Labels, resp.Labels)
+				}
+				assert.Equal(t, `"1"`, w.Header().Get(etagHeader))
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// getRecord() - Test Method
+func TestServer_getRecord(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		nameParam        string
+		keyParam         string
+		sharesParam      []string
+		versionParam     string
+		encodingParam    string
+		acceptHeader     string
+		compressor       utils.Compressor
+		padder           utils.Padder
+		signer           utils.Signer
+		mockKeyGenFn     func() utils.KeyGen
+		mockClientBEFn   func(key []byte) utils.ClientBE // Pass key to mock
+		expectedStatus   int
+		expectedVersion  int64
+		expectedAttrs    Record
+		expectedErrorMsg string
+		validateData     func(data []byte, t *testing.T)
+	}{
+		{
+			name:     "should get record successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						// Verify encrypted ID and key commitment are passed
+						assert.NotNil(t, id)
+						assert.Equal(t, utils.KeyHash(key), opts.KeyHash)
+						// Encrypt record with the SAME key that will be used for decryption
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 1,
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:      "should get named record successfully",
+			idParam:   idHexStr,
+			nameParam: nameHexStr,
+			keyParam:  hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						// Verify encrypted name is passed
+						assert.Equal(t, nameEnc, name)
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 1,
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:     "should get record with attributes successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return storedInfo(false), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs: Record{
+				ContentType: contentTypeText,
+				Labels:      labels,
+				Created:     created,
+				Updated:     updated,
+			},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:     "should fail when record attributes are corrupted",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{Version: 1, Metadata: []byte(corruptedData)}, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:     "should get signed record successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			signer:   signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				info, sealed := signedRecord(key)
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return info, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealed, nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs: Record{
+				ContentType: contentTypeText,
+				Labels:      labels,
+				Signer:      clientKeyHexStr,
+				Created:     created,
+				Updated:     updated,
+			},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:         "should get signed raw record successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			signer:       signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				info, sealed := signedRecord(key)
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return info, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealed, nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs:   Record{Signer: clientKeyHexStr},
+		},
+		{
+			name:     "should fail when record signatures do not verify",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			signer:   signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				info, sealed := signedRecord(key)
+				info.Metadata = storedInfo(false).Metadata
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return info, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealed, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name:     "should fail when record does not match its signed digest",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			signer:   signingSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				info, _ := signedRecord(key)
+				_, other := signedRecord(key)
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return info, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return other, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name:     "should fail on unsigned record when signatures are required",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			signer:   requireSigner,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return storedInfo(false), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureMissing.Error(),
+		},
+		{
+			name:         "should get raw record successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return storedInfo(false), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:         "should get chunked raw record successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, ""), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, record), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:     "should get chunked record successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, ""), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, record), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs: Record{
+				ContentType: contentTypeText,
+				Labels:      labels,
+				Created:     created,
+				Updated:     updated,
+			},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:     "should fail when chunked record is truncated",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, ""), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						sealed := sealedStream(key, record)
+						return sealed[:len(sealed)-1], nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: badDecryptMessage,
+		},
+		{
+			name:     "should get compressed record successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(false, utils.CompressionGzip), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, compressedRecord, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs: Record{
+				ContentType: contentTypeText,
+				Labels:      labels,
+				Created:     created,
+				Updated:     updated,
+			},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:         "should get compressed chunked raw record successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, utils.CompressionGzip), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, compressedRecord), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:         "should get record beyond configured limit up to sealed size successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			compressor:   limitedCompressor,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return sealedInfo(true, utils.CompressionGzip), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, compressedRecord), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:       "should fail decompressing beyond sealed size",
+			idParam:    idHexStr,
+			keyParam:   hex.EncodeToString(make([]byte, 32)),
+			compressor: gzipCompressor,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(_, _ []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						nonce, _ := keygen.RandomNonce(metaCipher.NonceSize())
+						metadata, _ := utils.SealMetadata(metaCipher, nonce, id, nil, utils.RecordMetadata{
+							Size:        16,
+							Chunked:     true,
+							Compression: utils.CompressionGzip,
+						})
+						return utils.RecordInfo{Version: 3, Created: created, Metadata: metadata}, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, compressedRecord), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: utils.ErrDecompressedTooLarge.Error(),
+		},
+		{
+			name:     "should get padded record successfully",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return paddedInfo(false, int64(len(record))), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, paddedRecord, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs: Record{
+				ContentType: contentTypeText,
+				Labels:      labels,
+				Created:     created,
+				Updated:     updated,
+			},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:         "should get padded chunked raw record successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			acceptHeader: contentTypeOctetStream,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return paddedInfo(true, int64(len(record))), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return sealedStream(key, paddedRecord), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:     "should fail unpadding record shorter than its contents",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return paddedInfo(false, 200), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, paddedRecord, nil), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: utils.ErrPaddingInvalid.Error(),
+		},
+		{
+			name:          "should get base64 record successfully",
+			idParam:       idHexStr,
+			keyParam:      hex.EncodeToString(make([]byte, 32)),
+			encodingParam: encodingBase64,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 1,
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, base64.StdEncoding.EncodeToString(record), string(data))
+			},
+		},
+		{
+			name:             "should fail with unsupported encoding before retrieval",
+			idParam:          idHexStr,
+			keyParam:         hex.EncodeToString(make([]byte, 32)),
+			encodingParam:    "base32",
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Unsupported encoding base32",
+		},
+		{
+			name:             "should fail with invalid hex name",
+			idParam:          idHexStr,
+			nameParam:        invalidHexID,
+			keyParam:         hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:         "should get prior version successfully",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			versionParam: "2",
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						assert.Equal(t, int64(2), opts.Version)
+						cipher, _ := keygen.GetGCMCipher(key)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 2,
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:             "should fail with invalid version",
+			idParam:          idHexStr,
+			keyParam:         hex.EncodeToString(make([]byte, 32)),
+			versionParam:     "latest",
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badVersion,
+		},
+		{
+			name:           "should fail when key query parameter missing",
+			idParam:        idHexStr,
+			keyParam:       "",
+			mockKeyGenFn:   func() utils.KeyGen { return keygen },
+			mockClientBEFn: func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:             "should fail with invalid hex ID",
+			idParam:          invalidHexID,
+			keyParam:         hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:             "should fail with invalid hex key",
+			idParam:          idHexStr,
+			keyParam:         invalidHexID,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:        "should get record with key shares successfully",
+			idParam:     idHexStr,
+			sharesParam: idKeyShares[1:],
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						// Verify the recovered key is presented
+						assert.Equal(t, utils.KeyHash(idKey), opts.KeyHash)
+						cipher, _ := keygen.GetGCMCipher(idKey)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 1,
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:        "should fail with duplicate key shares",
+			idParam:     idHexStr,
+			sharesParam: []string{idKeyShares[0], idKeyShares[0]},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: shamir.ErrInvalidShares.Error(),
+		},
+		{
+			name:        "should fail with invalid hex key share",
+			idParam:     idHexStr,
+			sharesParam: []string{idKeyShares[0], invalidHexID},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{}
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:     "should get member record successfully",
+			idParam:  idHexStr,
+			keyParam: memberKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return memberInfo(), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						// Record is sealed under the record key, not the member key
+						cipher, _ := keygen.GetGCMCipher(idKey)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs:   Record{Created: created, Updated: updated},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:     "should get one-time member record successfully",
+			idParam:  idHexStr,
+			keyParam: memberKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// One-time records are only consumed against the record key
+						if !opts.MetadataOnly && !bytes.Equal(utils.KeyHash(idKey), opts.KeyHash) {
+							return utils.RecordInfo{}, utils.ErrKeyMismatch
+						}
+						return memberInfo(), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(idKey)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+			expectedAttrs:   Record{Created: created, Updated: updated},
+			validateData: func(data []byte, t *testing.T) {
+				assert.Equal(t, record, data)
+			},
+		},
+		{
+			name:     "should fail on one-time record without a member key slot",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						if !opts.MetadataOnly {
+							return utils.RecordInfo{}, utils.ErrKeyMismatch
+						}
+						return memberInfo(), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:     "should fail when backend client fails to retrieve record",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return nil, errors.New(errBackendRetrievalFailed)
+					},
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:     "should fail with forbidden when record was sealed under another key",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						cipher, _ := keygen.GetGCMCipher(idKey)
+						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
+						return cipher.Seal(nonce, nonce, record, nil), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:     "should fail when decryption fails (corrupted data)",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return []byte(corruptedData), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:     "should fail with not found on missing record",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return nil, utils.ErrRecordNotFound
+					},
+				}
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrRecordNotFound.Error(),
+		},
+		{
+			name:         "should fail with not found on unretained version",
+			idParam:      idHexStr,
+			keyParam:     hex.EncodeToString(make([]byte, 32)),
+			versionParam: "2",
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return nil, utils.ErrVersionNotFound
+					},
+				}
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrVersionNotFound.Error(),
+		},
+		{
+			name:     "should fail when record is shorter than its nonce",
+			idParam:  idHexStr,
+			keyParam: hex.EncodeToString(make([]byte, 32)),
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func(key []byte) utils.ClientBE {
+				return &mockClientBE{
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return []byte{1, 2, 3}, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: "Sealed record too short",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kg := test.mockKeyGenFn()
+			idCipherTest, _ := kg.GetGCMCipher([]byte(idKeyStr))
+			if idCipherTest == nil {
+				idCipherTest = idCipher
+			}
+
+			// Decode the key parameter to use in mock backend
+			var keyBytes []byte
+			if test.keyParam != "" {
+				keyBytes, _ = hex.DecodeString(test.keyParam)
+			} else {
+				keyBytes = make([]byte, 32)
+			}
+
+			compressor := test.compressor
+			if compressor == nil {
+				compressor = noCompressor
+			}
+			padder := test.padder
+			if padder == nil {
+				padder = noPadder
+			}
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+
+			server := &serverImpl{
+				keygen:     kg,
+				idNonce:    idNonce,
+				idCipher:   idCipherTest,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: compressor,
+				padder:     padder,
+				signer:     signer,
+				beClient:   test.mockClientBEFn(keyBytes), // Pass key to mock
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path and query parameters
+			url := serverRecordsPath + "/" + test.idParam
+			if test.keyParam != "" {
+				url += "?" + keyQueryParam + "=" + test.keyParam
+			}
+			for i, share := range test.sharesParam {
+				if i == 0 {
+					url += "?" + shareQueryParam + "=" + share
+				} else {
+					url += "&" + shareQueryParam + "=" + share
+				}
+			}
+			if test.versionParam != "" {
+				url += "&" + versionQueryParam + "=" + test.versionParam
+			}
+			if test.encodingParam != "" {
+				url += "&" + encodingQueryParam + "=" + test.encodingParam
+			}
+			req, _ := http.NewRequest(httpMethodGET, url, nil)
+			if test.acceptHeader != "" {
+				req.Header.Set(acceptHeader, test.acceptHeader)
+			}
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: idQueryParam, Value: test.idParam},
+				{Key: nameQueryParam, Value: test.nameParam},
+			}
+
+			// Call handler
+			server.getRecord(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If raw success, verify record bytes and attribute headers
+			if test.expectedStatus == http.StatusOK && test.acceptHeader == contentTypeOctetStream {
+				assert.Equal(t, contentTypeOctetStream, w.Header().Get(contentTypeHeader))
+				assert.Equal(t, record, w.Body.Bytes())
+				assert.Equal(t, utils.FormatETag(test.expectedVersion), w.Header().Get(etagHeader))
+				assert.Equal(t, strconv.Itoa(len(recordStr)), w.Header().Get(sizeHeader))
+				assert.Equal(t, contentTypeText, w.Header().Get(recordContentTypeHeader))
+				assert.Equal(t, "env=prod", w.Header().Get(labelsHeader))
+				assert.Equal(t, test.expectedAttrs.Signer, w.Header().Get(signerHeader))
+				return
+			}
+
+			// If success, verify response structure
+			if test.expectedStatus == http.StatusOK {
+				var resp Record
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, test.idParam, resp.ID)
+				assert.Equal(t, test.nameParam, resp.Name)
+				assert.Equal(t, test.keyParam, resp.Key)
+				assert.Equal(t, test.expectedVersion, resp.Version)
+				assert.Equal(t, test.encodingParam, resp.Encoding)
+				assert.Equal(t, utils.FormatETag(test.expectedVersion), w.Header().Get(etagHeader))
+				assert.Equal(t, int64(len(recordStr)), resp.Size)
+				assert.Equal(t, test.expectedAttrs.ContentType, resp.ContentType)
+				assert.Equal(t, test.expectedAttrs.Labels, resp.Labels)
+				assert.Equal(t, test.expectedAttrs.Signer, resp.Signer)
+				assert.True(t, test.expectedAttrs.Created.Equal(resp.Created))
+				assert.True(t, test.expectedAttrs.Updated.Equal(resp.Updated))
+				if test.validateData != nil {
+					test.validateData([]byte(resp.Data), t)
+				}
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// postSealedRecord() - Test Method
+func TestServer_postSealedRecord(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		nameParam        string
+		query            string
+		contentType      string
+		labelsHeader     string
+		body             []byte
+		signer           utils.Signer
+		mockKeyGenFn     func() utils.KeyGen
+		mockClientBEFn   func() utils.ClientBE
+		expectedStatus   int
+		expectedErrorMsg string
+		expectedRecord   Record
+	}{
+		{
+			name:         "should post sealed record successfully",
+			idParam:      idHexStr,
+			query:        "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType:  contentTypeOctetStream,
+			labelsHeader: "env=prod",
+			body:         clientSealedRecord,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, rec []byte, opts utils.StoreOptions) error {
+						// Verify the cipher entry is stored as posted
+						assert.Equal(t, idEnc, id)
+						assert.Nil(t, name)
+						assert.Equal(t, clientSealedRecord, rec)
+						assert.False(t, opts.OneTime)
+						assert.Nil(t, opts.KeyHash)
+						assert.Nil(t, opts.Signatures)
+
+						// Verify attributes commit to the client's key
+						meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, opts.Metadata)
+						assert.NoError(t, err)
+						assert.True(t, meta.ClientSealed)
+						assert.Equal(t, utils.KeyHash(idKey), meta.KeyHash)
+						assert.Equal(t, int64(len(record)), meta.Size)
+						assert.Equal(t, contentTypeText, meta.ContentType)
+						assert.Equal(t, labels, meta.Labels)
+						assert.Nil(t, meta.Digest)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRecord: Record{ID: idHexStr, Version: 1, ContentType: contentTypeText, Labels: labels,
+				Size: int64(len(record))},
+		},
+		{
+			name:        "should post named one-time sealed record successfully",
+			idParam:     idHexStr,
+			nameParam:   nameHexStr,
+			query:       "?" + keyHashQueryParam + "=" + keyHashHexStr + "&" + oneTimeQueryParam + "=true",
+			contentType: contentTypeOctetStream,
+			body:        clientSealedRecord,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, rec []byte, opts utils.StoreOptions) error {
+						// Verify one-time records are consumed against the commitment
+						assert.Equal(t, nameEnc, name)
+						assert.True(t, opts.OneTime)
+						assert.Equal(t, utils.KeyHash(idKey), opts.KeyHash)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRecord: Record{ID: idHexStr, Name: nameHexStr, OneTime: true, Version: 1,
+				Size: int64(len(record))},
+		},
+		{
+			name:        "should post signed sealed record successfully",
+			idParam:     idHexStr,
+			query:       "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType: contentTypeOctetStream,
+			body:        clientSealedRecord,
+			signer:      signingSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, rec []byte, opts utils.StoreOptions) error {
+						// Verify the envelope is signed over the cipher entry digest
+						content, err := signingSigner.VerifyEnvelope(id, name, opts.Metadata, opts.Signatures)
+						assert.NoError(t, err)
+						assert.Nil(t, content)
+						meta, _ := utils.OpenMetadata(metaCipher, []byte(idStr), nil, opts.Metadata)
+						assert.NoError(t, utils.VerifyDigest(rec, meta.Digest))
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRecord: Record{ID: idHexStr, Version: 1, Size: int64(len(record))},
+		},
+		{
+			name:             "should fail with JSON body",
+			idParam:          idHexStr,
+			query:            "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType:      contentTypeJSON,
+			body:             []byte("{}"),
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Sealed records must be posted as " + contentTypeOctetStream,
+		},
+		{
+			name:             "should fail without key hash",
+			idParam:          idHexStr,
+			contentType:      contentTypeOctetStream,
+			body:             clientSealedRecord,
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Sealed records require a hex SHA-256 keyHash",
+		},
+		{
+			name:             "should fail with invalid hex ID",
+			idParam:          invalidHexID,
+			query:            "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType:      contentTypeOctetStream,
+			body:             clientSealedRecord,
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:             "should fail with cipher entry too short",
+			idParam:          idHexStr,
+			query:            "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType:      contentTypeOctetStream,
+			body:             clientSealedRecord[:16],
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Sealed record too short",
+		},
+		{
+			name:        "should fail when nonce generation fails",
+			idParam:     idHexStr,
+			query:       "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType: contentTypeOctetStream,
+			body:        clientSealedRecord,
+			mockKeyGenFn: func() utils.KeyGen {
+				return &mockKeyGen{
+					randomNonceFn: func(nonceSize int) ([]byte, error) {
+						return nil, errors.New(errNonceGenFailed)
+					},
+				}
+			},
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errNonceGenFailed,
+		},
+		{
+			name:        "should fail when precondition fails",
+			idParam:     idHexStr,
+			query:       "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType: contentTypeOctetStream,
+			body:        clientSealedRecord,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, rec []byte, opts utils.StoreOptions) error {
+						return utils.ErrPreconditionFailed
+					},
+				}
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:        "should fail when backend client fails to store",
+			idParam:     idHexStr,
+			query:       "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			contentType: contentTypeOctetStream,
+			body:        clientSealedRecord,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					storeRecordFn: func(id, name, rec []byte, opts utils.StoreOptions) error {
+						return errors.New(errBackendStorageFailed)
+					},
+				}
+			},
+			expectedStatus:   http.StatusBadGateway,
+			expectedErrorMsg: errBackendStorageFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kg := keygen
+			if test.mockKeyGenFn != nil {
+				kg = test.mockKeyGenFn()
+			}
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+			server := &serverImpl{
+				keygen:     kg,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     signer,
+				beClient:   test.mockClientBEFn(),
+				serverAddr: ":" + port,
+			}
+
+			// Create request with raw cipher entry body
+			url := serverSealedPath + serverRecordsPath + "/" + test.idParam + test.query
+			req, _ := http.NewRequest(httpMethodPOST, url, bytes.NewReader(test.body))
+			req.Header.Set(contentTypeHeader, test.contentType)
+			if test.labelsHeader != "" {
+				req.Header.Set(recordContentTypeHeader, contentTypeText)
+				req.Header.Set(labelsHeader, test.labelsHeader)
+			}
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: idQueryParam, Value: test.idParam},
+				{Key: nameQueryParam, Value: test.nameParam},
+			}
+
+			// Call handler
+			server.postSealedRecord(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify the record is returned without a key
+			if test.expectedStatus == http.StatusCreated {
+				var resp Record
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRecord, resp)
+				assert.Equal(t, `"1"`, w.Header().Get(etagHeader))
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// getSealedRecord() - Test Method
+func TestServer_getSealedRecord(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		query            string
+		signer           utils.Signer
+		mockClientBEFn   func() utils.ClientBE
+		expectedStatus   int
+		expectedErrorMsg string
+	}{
+		{
+			name:    "should get sealed record successfully",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// Verify retrieval presents the key commitment
+						assert.Equal(t, idEnc, id)
+						assert.Equal(t, utils.KeyHash(idKey), opts.KeyHash)
+						return clientSealedInfo(true, noSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "should get signed sealed record successfully",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			signer:  signingSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(true, signingSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "should fail when record was sealed by the server",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(false, noSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusConflict,
+			expectedErrorMsg: "Record was not sealed by the client",
+		},
+		{
+			name:    "should fail when key hash does not match",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + hex.EncodeToString(utils.KeyHash(memberKey)),
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(true, noSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:    "should fail when one-time record key does not match",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{}, utils.ErrKeyMismatch
+					},
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:    "should fail when cipher entry does not match its signed digest",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			signer:  signingSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(true, signingSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return []byte(corruptedData), nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name:    "should fail with forbidden when record signatures do not verify",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			signer:  signingSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						info := clientSealedInfo(true, signingSigner)
+						info.Metadata = clientSealedInfo(true, noSigner).Metadata
+						return info, nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name:    "should fail on unsigned record when signatures are required",
+			idParam: idHexStr,
+			query:   "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			signer:  requireSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(true, noSigner), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						return clientSealedRecord, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureMissing.Error(),
+		},
+		{
+			name:             "should fail without key hash",
+			idParam:          idHexStr,
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Sealed records require a hex SHA-256 keyHash",
+		},
+		{
+			name:             "should fail with invalid version",
+			idParam:          idHexStr,
+			query:            "?" + keyHashQueryParam + "=" + keyHashHexStr + "&" + versionQueryParam + "=latest",
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badVersion,
+		},
+		{
+			name:             "should fail when backend client fails to retrieve",
+			idParam:          idHexStr,
+			query:            "?" + keyHashQueryParam + "=" + keyHashHexStr,
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errMockError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     signer,
+				beClient:   test.mockClientBEFn(),
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path and query parameters
+			url := serverSealedPath + serverRecordsPath + "/" + test.idParam + test.query
+			req, _ := http.NewRequest(httpMethodGET, url, nil)
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{{Key: idQueryParam, Value: test.idParam}}
+
+			// Call handler
+			server.getSealedRecord(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify the cipher entry is returned with attributes
+			if test.expectedStatus == http.StatusOK {
+				assert.Equal(t, clientSealedRecord, w.Body.Bytes())
+				assert.Equal(t, contentTypeOctetStream, w.Header().Get(contentTypeHeader))
+				assert.Equal(t, `"3"`, w.Header().Get(etagHeader))
+				assert.Equal(t, strconv.Itoa(len(record)), w.Header().Get(sizeHeader))
+				assert.Equal(t, contentTypeText, w.Header().Get(recordContentTypeHeader))
+				assert.Equal(t, "env=prod", w.Header().Get(labelsHeader))
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// headRecord() - Test Method
+func TestServer_headRecord(t *testing.T) {
+	tests := []struct {
+		name            string
+		idParam         string
+		nameParam       string
+		versionParam    string
+		signer          utils.Signer
+		mockClientBEFn  func() utils.ClientBE
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:    "should head record successfully",
+			idParam: idHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// Verify only attributes are requested
+						assert.Equal(t, idEnc, id)
+						assert.Nil(t, name)
+						assert.True(t, opts.MetadataOnly)
+						assert.Nil(t, opts.KeyHash)
+						return storedInfo(false), nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				etagHeader:              `"3"`,
+				lastModifiedHeader:      updated.UTC().Format(http.TimeFormat),
+				createdHeader:           created.UTC().Format(http.TimeFormat),
+				sizeHeader:              strconv.Itoa(len(recordStr)),
+				recordContentTypeHeader: contentTypeText,
+				labelsHeader:            "env=prod",
+			},
+		},
+		{
+			name:         "should head named prior version successfully",
+			idParam:      idHexStr,
+			nameParam:    nameHexStr,
+			versionParam: "2",
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// Verify encrypted name and version are passed
+						assert.Equal(t, nameEnc, name)
+						assert.Equal(t, int64(2), opts.Version)
+						info := storedInfo(true)
+						info.Version = opts.Version
+						return info, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				etagHeader:              `"2"`,
+				sizeHeader:              strconv.Itoa(len(recordStr)),
+				recordContentTypeHeader: contentTypeText,
+			},
+		},
+		{
+			name:    "should head legacy record without attributes",
+			idParam: idHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{Version: 1}, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				etagHeader:              `"1"`,
+				lastModifiedHeader:      "",
+				sizeHeader:              "0",
+				recordContentTypeHeader: "",
+				labelsHeader:            "",
+			},
+		},
+		{
+			name:           "should fail with invalid hex ID",
+			idParam:        invalidHexID,
+			mockClientBEFn: func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail with invalid hex name",
+			idParam:        idHexStr,
+			nameParam:      invalidHexID,
+			mockClientBEFn: func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail with invalid version",
+			idParam:        idHexStr,
+			versionParam:   "latest",
+			mockClientBEFn: func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail when backend client fails to retrieve attributes",
+			idParam:        idHexStr,
+			mockClientBEFn: func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:    "should fail with not found on missing record",
+			idParam: idHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{}, utils.ErrRecordNotFound
+					},
+				}
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:    "should fail with forbidden when record signatures do not verify",
+			idParam: idHexStr,
+			signer:  signingSigner,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						info := clientSealedInfo(false, signingSigner)
+						info.Metadata = storedInfo(false).Metadata
+						return info, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:    "should fail when record attributes are corrupted",
+			idParam: idHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{Version: 1, Metadata: []byte(corruptedData)}, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:    "should fail when record attributes are sealed under the ID cipher",
+			idParam: idHexStr,
+			mockClientBEFn: func() utils.ClientBE {
+				nonce, _ := keygen.RandomNonce(idCipher.NonceSize())
+				metadata, _ := utils.SealMetadata(idCipher, nonce, id, nil, utils.RecordMetadata{
+					ContentType: contentTypeText,
+					Size:        int64(len(record)),
+				})
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{Version: 1, Metadata: metadata}, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     signer,
 				beClient:   test.mockClientBEFn(),
 				serverAddr: ":" + port,
 			}
 
-			// Create request
-			body, _ := json.Marshal(test.requestBody)
-			req, _ := http.NewRequest(httpMethodPOST, serverRecordsPath, bytes.NewReader(body))
-			req.Header.Set(contentTypeHeader, contentTypeJSON)
+			// Create request with path and query parameters
+			url := serverRecordsPath + "/" + test.idParam
+			if test.versionParam != "" {
+				url += "?" + versionQueryParam + "=" + test.versionParam
+			}
+			req, _ := http.NewRequest(httpMethodHEAD, url, nil)
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: idQueryParam, Value: test.idParam},
+				{Key: nameQueryParam, Value: test.nameParam},
+			}
+
+			// Call handler
+			server.headRecord(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			// Verify status code, headers and empty body
+			assert.Equal(t, test.expectedStatus, w.Code)
+			for header, value := range test.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
+
+// getVersions() - Test Method
+func TestServer_getVersions(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		nameParam        string
+		mockClientBE     utils.ClientBE
+		expectedStatus   int
+		expectedVersions []int64
+		expectedErrorMsg string
+	}{
+		{
+			name:    "should list versions successfully",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listVersionsFn: func(id, name []byte) ([]int64, error) {
+					assert.NotNil(t, id)
+					return []int64{3, 2, 1}, nil
+				},
+			},
+			expectedStatus:   http.StatusOK,
+			expectedVersions: []int64{3, 2, 1},
+		},
+		{
+			name:      "should list named record versions successfully",
+			idParam:   idHexStr,
+			nameParam: nameHexStr,
+			mockClientBE: &mockClientBE{
+				listVersionsFn: func(id, name []byte) ([]int64, error) {
+					assert.Equal(t, nameEnc, name)
+					return []int64{1}, nil
+				},
+			},
+			expectedStatus:   http.StatusOK,
+			expectedVersions: []int64{1},
+		},
+		{
+			name:             "should fail with invalid hex ID",
+			idParam:          invalidHexID,
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:    "should fail when backend client fails to list versions",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listVersionsFn: func(id, name []byte) ([]int64, error) {
+					return nil, errors.New(errBackendRetrievalFailed)
+				},
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errBackendRetrievalFailed,
+		},
+		{
+			name:    "should fail with not found on missing record",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listVersionsFn: func(id, name []byte) ([]int64, error) {
+					return nil, utils.ErrRecordNotFound
+				},
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrRecordNotFound.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				beClient:   test.mockClientBE,
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path parameter
+			url := serverRecordsPath + "/" + test.idParam + "/versions"
+			req, _ := http.NewRequest(httpMethodGET, url, nil)
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: idQueryParam, Value: test.idParam},
+				{Key: nameQueryParam, Value: test.nameParam},
+			}
+
+			// Call handler
+			server.getVersions(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify response structure
+			if test.expectedStatus == http.StatusOK {
+				var resp Versions
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, test.idParam, resp.ID)
+				assert.Equal(t, test.nameParam, resp.Name)
+				assert.Equal(t, test.expectedVersions, resp.Versions)
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// getKeySlot() - Test Method
+func TestServer_getKeySlot(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		recipient        string
+		signer           utils.Signer
+		mockClientBE     utils.ClientBE
+		expectedStatus   int
+		expectedErrorMsg string
+	}{
+		{
+			name:      "should get key slot successfully",
+			idParam:   idHexStr,
+			recipient: recipientHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					// Verify only attributes are retrieved
+					assert.Equal(t, idEnc, id)
+					assert.True(t, opts.MetadataOnly)
+					return slottedInfo(), nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "should fail without a key slot for the recipient",
+			idParam:   idHexStr,
+			recipient: idHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return slottedInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrNoKeySlot.Error(),
+		},
+		{
+			name:             "should fail with invalid hex ID",
+			idParam:          invalidHexID,
+			recipient:        recipientHexStr,
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:             "should fail with invalid hex recipient",
+			idParam:          idHexStr,
+			recipient:        invalidHexID,
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:      "should fail when backend client fails to retrieve attributes",
+			idParam:   idHexStr,
+			recipient: recipientHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return utils.RecordInfo{}, errors.New(errBackendRetrievalFailed)
+				},
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errBackendRetrievalFailed,
+		},
+		{
+			name:      "should fail with a missing record",
+			idParam:   idHexStr,
+			recipient: recipientHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return utils.RecordInfo{}, utils.ErrRecordNotFound
+				},
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrRecordNotFound.Error(),
+		},
+		{
+			name:      "should fail with forbidden when record signatures do not verify",
+			idParam:   idHexStr,
+			recipient: recipientHexStr,
+			signer:    signingSigner,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					info := clientSealedInfo(false, signingSigner)
+					info.Metadata = slottedInfo().Metadata
+					return info, nil
+				},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrSignatureInvalid.Error(),
+		},
+		{
+			name:      "should fail decrypting attributes",
+			idParam:   idHexStr,
+			recipient: recipientHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					info := slottedInfo()
+					info.Metadata = info.Metadata[1:]
+					return info, nil
+				},
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: badDecryptMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer := test.signer
+			if signer == nil {
+				signer = noSigner
+			}
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     signer,
+				beClient:   test.mockClientBE,
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path parameter and recipient
+			url := serverRecordsPath + "/" + test.idParam + "/keyslot?" + recipientQueryParam + "=" + test.recipient
+			req, _ := http.NewRequest(httpMethodGET, url, nil)
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{{Key: idQueryParam, Value: test.idParam}}
+
+			// Call handler
+			server.getKeySlot(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify the slot unwraps to the record key
+			if test.expectedStatus == http.StatusOK {
+				var resp KeySlot
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, test.idParam, resp.ID)
+				assert.Equal(t, recipientHexStr, resp.Recipient)
+				assert.Equal(t, int64(3), resp.Version)
+				ephemeral, _ := hex.DecodeString(resp.Ephemeral)
+				wrapped, _ := hex.DecodeString(resp.Wrapped)
+				key, err := utils.UnwrapKey(recipientPrivate, utils.KeySlot{
+					Recipient: recipientPublic,
+					Ephemeral: ephemeral,
+					Wrapped:   wrapped,
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, idKey, key)
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// postRecipients() - Test Method
+func TestServer_postRecipients(t *testing.T) {
+	tests := []struct {
+		name             string
+		idParam          string
+		requestBody      Recipients
+		mockClientBE     utils.ClientBE
+		expectedStatus   int
+		expectedSlots    int
+		expectedErrorMsg string
+	}{
+		{
+			name:    "should add recipients with the record key successfully",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:        idKeyHexStr,
+				Recipients: []string{recipientHexStr},
+				Members:    []string{hex.EncodeToString(make([]byte, 32))},
+			},
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					assert.True(t, opts.MetadataOnly)
+					return memberInfo(), nil
+				},
+				updateMetadataFn: func(id, name, prior, metadata, signatures []byte) error {
+					// Verify every slot wraps the record key
+					assert.Equal(t, idEnc, id)
+					meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, metadata)
+					assert.NoError(t, err)
+					assert.Len(t, meta.KeySlots, 3)
+					key, err := utils.MemberKey(meta.KeySlots, make([]byte, 32))
+					assert.NoError(t, err)
+					assert.Equal(t, idKey, key)
+					slot, _ := utils.FindKeySlot(meta.KeySlots, recipientPublic)
+					key, err = utils.UnwrapKey(recipientPrivate, slot)
+					assert.NoError(t, err)
+					assert.Equal(t, idKey, key)
+					assert.Equal(t, utils.KeyHash(idKey), meta.KeyHash)
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedSlots:  3,
+		},
+		{
+			name:    "should fail to add members with a member key",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:     memberKeyHexStr,
+				Members: []string{memberKeyHexStr},
+			},
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:    "should fail with a key not granting the record key",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:     hex.EncodeToString(make([]byte, 32)),
+				Members: []string{memberKeyHexStr},
+			},
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:    "should fail on a record changed concurrently",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:     idKeyHexStr,
+				Members: []string{memberKeyHexStr},
+			},
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+				updateMetadataFn: func(id, name, prior, metadata, signatures []byte) error {
+					return utils.ErrPreconditionFailed
+				},
+			},
+			expectedStatus:   http.StatusPreconditionFailed,
+			expectedErrorMsg: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name:    "should fail with invalid member key",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:     idKeyHexStr,
+				Members: []string{idHexStr},
+			},
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: aes.KeySizeError(3).Error(),
+		},
+		{
+			name:    "should fail with invalid recipient",
+			idParam: idHexStr,
+			requestBody: Recipients{
+				Key:        idKeyHexStr,
+				Recipients: []string{idHexStr},
+			},
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badRecipientMessage,
+		},
+		{
+			name:             "should fail with invalid hex ID",
+			idParam:          invalidHexID,
+			requestBody:      Recipients{Key: idKeyHexStr},
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:             "should fail with invalid hex key",
+			idParam:          idHexStr,
+			requestBody:      Recipients{Key: invalidHexID},
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:        "should fail when backend client fails to retrieve attributes",
+			idParam:     idHexStr,
+			requestBody: Recipients{Key: idKeyHexStr},
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return utils.RecordInfo{}, errors.New(errBackendRetrievalFailed)
+				},
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errBackendRetrievalFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				beClient:   test.mockClientBE,
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path parameter and JSON body
+			body, _ := json.Marshal(test.requestBody)
+			url := serverRecordsPath + "/" + test.idParam + "/recipients"
+			req, _ := http.NewRequest(httpMethodPOST, url, bytes.NewBuffer(body))
+			req.Header.Set(contentTypeHeader, contentTypeJSON)
+
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = req
+			ctx.Params = gin.Params{{Key: idQueryParam, Value: test.idParam}}
+
+			// Call handler
+			server.postRecipients(ctx)
+
+			// Verify status code
+			assert.Equal(t, test.expectedStatus, w.Code)
+
+			// If success, verify the record key slots
+			if test.expectedStatus == http.StatusOK {
+				var resp KeySlots
+				_ = json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, test.idParam, resp.ID)
+				assert.Equal(t, int64(3), resp.Version)
+				assert.Len(t, resp.Slots, test.expectedSlots)
+				assert.Contains(t, resp.Slots, hex.EncodeToString(utils.KeySlotID(memberSlot)))
+			}
+
+			// If error, verify error message
+			if test.expectedStatus >= 400 && test.expectedErrorMsg != "" {
+				assert.Contains(t, w.Body.String(), test.expectedErrorMsg)
+			}
+		})
+	}
+}
+
+// deleteRecipient() - Test Method
+func TestServer_deleteRecipient(t *testing.T) {
+	memberSlotHexStr := hex.EncodeToString(utils.KeySlotID(memberSlot))
+
+	tests := []struct {
+		name             string
+		idParam          string
+		slotParam        string
+		keyParam         string
+		mockClientBE     utils.ClientBE
+		expectedStatus   int
+		expectedErrorMsg string
+	}{
+		{
+			name:      "should revoke a member with the record key successfully",
+			idParam:   idHexStr,
+			slotParam: memberSlotHexStr,
+			keyParam:  idKeyHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+				updateMetadataFn: func(id, name, prior, metadata, signatures []byte) error {
+					meta, err := utils.OpenMetadata(metaCipher, []byte(idStr), nil, metadata)
+					assert.NoError(t, err)
+					assert.Empty(t, meta.KeySlots)
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "should fail without a key slot",
+			idParam:   idHexStr,
+			slotParam: recipientHexStr,
+			keyParam:  idKeyHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrNoKeySlot.Error(),
+		},
+		{
+			name:      "should fail to revoke a member with a member key",
+			idParam:   idHexStr,
+			slotParam: memberSlotHexStr,
+			keyParam:  memberKeyHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:      "should fail with a key not granting the record key",
+			idParam:   idHexStr,
+			slotParam: memberSlotHexStr,
+			keyParam:  recipientHexStr,
+			mockClientBE: &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return memberInfo(), nil
+				},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:             "should fail without key",
+			idParam:          idHexStr,
+			slotParam:        memberSlotHexStr,
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "key not defined",
+		},
+		{
+			name:             "should fail with invalid hex slot",
+			idParam:          idHexStr,
+			slotParam:        invalidHexID,
+			keyParam:         idKeyHexStr,
+			mockClientBE:     &mockClientBE{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &serverImpl{
+				keygen:     keygen,
+				idNonce:    idNonce,
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				beClient:   test.mockClientBE,
+				serverAddr: ":" + port,
+			}
+
+			// Create request with path parameters and key
+			url := serverRecordsPath + "/" + test.idParam + "/recipients/" + test.slotParam
+			if test.keyParam != "" {
+				url += "?" + keyQueryParam + "=" + test.keyParam
+			}
+			req, _ := http.NewRequest(httpMethodDELETE, url, nil)
 
 			// Create response recorder
 			w := httptest.NewRecorder()
@@ -412,19 +5034,23 @@ func TestServer_postRecord(t *testing.T) {
 			// Create Gin context
 			ctx, _ := gin.CreateTestContext(w)
 			ctx.Request = req
+			ctx.Params = gin.Params{
+				{Key: idQueryParam, Value: test.idParam},
+				{Key: "slot", Value: test.slotParam},
+			}
 
 			// Call handler
-			server.postRecord(ctx)
+			server.deleteRecipient(ctx)
 
 			// Verify status code
 			assert.Equal(t, test.expectedStatus, w.Code)
 
-			// If success, verify response has key
-			if test.expectedStatus == http.StatusCreated && test.expectedHasKey {
-				var resp Record
+			// If success, verify no key slots remain
+			if test.expectedStatus == http.StatusOK {
+				var resp KeySlots
 				_ = json.Unmarshal(w.Body.Bytes(), &resp)
-				assert.NotEmpty(t, resp.Key)
-				assert.Equal(t, test.requestBody.ID, resp.ID)
+				assert.Equal(t, test.idParam, resp.ID)
+				assert.Empty(t, resp.Slots)
 			}
 
 			// If error, verify error message
@@ -435,131 +5061,129 @@ func TestServer_postRecord(t *testing.T) {
 	}
 }
 
-// getRecord() - Test Method
-func TestServer_getRecord(t *testing.T) {
+// getRecords() - Test Method
+func TestServer_getRecords(t *testing.T) {
+	created := time.UnixMilli(1700000000000).UTC()
+
 	tests := []struct {
 		name             string
 		idParam          string
-		keyParam         string
-		mockKeyGenFn     func() utils.KeyGen
-		mockClientBEFn   func(key []byte) utils.ClientBE // Pass key to mock
+		query            string
+		mockClientBE     utils.ClientBE
 		expectedStatus   int
+		expectedRecords  []RecordSummary
+		expectedNext     string
 		expectedErrorMsg string
-		validateData     func(data []byte, t *testing.T)
 	}{
 		{
-			name:     "should get record successfully",
-			idParam:  idHexStr,
-			keyParam: hex.EncodeToString(make([]byte, 32)),
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
-			},
-			mockClientBEFn: func(key []byte) utils.ClientBE {
-				return &mockClientBE{
-					retrieveRecordFn: func(id []byte) ([]byte, error) {
-						// Verify encrypted ID is passed
-						assert.NotNil(t, id)
-						// Encrypt record with the SAME key that will be used for decryption
-						cipher, _ := keygen.GetGCMCipher(key)
-						nonce, _ := keygen.RandomNonce(cipher.NonceSize())
-						return cipher.Seal(nonce, nonce, record, nil), nil
-					},
-				}
+			name:    "should list records successfully",
+			idParam: idHexStr,
+			query:   "?" + cursorQueryParam + "=abc&" + limitQueryParam + "=1",
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					// Verify encrypted ID and page options are passed
+					assert.Equal(t, idEnc, id)
+					assert.Equal(t, utils.ListOptions{Cursor: "abc", Limit: 1}, opts)
+					return []utils.RecordSummary{{Name: nameEnc, Size: 33, Created: created}}, "def", nil
+				},
 			},
 			expectedStatus: http.StatusOK,
-			validateData: func(data []byte, t *testing.T) {
-				assert.Equal(t, record, data)
-			},
+			expectedRecords: []RecordSummary{{
+				Name:    nameHexStr,
+				Size:    5,
+				Created: created,
+			}},
+			expectedNext: "def",
 		},
 		{
-			name:           "should fail when key query parameter missing",
-			idParam:        idHexStr,
-			keyParam:       "",
-			mockKeyGenFn:   func() utils.KeyGen { return keygen },
-			mockClientBEFn: func(key []byte) utils.ClientBE { return &mockClientBE{} },
-			expectedStatus: http.StatusBadRequest,
+			name:    "should list no records successfully",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					return nil, "", nil
+				},
+			},
+			expectedStatus:  http.StatusOK,
+			expectedRecords: []RecordSummary{},
 		},
 		{
 			name:             "should fail with invalid hex ID",
 			idParam:          invalidHexID,
-			keyParam:         hex.EncodeToString(make([]byte, 32)),
-			mockKeyGenFn:     func() utils.KeyGen { return keygen },
-			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			mockClientBE:     &mockClientBE{},
 			expectedStatus:   http.StatusBadRequest,
 			expectedErrorMsg: badDecode,
 		},
 		{
-			name:             "should fail with invalid hex key",
+			name:             "should fail with invalid limit",
 			idParam:          idHexStr,
-			keyParam:         invalidHexID,
-			mockKeyGenFn:     func() utils.KeyGen { return keygen },
-			mockClientBEFn:   func(key []byte) utils.ClientBE { return &mockClientBE{} },
+			query:            "?" + limitQueryParam + "=all",
+			mockClientBE:     &mockClientBE{},
 			expectedStatus:   http.StatusBadRequest,
-			expectedErrorMsg: badDecode,
+			expectedErrorMsg: badVersion,
 		},
 		{
-			name:     "should fail when backend client fails to retrieve record",
-			idParam:  idHexStr,
-			keyParam: hex.EncodeToString(make([]byte, 32)),
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
+			name:    "should fail when backend client fails to list records",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					return nil, "", errors.New(errBackendRetrievalFailed)
+				},
 			},
-			mockClientBEFn: func(key []byte) utils.ClientBE {
-				return &mockClientBE{
-					retrieveRecordFn: func(id []byte) ([]byte, error) {
-						return nil, errors.New(errBackendRetrievalFailed)
-					},
-				}
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errBackendRetrievalFailed,
+		},
+		{
+			name:    "should fail with a missing user ID",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					return nil, "", utils.ErrRecordNotFound
+				},
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus:   http.StatusNotFound,
+			expectedErrorMsg: utils.ErrRecordNotFound.Error(),
 		},
 		{
-			name:     "should fail when decryption fails (corrupted data)",
-			idParam:  idHexStr,
-			keyParam: hex.EncodeToString(make([]byte, 32)),
-			mockKeyGenFn: func() utils.KeyGen {
-				return keygen
+			name:    "should fail on a failed precondition",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					return nil, "", utils.ErrPreconditionFailed
+				},
 			},
-			mockClientBEFn: func(key []byte) utils.ClientBE {
-				return &mockClientBE{
-					retrieveRecordFn: func(id []byte) ([]byte, error) {
-						return []byte(corruptedData), nil
-					},
-				}
+			expectedStatus:   http.StatusPreconditionFailed,
+			expectedErrorMsg: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name:    "should fail when name decryption fails",
+			idParam: idHexStr,
+			mockClientBE: &mockClientBE{
+				listRecordsFn: func(id []byte, opts utils.ListOptions) ([]utils.RecordSummary, string, error) {
+					return []utils.RecordSummary{{Name: []byte(corruptedData)}}, "", nil
+				},
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: badDecryptMessage,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			kg := test.mockKeyGenFn()
-			idCipherTest, _ := kg.GetGCMCipher([]byte(idKeyStr))
-			if idCipherTest == nil {
-				idCipherTest = idCipher
-			}
-
-			// Decode the key parameter to use in mock backend
-			var keyBytes []byte
-			if test.keyParam != "" {
-				keyBytes, _ = hex.DecodeString(test.keyParam)
-			} else {
-				keyBytes = make([]byte, 32)
-			}
-
 			server := &serverImpl{
-				keygen:     kg,
+				keygen:     keygen,
 				idNonce:    idNonce,
-				idCipher:   idCipherTest,
-				beClient:   test.mockClientBEFn(keyBytes), // Pass key to mock
+				idCipher:   idCipher,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				beClient:   test.mockClientBE,
 				serverAddr: ":" + port,
 			}
 
 			// Create request with path and query parameters
-			url := serverRecordsPath + "/" + test.idParam
-			if test.keyParam != "" {
-				url += "?" + keyQueryParam + "=" + test.keyParam
-			}
+			url := "/users/" + test.idParam + serverRecordsPath + test.query
 			req, _ := http.NewRequest(httpMethodGET, url, nil)
 
 			// Create response recorder
@@ -573,20 +5197,18 @@ func TestServer_getRecord(t *testing.T) {
 			}
 
 			// Call handler
-			server.getRecord(ctx)
+			server.getRecords(ctx)
 
 			// Verify status code
 			assert.Equal(t, test.expectedStatus, w.Code)
 
 			// If success, verify response structure
 			if test.expectedStatus == http.StatusOK {
-				var resp Record
+				var resp RecordList
 				_ = json.Unmarshal(w.Body.Bytes(), &resp)
 				assert.Equal(t, test.idParam, resp.ID)
-				assert.Equal(t, test.keyParam, resp.Key)
-				if test.validateData != nil {
-					test.validateData([]byte(resp.Data), t)
-				}
+				assert.Equal(t, test.expectedRecords, resp.Records)
+				assert.Equal(t, test.expectedNext, resp.Next)
 			}
 
 			// If error, verify error message
@@ -599,48 +5221,259 @@ func TestServer_getRecord(t *testing.T) {
 
 // deleteRecord() - Test Method
 func TestServer_deleteRecord(t *testing.T) {
+
+	// Back-end client holding the given record attributes and cipher entry,
+	// expecting a delete conditioned on the given revision
+	recordClient := func(info utils.RecordInfo, sealed []byte, match utils.Precondition) func() utils.ClientBE {
+		return func() utils.ClientBE {
+			return &mockClientBE{
+				retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+					return info, nil
+				},
+				retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+					return sealed, nil
+				},
+				deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+					assert.Equal(t, match, opts.Match)
+					return nil
+				},
+			}
+		}
+	}
+
 	tests := []struct {
 		name             string
 		idParam          string
+		nameParam        string
+		keyParam         string
+		sharesParam      []string
+		apiKey           string
+		auth             utils.Authenticator
+		headers          map[string]string
 		mockKeyGenFn     func() utils.KeyGen
 		mockClientBEFn   func() utils.ClientBE
 		expectedStatus   int
 		expectedErrorMsg string
 	}{
 		{
-			name:    "should delete record successfully",
+			name:     "should delete record successfully",
+			idParam:  idHexStr,
+			keyParam: idKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						// Verify attributes are read without consuming the record
+						assert.True(t, opts.MetadataOnly)
+						return clientSealedInfo(false, noSigner), nil
+					},
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+						// Verify encrypted ID is passed, and the delete applies
+						// to the revision the key was proven against
+						assert.Equal(t, idEnc, id)
+						assert.Equal(t, utils.Precondition{IfMatch: 3}, opts.Match)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "should delete record with key shares successfully",
+			idParam:     idHexStr,
+			sharesParam: idKeyShares[:2],
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: recordClient(clientSealedInfo(false, noSigner), nil, utils.Precondition{IfMatch: 3}),
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:     "should fail to delete record with member key",
+			idParam:  idHexStr,
+			keyParam: memberKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return memberInfo(), nil
+					},
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+						t.Error("member key must not delete the record")
+						return nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:      "should delete named record without key commitment successfully",
+			idParam:   idHexStr,
+			nameParam: nameHexStr,
+			keyParam:  idKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return storedInfo(true), nil
+					},
+					retrieveRecordFn: func(id, name []byte, opts utils.RetrieveOptions) ([]byte, error) {
+						// Verify the key is proven by opening the cipher entry
+						assert.Equal(t, utils.KeyHash(idKey), opts.KeyHash)
+						return clientSealedRecord, nil
+					},
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+						// Verify encrypted name is passed
+						assert.Equal(t, nameEnc, name)
+						return nil
+					},
+				}
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:    "should delete record without key under admin API key",
 			idParam: idHexStr,
+			apiKey:  adminAPIKey,
+			auth:    keyAuth,
 			mockKeyGenFn: func() utils.KeyGen {
 				return keygen
 			},
 			mockClientBEFn: func() utils.ClientBE {
 				return &mockClientBE{
-					deleteRecordFn: func(id []byte) error {
-						// Verify encrypted ID is passed
-						assert.NotNil(t, id)
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+						// Verify no revision is imposed without a key proof
+						assert.Equal(t, utils.Precondition{}, opts.Match)
 						return nil
 					},
 				}
 			},
 			expectedStatus: http.StatusAccepted,
 		},
+		{
+			name:             "should fail without key",
+			idParam:          idHexStr,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "key not defined",
+		},
+		{
+			name:             "should fail without key under non-admin API key",
+			idParam:          idHexStr,
+			apiKey:           storeAPIKey,
+			auth:             keyAuth,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "key not defined",
+		},
+		{
+			name:             "should fail with mismatched key",
+			idParam:          idHexStr,
+			keyParam:         memberKeyHexStr,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   recordClient(clientSealedInfo(false, noSigner), nil, utils.Precondition{}),
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:             "should fail with mismatched key without key commitment",
+			idParam:          idHexStr,
+			keyParam:         memberKeyHexStr,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   recordClient(storedInfo(false), clientSealedRecord, utils.Precondition{}),
+			expectedStatus:   http.StatusForbidden,
+			expectedErrorMsg: utils.ErrKeyMismatch.Error(),
+		},
+		{
+			name:     "should fail when revision does not match",
+			idParam:  idHexStr,
+			keyParam: idKeyHexStr,
+			headers:  map[string]string{ifMatchHeader: `"2"`},
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(false, noSigner), nil
+					},
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
+						// Verify revision precondition is forwarded
+						assert.Equal(t, utils.Precondition{IfMatch: 2}, opts.Match)
+						return utils.ErrPreconditionFailed
+					},
+				}
+			},
+			expectedStatus:   http.StatusPreconditionFailed,
+			expectedErrorMsg: utils.ErrPreconditionFailed.Error(),
+		},
+		{
+			name:             "should fail with malformed If-Match",
+			idParam:          idHexStr,
+			keyParam:         idKeyHexStr,
+			headers:          map[string]string{ifMatchHeader: "2"},
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "Malformed entity tag 2",
+		},
 		{
 			name:             "should fail with invalid hex ID",
 			idParam:          invalidHexID,
+			keyParam:         idKeyHexStr,
 			mockKeyGenFn:     func() utils.KeyGen { return keygen },
 			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
 			expectedStatus:   http.StatusBadRequest,
 			expectedErrorMsg: badDecode,
 		},
 		{
-			name:    "should fail when backend client fails to delete record",
-			idParam: idHexStr,
+			name:             "should fail with invalid hex key",
+			idParam:          idHexStr,
+			keyParam:         invalidHexID,
+			mockKeyGenFn:     func() utils.KeyGen { return keygen },
+			mockClientBEFn:   func() utils.ClientBE { return &mockClientBE{} },
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: badDecode,
+		},
+		{
+			name:     "should fail when backend client fails to read record attributes",
+			idParam:  idHexStr,
+			keyParam: idKeyHexStr,
+			mockKeyGenFn: func() utils.KeyGen {
+				return keygen
+			},
+			mockClientBEFn: func() utils.ClientBE {
+				return &mockClientBE{
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return utils.RecordInfo{}, errors.New(errBackendRetrievalFailed)
+					},
+				}
+			},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedErrorMsg: errBackendRetrievalFailed,
+		},
+		{
+			name:     "should fail when backend client fails to delete record",
+			idParam:  idHexStr,
+			keyParam: idKeyHexStr,
 			mockKeyGenFn: func() utils.KeyGen {
 				return keygen
 			},
 			mockClientBEFn: func() utils.ClientBE {
 				return &mockClientBE{
-					deleteRecordFn: func(id []byte) error {
+					retrieveInfoFn: func(id, name []byte, opts utils.RetrieveOptions) (utils.RecordInfo, error) {
+						return clientSealedInfo(false, noSigner), nil
+					},
+					deleteRecordFn: func(id, name []byte, opts utils.DeleteOptions) error {
 						return errors.New(errBackendDeletionFailed)
 					},
 				}
@@ -654,16 +5487,40 @@ func TestServer_deleteRecord(t *testing.T) {
 			kg := test.mockKeyGenFn()
 			idCipherTest, _ := kg.GetGCMCipher([]byte(idKeyStr))
 
+			auth := test.auth
+			if auth == nil {
+				auth = noAuth
+			}
+
 			server := &serverImpl{
 				keygen:     kg,
 				idNonce:    idNonce,
 				idCipher:   idCipherTest,
+				nameKey:    nameKey,
+				metaCipher: metaCipher,
+				compressor: noCompressor,
+				padder:     noPadder,
+				signer:     noSigner,
+				auth:       auth,
 				beClient:   test.mockClientBEFn(),
 				serverAddr: ":" + port,
 			}
 
-			// Create request
-			req, _ := http.NewRequest(httpMethodDELETE, "/records/"+test.idParam, nil)
+			// Create request with the key or key shares
+			query := url.Values{}
+			if test.keyParam != "" {
+				query.Set(keyQueryParam, test.keyParam)
+			}
+			for _, share := range test.sharesParam {
+				query.Add(shareQueryParam, share)
+			}
+			req, _ := http.NewRequest(httpMethodDELETE, "/records/"+test.idParam+"?"+query.Encode(), nil)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+			if test.apiKey != "" {
+				req.Header.Set(apiKeyHeader, test.apiKey)
+			}
 
 			// Create response recorder
 			w := httptest.NewRecorder()
@@ -673,6 +5530,7 @@ func TestServer_deleteRecord(t *testing.T) {
 			ctx.Request = req
 			ctx.Params = gin.Params{
 				{Key: idQueryParam, Value: test.idParam},
+				{Key: nameQueryParam, Value: test.nameParam},
 			}
 
 			// Call handler